@@ -0,0 +1,74 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// AckText is the message posted by Ack while the work is running.
+var AckText = "Working on it… :hourglass_flowing_sand:"
+
+// AckDoneText replaces the acknowledgement when the work succeeds without a result.
+var AckDoneText = "Done :white_check_mark:"
+
+// AckReply is the acknowledgement posted by Ack, edited with the outcome of the work.
+type AckReply struct {
+	msg  *liveMessage
+	text string
+}
+
+// Ack immediately replies to the message event with AckText, and returns the reply to edit
+// with the outcome of the work once done, see AckReply.Done. Route.AckHandler runs the work
+// in its own goroutine in between.
+func (b *Bot) Ack(evt *slack.MessageEvent) *AckReply {
+	a := &AckReply{text: AckText}
+	a.msg = newLiveMessage(b, evt.Channel, func(msg *OutgoingMessage) {
+		msg.Text = a.text
+	})
+	a.msg.mu.Lock()
+	defer a.msg.mu.Unlock()
+	if err := a.msg.updateLocked(true); err != nil {
+		fmt.Printf("Error acknowledging message: %s\n", err)
+	}
+	return a
+}
+
+// Done edits the acknowledgement with the result, or the error if any. An empty result is
+// reported with AckDoneText.
+func (a *AckReply) Done(result string, err error) {
+	switch {
+	case err != nil:
+		result = fmt.Sprintf(":x: %s", err)
+	case result == "":
+		result = AckDoneText
+	}
+	a.msg.mu.Lock()
+	defer a.msg.mu.Unlock()
+	a.text = result
+	if err := a.msg.updateLocked(true); err != nil {
+		fmt.Printf("Error updating acknowledged message: %s\n", err)
+	}
+}
+
+// AckHandler sets a handler whose work is acknowledged first, run in its own goroutine and
+// reported once done. Errors are reported with HandleError, and the outcome of the work is
+// the one recorded by a CircuitBreaker of the route.
+func (r *Route) AckHandler(fn AckHandler) *Route {
+	return r.Handler(func(ctx context.Context) {
+		bot, evt := BotFromContext(ctx), MessageFromContext(ctx)
+		ack := bot.Ack(evt)
+		finish := finishLater(ctx)
+		go func() {
+			result, err := fn(ctx, bot, evt)
+			if err != nil {
+				bot.HandleError(ctx, err)
+			} else {
+				RecordResult(ctx, result)
+			}
+			finish()
+			ack.Done(result, err)
+		}()
+	})
+}
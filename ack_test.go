@@ -0,0 +1,39 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAck(t *testing.T) {
+	assert := assert.New(t)
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+
+	for result, text := range map[string]string{"deployed": "deployed", "": AckDoneText} {
+		bot := New("")
+		api := newSlackAPI(t, bot)
+		bot.Ack(evt).Done(result, nil)
+		assert.Equal([]string{"chat.postMessage " + AckText, "chat.update " + text}, api.calls("text"))
+	}
+}
+
+func TestAckHandler(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	bot.Hear("^deploy$").AckHandler(func(ctx context.Context, bot *Bot, msg *slack.MessageEvent) (string, error) {
+		return "", errors.New("no such service")
+	})
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", "deploy"
+	bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+
+	api.wait("chat.update", 1)
+	assert.Equal([]string{AckText}, api.values("chat.postMessage", "text"))
+	assert.Equal([]string{":x: no such service"}, api.values("chat.update", "text"))
+}
@@ -0,0 +1,234 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// AckStatus tells who acknowledged an announcement.
+type AckStatus struct {
+	Acked   []string
+	Pending []string
+	// Done is set once every user acknowledged, or the deadline passed
+	Done bool
+}
+
+// AckOption configures RequireAck.
+type AckOption func(a *Acknowledgement)
+
+// NagEvery reminds the users who did not acknowledge yet, by direct message, at the interval.
+func NagEvery(interval time.Duration) AckOption {
+	return func(a *Acknowledgement) { a.nagEvery = interval }
+}
+
+// OnAcked calls fn once every user acknowledged, or when the deadline passes.
+func OnAcked(fn func(status AckStatus)) AckOption {
+	return func(a *Acknowledgement) { a.onDone = fn }
+}
+
+// Acknowledgement tracks the users acknowledging an announcement.
+type Acknowledgement struct {
+	bot      *Bot
+	channel  string
+	ts       string
+	text     string
+	actionID string
+	nagEvery time.Duration
+	onDone   func(status AckStatus)
+
+	mu    sync.Mutex
+	users []string
+	acked map[string]bool
+	done  bool
+	timer *time.Timer
+	stop  chan struct{}
+}
+
+// RequireAck posts the announcement to the channel, given by ID, with an "Acknowledge"
+// button which the users are asked to click before the deadline. The message shows how many
+// users acknowledged, and once all of them did, or at the deadline, the outcome is reported
+// in its thread. Users listed twice are counted once, and an announcement to nobody is done
+// as soon as it is posted. Clicks are received through the InteractionHandler.
+//
+//	bot.RequireAck(oncallChannelID, "Rotation changes on Monday.", team, time.Now().Add(48*time.Hour),
+//		slackbot.NagEvery(12*time.Hour))
+func (b *Bot) RequireAck(channel, msg string, users []string, deadline time.Time, opts ...AckOption) (*Acknowledgement, error) {
+	a := &Acknowledgement{
+		bot:      b,
+		channel:  channel,
+		text:     msg,
+		actionID: newActionID("ack"),
+		acked:    make(map[string]bool),
+		stop:     make(chan struct{}),
+	}
+	for _, u := range users {
+		if !containsString(a.users, u) {
+			a.users = append(a.users, u)
+		}
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	a.mu.Lock()
+	a.done = len(a.users) == 0
+	out := a.messageLocked()
+	out.Params = slack.PostMessageParameters{AsUser: true}
+	ts, err := b.Send(out)
+	if err != nil {
+		a.mu.Unlock()
+		return nil, err
+	}
+	a.ts = ts
+	if a.done {
+		// nobody to wait for
+		close(a.stop)
+		status := a.statusLocked()
+		a.mu.Unlock()
+		if a.onDone != nil {
+			a.onDone(status)
+		}
+		return a, nil
+	}
+	b.OnAction(a.actionID, func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		a.ack(callback.User.ID)
+	})
+	a.timer = time.AfterFunc(time.Until(deadline), a.finish)
+	if a.nagEvery > 0 {
+		go a.nag()
+	}
+	a.mu.Unlock()
+	return a, nil
+}
+
+// Status returns who acknowledged so far.
+func (a *Acknowledgement) Status() AckStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.statusLocked()
+}
+
+func (a *Acknowledgement) statusLocked() AckStatus {
+	status := AckStatus{Done: a.done}
+	for _, u := range a.users {
+		if a.acked[u] {
+			status.Acked = append(status.Acked, u)
+		} else {
+			status.Pending = append(status.Pending, u)
+		}
+	}
+	return status
+}
+
+func (a *Acknowledgement) messageLocked() *OutgoingMessage {
+	status := a.statusLocked()
+	count := fmt.Sprintf("%d of %d acknowledged", len(status.Acked), len(a.users))
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, a.text, false, false), nil, nil),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, count, false, false)),
+	}
+	if !a.done {
+		button := slack.NewButtonBlockElement(a.actionID, "ack", slack.NewTextBlockObject(slack.PlainTextType, "Acknowledge", false, false))
+		button.Style = slack.StylePrimary
+		blocks = append(blocks, slack.NewActionBlock("", button))
+	}
+	return &OutgoingMessage{Channel: a.channel, Timestamp: a.ts, Text: a.text, Blocks: blocks}
+}
+
+// ack records the acknowledgement of the user, if expected.
+func (a *Acknowledgement) ack(user string) {
+	a.mu.Lock()
+	if a.done || a.acked[user] || !containsString(a.users, user) {
+		a.mu.Unlock()
+		return
+	}
+	a.acked[user] = true
+	complete := len(a.acked) == len(a.users)
+	msg := a.messageLocked()
+	a.mu.Unlock()
+
+	if complete {
+		a.finish()
+		return
+	}
+	if _, err := a.bot.Send(msg); err != nil {
+		fmt.Printf("Error updating acknowledgements: %s\n", err)
+	}
+}
+
+// nag reminds the pending users until the acknowledgement is done.
+func (a *Acknowledgement) nag() {
+	ticker := time.NewTicker(a.nagEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		}
+		status := a.Status()
+		if status.Done {
+			return
+		}
+		link, err := a.bot.Permalink(a.channel, a.ts)
+		if err != nil {
+			link = fmt.Sprintf("<#%s>", a.channel)
+		}
+		for _, user := range status.Pending {
+			if res := a.bot.DM(user, fmt.Sprintf("Reminder: please acknowledge %s", link)); res.Err != nil {
+				fmt.Printf("Error reminding %s: %s\n", user, res.Err)
+			}
+		}
+	}
+}
+
+// finish reports the outcome, once.
+func (a *Acknowledgement) finish() {
+	a.mu.Lock()
+	if a.done {
+		a.mu.Unlock()
+		return
+	}
+	a.done = true
+	a.timer.Stop()
+	close(a.stop)
+	status := a.statusLocked()
+	msg := a.messageLocked()
+	a.mu.Unlock()
+
+	a.bot.RemoveAction(a.actionID)
+	if _, err := a.bot.Send(msg); err != nil {
+		fmt.Printf("Error updating acknowledgements: %s\n", err)
+	}
+	report := fmt.Sprintf("All %d acknowledged.", len(status.Acked))
+	if len(status.Pending) > 0 {
+		mentions := make([]string, len(status.Pending))
+		for i, u := range status.Pending {
+			mentions[i] = fmt.Sprintf("<@%s>", u)
+		}
+		report = fmt.Sprintf("Deadline passed, %d of %d did not acknowledge: %s",
+			len(status.Pending), len(a.users), strings.Join(mentions, ", "))
+	}
+	_, _ = a.bot.Send(&OutgoingMessage{
+		Channel: a.channel,
+		Text:    report,
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: a.ts},
+	})
+	if a.onDone != nil {
+		a.onDone(status)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireAck(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	done := make(chan AckStatus, 1)
+	a, err := bot.RequireAck("C1", "Rotation changes on Monday.", []string{"U1", "U2"}, time.Now().Add(time.Hour),
+		OnAcked(func(status AckStatus) { done <- status }))
+	assert.NoError(err)
+
+	a.ack("U3")
+	a.ack("U1")
+	a.ack("U1")
+	assert.Equal(AckStatus{Acked: []string{"U1"}, Pending: []string{"U2"}}, a.Status())
+	a.ack("U2")
+	assert.Equal(AckStatus{Acked: []string{"U1", "U2"}, Done: true}, <-done)
+
+	assert.Equal([]string{"chat.postMessage ", "chat.update ", "chat.update ", "chat.postMessage 1.0"}, api.calls("thread_ts"))
+}
+
+func TestRequireAckDeadline(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	newSlackAPI(t, bot)
+	done := make(chan AckStatus, 1)
+	a, err := bot.RequireAck("C1", "Read the runbook.", []string{"U1", "U2"}, time.Now().Add(20*time.Millisecond),
+		OnAcked(func(status AckStatus) { done <- status }))
+	assert.NoError(err)
+	a.ack("U2")
+	assert.Equal(AckStatus{Acked: []string{"U2"}, Pending: []string{"U1"}, Done: true}, <-done)
+}
+
+func TestRequireAckUsers(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	done := make(chan AckStatus, 1)
+	a, err := bot.RequireAck("C1", "Read the runbook.", []string{"U1", "U1", "U2"}, time.Now().Add(time.Hour),
+		OnAcked(func(status AckStatus) { done <- status }))
+	assert.NoError(err)
+	// users listed twice are counted once
+	a.ack("U1")
+	a.ack("U2")
+	assert.Equal(AckStatus{Acked: []string{"U1", "U2"}, Done: true}, <-done)
+
+	// announcements to nobody are done at once
+	a, err = bot.RequireAck("C1", "Nothing to read.", nil, time.Now().Add(time.Hour),
+		OnAcked(func(status AckStatus) { done <- status }))
+	assert.NoError(err)
+	assert.Equal(AckStatus{Done: true}, <-done)
+	assert.Equal(AckStatus{Done: true}, a.Status())
+	assert.Len(api.values("chat.postMessage", "channel"), 3)
+}
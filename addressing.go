@@ -0,0 +1,59 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const ADDRESSED_CONTEXT = "__ADDRESSED_CONTEXT__"
+
+// CommandPrefix makes Hear routes match channel messages only when they start with the
+// prefix, e.g. "!deploy", or mention the bot. Direct messages still match bare text.
+func (b *Bot) CommandPrefix(prefix string) *Bot {
+	b.commandPrefix = prefix
+	return b
+}
+
+// RespondOnlyWhenAddressed makes Hear routes match channel messages only when they mention
+// the bot, or start with the command prefix. Direct messages still match bare text.
+func (b *Bot) RespondOnlyWhenAddressed() *Bot {
+	b.onlyWhenAddressed = true
+	return b
+}
+
+// IsAddressed returns true if the message in context is addressed to the bot: sent in a
+// direct message, mentioning the bot or starting with the command prefix.
+func IsAddressed(ctx context.Context) bool {
+	addressed, _ := ctx.Value(ADDRESSED_CONTEXT).(bool)
+	return addressed
+}
+
+// requiresAddress returns true when Hear routes only match messages addressed to the bot.
+func (b *Bot) requiresAddress() bool {
+	return b.onlyWhenAddressed || b.commandPrefix != ""
+}
+
+// addAddressingToContext records whether the message is addressed to the bot and strips
+// the command prefix from the text routes are matched against.
+func (b *Bot) addAddressingToContext(ctx context.Context, evt *slack.MessageEvent) context.Context {
+	text := TextFromContext(ctx)
+	addressed := IsDirectMessage(evt) || b.isMentioned(evt)
+	if b.commandPrefix != "" && strings.HasPrefix(text, b.commandPrefix) {
+		addressed = true
+		text = strings.TrimSpace(strings.TrimPrefix(text, b.commandPrefix))
+	}
+	ctx = AddTextToContext(ctx, text)
+	return context.WithValue(ctx, ADDRESSED_CONTEXT, addressed)
+}
+
+// isMentioned returns true if the message mentions the bot, by ID or name.
+func (b *Bot) isMentioned(evt *slack.MessageEvent) bool {
+	for _, id := range []string{b.botUserID, b.botEnterpriseID, b.botUserName} {
+		if id != "" && IsMentioned(evt, id) {
+			return true
+		}
+	}
+	return false
+}
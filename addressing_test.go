@@ -0,0 +1,50 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func addressedMessage(channel, text string) *slack.MessageEvent {
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = channel, "U1", text
+	return evt
+}
+
+func TestCommandPrefix(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").CommandPrefix("!")
+	bot.botUserID = "UBOT"
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^deploy (.*)$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, evt.Channel+" "+evt.Text)
+	})
+
+	bot.handleMessage(ctx, addressedMessage("C1", "deploy web"))
+	bot.handleMessage(ctx, addressedMessage("C1", "!deploy web"))
+	bot.handleMessage(ctx, addressedMessage("C1", "<@UBOT> deploy db"))
+	bot.handleMessage(ctx, addressedMessage("D1", "deploy api"))
+	assert.Equal([]string{"C1 !deploy web", "C1 <@UBOT> deploy db", "D1 deploy api"}, heard)
+}
+
+func TestRespondOnlyWhenAddressed(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").RespondOnlyWhenAddressed()
+	bot.botUserID = "UBOT"
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^status$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		assert.True(IsAddressed(ctx))
+		heard = append(heard, evt.Channel)
+	})
+
+	bot.handleMessage(ctx, addressedMessage("C1", "status"))
+	bot.handleMessage(ctx, addressedMessage("C1", "!status"))
+	bot.handleMessage(ctx, addressedMessage("C2", "<@UBOT> status"))
+	bot.handleMessage(ctx, addressedMessage("D1", "status"))
+	assert.Equal([]string{"C2", "D1"}, heard)
+}
@@ -0,0 +1,58 @@
+package slackbot
+
+import "context"
+
+// AdminOnlyText is the reply sent when a non admin user invokes an admin only route.
+var AdminOnlyText = "Sorry, only bot admins can do that."
+
+// WorkspaceAdminOnlyText is the reply sent when a user who is neither a bot admin nor an
+// admin of the workspace invokes a workspace admin only route.
+var WorkspaceAdminOnlyText = "Sorry, only the admins of this workspace can do that."
+
+// SetAdmins sets the Slack user IDs allowed to run admin commands.
+func (b *Bot) SetAdmins(userIDs ...string) *Bot {
+	b.admins = make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		b.admins[id] = true
+	}
+	return b
+}
+
+// IsAdmin returns true if the user is a bot admin.
+func (b *Bot) IsAdmin(userID string) bool {
+	return b.admins[userID]
+}
+
+// AdminOnly restricts the route to bot admins. Other users receive AdminOnlyText.
+func (r *Route) AdminOnly() *Route {
+	r.admin = true
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			msg := MessageFromContext(ctx)
+			if !bot.IsAdmin(msg.User) {
+				bot.Reply(msg, AdminOnlyText)
+				return
+			}
+			next(ctx)
+		}
+	})
+}
+
+// WorkspaceAdminOnly restricts the route to bot admins and to the admins of the workspace in
+// context, for commands only affecting the workspace. Other users receive
+// WorkspaceAdminOnlyText.
+func (r *Route) WorkspaceAdminOnly() *Route {
+	r.admin = true
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			msg := MessageFromContext(ctx)
+			if !bot.IsAdmin(msg.User) && !WorkspaceConfig(ctx).IsAdmin(msg.User) {
+				bot.Reply(msg, WorkspaceAdminOnlyText)
+				return
+			}
+			next(ctx)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminOnly(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetAdmins("U1")
+	api := newSlackAPI(t, bot)
+	bot.DefaultWorkspace(Workspace{Admins: []string{"U2"}})
+	assert.True(bot.IsAdmin("U1"))
+	assert.False(bot.IsAdmin("U2"))
+
+	var ran []string
+	bot.Hear("shutdown").AdminOnly().Handler(func(ctx context.Context) {
+		ran = append(ran, MessageFromContext(ctx).User+" shutdown")
+	})
+	bot.Hear("purge").WorkspaceAdminOnly().Handler(func(ctx context.Context) {
+		ran = append(ran, MessageFromContext(ctx).User+" purge")
+	})
+	for _, text := range []string{"shutdown", "purge"} {
+		for _, user := range []string{"U1", "U2", "U3"} {
+			evt := &slack.MessageEvent{}
+			evt.Channel, evt.User, evt.Text = "C1", user, text
+			bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+		}
+	}
+	assert.Equal([]string{"U1 shutdown", "U1 purge", "U2 purge"}, ran)
+	assert.Equal([]string{AdminOnlyText, AdminOnlyText, WorkspaceAdminOnlyText}, api.values("chat.postMessage", "text"))
+}
@@ -0,0 +1,87 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// AlertConfig configures a keyword alert.
+type AlertConfig struct {
+	// Regular expressions watched for, each with its own cooldown
+	Keywords []string
+	// Channel IDs watched, all channels the bot is in when empty
+	Channels []string
+	// Users receiving the alert by direct message
+	NotifyUsers []string
+	// Channel receiving the alert
+	NotifyChannel string
+	// Minimum delay between two alerts for the same keyword
+	Cooldown time.Duration
+}
+
+// Alert watches messages for keywords and notifies users or a channel with the message and
+// its permalink. It returns an error, watching nothing, if a keyword is not a valid regular
+// expression.
+func (b *Bot) Alert(cfg AlertConfig) error {
+	keywords := make([]*regexp.Regexp, len(cfg.Keywords))
+	for i, k := range cfg.Keywords {
+		re, err := regexp.Compile(k)
+		if err != nil {
+			return fmt.Errorf("slackbot: alert keyword %q: %s", k, err)
+		}
+		keywords[i] = re
+	}
+	b.RequireScopes("chat:write", "im:write")
+	channels := make(map[string]bool, len(cfg.Channels))
+	for _, c := range cfg.Channels {
+		channels[c] = true
+	}
+
+	var mu sync.Mutex
+	lastAlert := make(map[string]time.Time)
+
+	b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.BotID != "" || (len(channels) > 0 && !channels[msg.Channel]) {
+			return
+		}
+		for i, k := range keywords {
+			if !k.MatchString(msg.Text) {
+				continue
+			}
+			mu.Lock()
+			cooling := time.Since(lastAlert[cfg.Keywords[i]]) < cfg.Cooldown
+			if !cooling {
+				lastAlert[cfg.Keywords[i]] = time.Now()
+			}
+			mu.Unlock()
+			if !cooling {
+				bot.sendAlert(cfg, cfg.Keywords[i], msg)
+			}
+		}
+	})
+	return nil
+}
+
+func (b *Bot) sendAlert(cfg AlertConfig, keyword string, msg *slack.MessageEvent) {
+	text := fmt.Sprintf(":rotating_light: `%s` was mentioned.\n%s", keyword, b.quote(msg))
+	for _, user := range cfg.NotifyUsers {
+		if result := b.DM(user, text); result.Err != nil {
+			fmt.Printf("Error alerting %s: %s\n", user, result.Err)
+		}
+	}
+	if cfg.NotifyChannel != "" {
+		if _, err := b.Send(&OutgoingMessage{
+			Channel: cfg.NotifyChannel,
+			Text:    text,
+			Params:  slack.PostMessageParameters{AsUser: true},
+		}); err != nil {
+			fmt.Printf("Error alerting %s: %s\n", cfg.NotifyChannel, err)
+		}
+	}
+}
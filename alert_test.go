@@ -0,0 +1,47 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlert(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.open", `{"ok": true, "channel": {"id": "D1"}}`)
+	api.respond("chat.getPermalink", `{"ok": false, "error": "message_not_found"}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	assert.EqualError(bot.Alert(AlertConfig{Keywords: []string{"(?i)outage", "sev(1"}, NotifyChannel: "CALERTS"}),
+		"slackbot: alert keyword \"sev(1\": error parsing regexp: missing closing ): `sev(1`")
+	assert.NoError(bot.Alert(AlertConfig{
+		Keywords:      []string{"(?i)outage", "(?i)sev ?1"},
+		Channels:      []string{"C1"},
+		NotifyUsers:   []string{"UONCALL"},
+		NotifyChannel: "CALERTS",
+		Cooldown:      time.Hour,
+	}))
+
+	for _, m := range []struct{ channel, text string }{
+		{"C1", "Outage in eu-west"},
+		{"C1", "still an outage"},
+		{"C2", "sev1 in C2"},
+		{"C1", "all good"},
+		{"C1", "declaring a SEV 1"},
+	} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = m.channel, "U1", m.text
+		bot.handleMessage(ctx, evt)
+	}
+
+	// each keyword alerts once per cooldown, to both the users and the channel
+	assert.Equal([]string{"D1", "CALERTS", "D1", "CALERTS"}, api.values("chat.postMessage", "channel"))
+	texts := api.values("chat.postMessage", "text")
+	assert.True(strings.HasPrefix(texts[0], ":rotating_light: `(?i)outage` was mentioned.\n<@U1> in <#C1>:\n> Outage in eu-west"))
+	assert.True(strings.HasPrefix(texts[2], ":rotating_light: `(?i)sev ?1` was mentioned."))
+}
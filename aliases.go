@@ -0,0 +1,138 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const aliasesStoreKey = "aliases"
+
+// Aliases declares synonyms of the command word of the route. When a message starts with
+// one of the aliases, the route sees the command word instead:
+//
+//	bot.Hear("^deploy (\\w+)$").Aliases("deploy", "ship", "release").MessageHandler(DeployHandler)
+func (r *Route) Aliases(command string, aliases ...string) *Route {
+	if r.err != nil {
+		return r
+	}
+	if r.aliases == nil {
+		r.aliases = make(map[string]string)
+	}
+	for _, alias := range aliases {
+		r.aliases[strings.ToLower(alias)] = command
+	}
+	return r
+}
+
+// applyAliases replaces the first word of the text by its command, if it is an alias.
+func applyAliases(ctx context.Context, aliases map[string]string) context.Context {
+	if len(aliases) == 0 {
+		return ctx
+	}
+	text := TextFromContext(ctx)
+	word := strings.Fields(text)
+	if len(word) == 0 {
+		return ctx
+	}
+	command, ok := aliases[strings.ToLower(word[0])]
+	if !ok {
+		return ctx
+	}
+	return AddTextToContext(ctx, command+strings.TrimPrefix(text, word[0]))
+}
+
+// Aliases returns the aliases of the workspace, editable at runtime, mapped to their command.
+func (b *Bot) Aliases(teamID string) (map[string]string, error) {
+	aliases := map[string]string{}
+	data, found, err := b.StoreFor(teamID).Get(aliasesStoreKey)
+	if err != nil || !found {
+		return aliases, err
+	}
+	err = json.Unmarshal(data, &aliases)
+	return aliases, err
+}
+
+// AddAlias registers an alias of a command in the workspace. Workspace aliases apply to all
+// routes.
+func (b *Bot) AddAlias(teamID, alias, command string) error {
+	return b.updateAliases(teamID, func(aliases map[string]string) {
+		aliases[strings.ToLower(alias)] = command
+	})
+}
+
+// RemoveAlias removes an alias of the workspace.
+func (b *Bot) RemoveAlias(teamID, alias string) error {
+	return b.updateAliases(teamID, func(aliases map[string]string) {
+		delete(aliases, strings.ToLower(alias))
+	})
+}
+
+func (b *Bot) updateAliases(teamID string, fn func(map[string]string)) error {
+	b.aliasesMu.Lock()
+	defer b.aliasesMu.Unlock()
+	aliases, err := b.Aliases(teamID)
+	if err != nil {
+		return err
+	}
+	fn(aliases)
+	data, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+	return b.StoreFor(teamID).Set(aliasesStoreKey, data, 0)
+}
+
+// EnableAliasCommands registers admin commands to manage the aliases of the workspace:
+// "alias add <alias> <command>", "alias remove <alias>" and "aliases".
+func (b *Bot) EnableAliasCommands() *Bot {
+	b.Hear(aliasAddRegexp).WorkspaceAdminOnly().MessageHandler(aliasAddHandler)
+	b.Hear(aliasRemoveRegexp).WorkspaceAdminOnly().MessageHandler(aliasRemoveHandler)
+	b.Hear(`(?i)^aliases$`).MessageHandler(aliasListHandler)
+	return b
+}
+
+const (
+	aliasAddRegexp    = `(?i)^alias add (\S+) (\S+)$`
+	aliasRemoveRegexp = `(?i)^alias (?:remove|rm) (\S+)$`
+)
+
+func aliasAddHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	args := submatches(aliasAddRegexp, TextFromContext(ctx))
+	if err := bot.AddAlias(TeamFromContext(ctx), args[1], args[2]); err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not add alias: %s", err))
+		return
+	}
+	bot.Reply(evt, fmt.Sprintf("`%s` is now an alias of `%s`.", args[1], args[2]))
+}
+
+func aliasRemoveHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	args := submatches(aliasRemoveRegexp, TextFromContext(ctx))
+	if err := bot.RemoveAlias(TeamFromContext(ctx), args[1]); err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not remove alias: %s", err))
+		return
+	}
+	bot.Reply(evt, fmt.Sprintf("Alias `%s` removed.", args[1]))
+}
+
+func aliasListHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	aliases, err := bot.Aliases(TeamFromContext(ctx))
+	if err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not list aliases: %s", err))
+		return
+	}
+	if len(aliases) == 0 {
+		bot.Reply(evt, "No aliases defined.")
+		return
+	}
+	lines := make([]string, 0, len(aliases))
+	for alias, command := range aliases {
+		lines = append(lines, fmt.Sprintf("`%s` → `%s`", alias, command))
+	}
+	sort.Strings(lines)
+	bot.Reply(evt, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,69 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func aliasMessage(team, user, text string) *slack.MessageEvent {
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.Team, evt.User, evt.Text = "C1", team, user, text
+	return evt
+}
+
+func TestRouteAliases(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+	var deployed []string
+	bot.Hear(`^deploy (\w+)$`).Aliases("deploy", "Ship", "release").Handler(func(ctx context.Context) {
+		deployed = append(deployed, TextFromContext(ctx))
+	})
+	var other []string
+	bot.Hear(`^ship`).Handler(func(ctx context.Context) {
+		other = append(other, TextFromContext(ctx))
+	})
+
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "ship web"))
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "release db"))
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "shipit"))
+	assert.Equal([]string{"deploy web", "deploy db"}, deployed)
+	// routes do not see the aliases of others
+	assert.Equal([]string{"shipit"}, other)
+}
+
+func TestWorkspaceAliases(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetAdmins("UADMIN")
+	api := newSlackAPI(t, bot)
+	bot.EnableAliasCommands()
+	ctx := AddBotToContext(context.Background(), bot)
+	var deployed []string
+	bot.Hear(`^deploy (\w+)$`).Handler(func(ctx context.Context) {
+		deployed = append(deployed, TeamFromContext(ctx)+" "+TextFromContext(ctx))
+	})
+
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "alias add ship deploy"))
+	bot.handleMessage(ctx, aliasMessage("T1", "UADMIN", "alias add ship deploy"))
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "ship web"))
+	// aliases are kept per workspace
+	bot.handleMessage(ctx, aliasMessage("T2", "U1", "ship web"))
+	aliases, err := bot.Aliases("T2")
+	assert.NoError(err)
+	assert.Empty(aliases)
+	assert.Equal([]string{"T1 deploy web"}, deployed)
+
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "aliases"))
+	bot.handleMessage(ctx, aliasMessage("T1", "UADMIN", "alias rm ship"))
+	bot.handleMessage(ctx, aliasMessage("T1", "U1", "aliases"))
+	assert.Equal([]string{
+		WorkspaceAdminOnlyText,
+		"`ship` is now an alias of `deploy`.",
+		"`ship` → `deploy`",
+		"Alias `ship` removed.",
+		"No aliases defined.",
+	}, api.values("chat.postMessage", "text"))
+}
@@ -0,0 +1,185 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AppManifestOptions describes the Slack app beyond what the bot derives from its features.
+type AppManifestOptions struct {
+	Name        string
+	Description string
+	// Display name of the bot user, Name when empty
+	BotName string
+	// Descriptions and usage hints of the slash commands, by command
+	SlashCommands map[string]SlashCommandInfo
+	// Enables interactivity without registered actions, for prompts created at runtime
+	// such as AskSelect or RequireAck
+	Interactivity bool
+}
+
+// SlashCommandInfo documents a slash command in the app manifest.
+type SlashCommandInfo struct {
+	Description string
+	UsageHint   string
+}
+
+// AppManifest is a Slack app manifest, as pasted in the app settings or sent to the
+// apps.manifest.create API.
+type AppManifest struct {
+	DisplayInformation AppDisplayInformation `json:"display_information" yaml:"display_information"`
+	Features           AppFeatures           `json:"features" yaml:"features"`
+	OAuthConfig        AppOAuthConfig        `json:"oauth_config" yaml:"oauth_config"`
+	Settings           AppSettings           `json:"settings" yaml:"settings"`
+}
+
+// AppDisplayInformation and the types following it are the sections of an AppManifest, as
+// defined by the schema of Slack app manifests.
+type AppDisplayInformation struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type AppFeatures struct {
+	BotUser       AppBotUser        `json:"bot_user" yaml:"bot_user"`
+	SlashCommands []AppSlashCommand `json:"slash_commands,omitempty" yaml:"slash_commands,omitempty"`
+}
+
+type AppBotUser struct {
+	DisplayName  string `json:"display_name" yaml:"display_name"`
+	AlwaysOnline bool   `json:"always_online" yaml:"always_online"`
+}
+
+type AppSlashCommand struct {
+	Command      string `json:"command" yaml:"command"`
+	URL          string `json:"url,omitempty" yaml:"url,omitempty"`
+	Description  string `json:"description" yaml:"description"`
+	UsageHint    string `json:"usage_hint,omitempty" yaml:"usage_hint,omitempty"`
+	ShouldEscape bool   `json:"should_escape" yaml:"should_escape"`
+}
+
+type AppOAuthConfig struct {
+	Scopes AppScopes `json:"scopes" yaml:"scopes"`
+}
+
+type AppScopes struct {
+	Bot []string `json:"bot" yaml:"bot"`
+}
+
+type AppSettings struct {
+	EventSubscriptions AppEventSubscriptions `json:"event_subscriptions" yaml:"event_subscriptions"`
+	Interactivity      *AppInteractivity     `json:"interactivity,omitempty" yaml:"interactivity,omitempty"`
+	OrgDeployEnabled   bool                  `json:"org_deploy_enabled" yaml:"org_deploy_enabled"`
+	SocketModeEnabled  bool                  `json:"socket_mode_enabled" yaml:"socket_mode_enabled"`
+}
+
+type AppEventSubscriptions struct {
+	RequestURL string   `json:"request_url,omitempty" yaml:"request_url,omitempty"`
+	BotEvents  []string `json:"bot_events" yaml:"bot_events"`
+}
+
+type AppInteractivity struct {
+	IsEnabled  bool   `json:"is_enabled" yaml:"is_enabled"`
+	RequestURL string `json:"request_url,omitempty" yaml:"request_url,omitempty"`
+}
+
+// messageEvents are the events and scopes through which the bot receives the messages
+// it routes.
+var (
+	messageEvents = []string{"app_mention", "message.channels", "message.groups", "message.im", "message.mpim"}
+	messageScopes = []string{"app_mentions:read", "channels:history", "chat:write", "groups:history", "im:history", "mpim:history"}
+)
+
+// AppManifest derives the manifest of the Slack app from the features registered on the bot:
+// the scopes declared with RequireScopes, the events with handlers, the slash commands, and
+// interactivity when actions or views are registered. Request URLs are those of HTTPHandler
+// under the PublicURL of the HTTPConfig. Generate it once every feature is registered:
+//
+//	data, _ := bot.AppManifest(slackbot.AppManifestOptions{Name: "Deploy Bot"}).YAML()
+func (b *Bot) AppManifest(opts AppManifestOptions) AppManifest {
+	if opts.BotName == "" {
+		opts.BotName = opts.Name
+	}
+	m := AppManifest{
+		DisplayInformation: AppDisplayInformation{Name: opts.Name, Description: opts.Description},
+		Features:           AppFeatures{BotUser: AppBotUser{DisplayName: opts.BotName, AlwaysOnline: true}},
+	}
+	base := strings.TrimSuffix(b.httpConfig.PublicURL, "/")
+	endpoint := func(path string) string {
+		if base == "" {
+			return ""
+		}
+		return base + path
+	}
+
+	scopes := map[string]bool{}
+	for _, s := range messageScopes {
+		scopes[s] = true
+	}
+	b.scopesMu.Lock()
+	for s := range b.requiredScopes {
+		scopes[s] = true
+	}
+	b.scopesMu.Unlock()
+
+	b.interactionsMu.Lock()
+	for command := range b.commands {
+		info := opts.SlashCommands[command]
+		if info.Description == "" {
+			info.Description = strings.TrimPrefix(command, "/")
+		}
+		m.Features.SlashCommands = append(m.Features.SlashCommands, AppSlashCommand{
+			Command:     command,
+			URL:         endpoint("/commands"),
+			Description: info.Description,
+			UsageHint:   info.UsageHint,
+		})
+		scopes["commands"] = true
+	}
+	interactive := opts.Interactivity || len(b.actions) > 0 || len(b.views) > 0
+	b.interactionsMu.Unlock()
+	sort.Slice(m.Features.SlashCommands, func(i, j int) bool {
+		return m.Features.SlashCommands[i].Command < m.Features.SlashCommands[j].Command
+	})
+	if interactive {
+		m.Settings.Interactivity = &AppInteractivity{IsEnabled: true, RequestURL: endpoint("/interactivity")}
+	}
+
+	events := map[string]bool{}
+	for _, e := range messageEvents {
+		events[e] = true
+	}
+	b.eventsMu.Lock()
+	for e := range b.events {
+		// messages are subscribed to by channel type
+		if e != "message" {
+			events[e] = true
+		}
+	}
+	b.eventsMu.Unlock()
+	m.Settings.EventSubscriptions = AppEventSubscriptions{RequestURL: endpoint("/events"), BotEvents: sortedKeys(events)}
+	m.OAuthConfig.Scopes.Bot = sortedKeys(scopes)
+	return m
+}
+
+// JSON encodes the manifest as indented JSON.
+func (m AppManifest) JSON() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// YAML encodes the manifest as YAML.
+func (m AppManifest) YAML() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,59 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAppManifest(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	m := bot.AppManifest(AppManifestOptions{Name: "Deploy Bot"})
+	assert.Equal("Deploy Bot", m.Features.BotUser.DisplayName)
+	assert.Equal(messageEvents, m.Settings.EventSubscriptions.BotEvents)
+	assert.Equal(messageScopes, m.OAuthConfig.Scopes.Bot)
+	assert.Equal("", m.Settings.EventSubscriptions.RequestURL)
+	assert.Nil(m.Settings.Interactivity)
+
+	bot.SetHTTPConfig(HTTPConfig{PublicURL: "https://bot.example.com/slack/"})
+	bot.SlashCommand("/deploy", func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string { return "" })
+	bot.SlashCommand("/status", func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string { return "" })
+	bot.OnAction("approve", func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {})
+	bot.OnReactionCommand("wastebasket", func(ctx context.Context, bot *Bot, reaction *slack.ReactionAddedEvent, msg, original *slack.MessageEvent) {
+	})
+	bot.RequireScopes("users:read")
+
+	m = bot.AppManifest(AppManifestOptions{
+		Name:          "Deploy Bot",
+		BotName:       "deploybot",
+		SlashCommands: map[string]SlashCommandInfo{"/deploy": {Description: "Deploys a service", UsageHint: "<service> <env>"}},
+	})
+	assert.Equal([]AppSlashCommand{
+		{Command: "/deploy", URL: "https://bot.example.com/slack/commands", Description: "Deploys a service", UsageHint: "<service> <env>"},
+		{Command: "/status", URL: "https://bot.example.com/slack/commands", Description: "status"},
+	}, m.Features.SlashCommands)
+	assert.Equal(&AppInteractivity{IsEnabled: true, RequestURL: "https://bot.example.com/slack/interactivity"}, m.Settings.Interactivity)
+	assert.Equal("https://bot.example.com/slack/events", m.Settings.EventSubscriptions.RequestURL)
+	assert.Contains(m.Settings.EventSubscriptions.BotEvents, "reaction_added")
+	assert.NotContains(m.Settings.EventSubscriptions.BotEvents, "message")
+	assert.Contains(m.OAuthConfig.Scopes.Bot, "commands")
+	assert.Contains(m.OAuthConfig.Scopes.Bot, "reactions:read")
+	assert.Contains(m.OAuthConfig.Scopes.Bot, "users:read")
+
+	data, err := m.JSON()
+	assert.NoError(err)
+	var decoded AppManifest
+	assert.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal(m, decoded)
+	data, err = m.YAML()
+	assert.NoError(err)
+	var raw map[string]interface{}
+	assert.NoError(yaml.Unmarshal(data, &raw))
+	assert.Contains(raw, "oauth_config")
+	assert.Contains(raw, "settings")
+}
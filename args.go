@@ -0,0 +1,156 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ARGS_CONTEXT = "__ARGS_CONTEXT__"
+
+// ArgType is the type an argument is parsed into.
+type ArgType int
+
+const (
+	StringArg ArgType = iota
+	IntArg
+	BoolArg
+	DurationArg
+)
+
+// Arg declares a positional argument of a command.
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Required bool
+}
+
+// Schema declares the positional arguments of a command, in order.
+type Schema []Arg
+
+// Args holds the parsed arguments of a command by name.
+type Args map[string]interface{}
+
+// String returns the string argument, or an empty string if it was not given.
+func (a Args) String(name string) string {
+	v, _ := a[name].(string)
+	return v
+}
+
+// Int returns the integer argument, or 0 if it was not given.
+func (a Args) Int(name string) int {
+	v, _ := a[name].(int)
+	return v
+}
+
+// Bool returns the boolean argument, or false if it was not given.
+func (a Args) Bool(name string) bool {
+	v, _ := a[name].(bool)
+	return v
+}
+
+// Duration returns the duration argument, or 0 if it was not given.
+func (a Args) Duration(name string) time.Duration {
+	v, _ := a[name].(time.Duration)
+	return v
+}
+
+// ArgsFromContext returns the arguments parsed for a route declaring a Schema.
+func ArgsFromContext(ctx context.Context) Args {
+	if result, ok := ctx.Value(ARGS_CONTEXT).(Args); ok {
+		return result
+	}
+	return nil
+}
+
+// Args validates the words following the command against the schema before the handler
+// runs. The command words are taken from the route Usage, or the first word without one.
+// Invalid input is answered with the usage and the validation errors.
+func (r *Route) Args(schema Schema) *Route {
+	r.schema = schema
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			skip := len(usageCommand(r.usage))
+			if skip == 0 {
+				skip = 1
+			}
+			words := strings.Fields(TextFromContext(ctx))
+			if len(words) < skip {
+				words = nil
+			} else {
+				words = words[skip:]
+			}
+
+			args, errs := schema.Parse(words)
+			if len(errs) > 0 {
+				bot := BotFromContext(ctx)
+				bot.Reply(MessageFromContext(ctx), validationReply(r.usage, errs))
+				return
+			}
+			next(context.WithValue(ctx, ARGS_CONTEXT, args))
+		}
+	})
+}
+
+// Parse converts the words into arguments, returning every validation error found.
+func (s Schema) Parse(words []string) (Args, []error) {
+	args := Args{}
+	var errs []error
+	for i, arg := range s {
+		if i >= len(words) {
+			if arg.Required {
+				errs = append(errs, fmt.Errorf("%s is required", arg.Name))
+			}
+			continue
+		}
+		v, err := parseArg(arg.Type, words[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s", arg.Name, err))
+			continue
+		}
+		args[arg.Name] = v
+	}
+	if len(words) > len(s) {
+		errs = append(errs, fmt.Errorf("too many arguments: %s", strings.Join(words[len(s):], " ")))
+	}
+	return args, errs
+}
+
+func parseArg(t ArgType, word string) (interface{}, error) {
+	switch t {
+	case IntArg:
+		v, err := strconv.Atoi(word)
+		if err != nil {
+			return nil, fmt.Errorf("must be an integer, got %q", word)
+		}
+		return v, nil
+	case BoolArg:
+		switch strings.ToLower(word) {
+		case "true", "yes", "on", "1":
+			return true, nil
+		case "false", "no", "off", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("must be yes or no, got %q", word)
+	case DurationArg:
+		v, err := time.ParseDuration(word)
+		if err != nil {
+			return nil, fmt.Errorf("must be a duration like 10m, got %q", word)
+		}
+		return v, nil
+	}
+	return word, nil
+}
+
+func validationReply(usage string, errs []error) string {
+	var b strings.Builder
+	if usage != "" {
+		fmt.Fprintf(&b, "Usage: `%s`\n", usage)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(&b, "• %s\n", err)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,33 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaParse(t *testing.T) {
+	assert := assert.New(t)
+	schema := Schema{
+		{Name: "env", Required: true},
+		{Name: "replicas", Type: IntArg},
+		{Name: "timeout", Type: DurationArg},
+	}
+
+	args, errs := schema.Parse([]string{"prod", "3", "5m"})
+	assert.Empty(errs)
+	assert.Equal("prod", args.String("env"))
+	assert.Equal(3, args.Int("replicas"))
+	assert.Equal(5*time.Minute, args.Duration("timeout"))
+
+	args, errs = schema.Parse([]string{"prod"})
+	assert.Empty(errs)
+	assert.Equal(0, args.Int("replicas"))
+
+	_, errs = schema.Parse([]string{})
+	assert.Len(errs, 1)
+
+	_, errs = schema.Parse([]string{"prod", "three", "soon", "extra"})
+	assert.Len(errs, 3)
+}
@@ -0,0 +1,102 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/slack-go/slack"
+)
+
+const BACKFILL_CONTEXT = "__BACKFILL_CONTEXT__"
+
+// Backfill makes the bot fetch the messages posted in the channels while it was disconnected,
+// once reconnected, and route them as if just received. The timestamp of the last message
+// seen in each channel is kept in the Store, so messages missed during a restart are also
+// routed when the Store is persistent. Channels may be public or private channels, direct
+// messages or group direct messages.
+func (b *Bot) Backfill(channels ...string) *Bot {
+	b.RequireScopes("channels:history", "groups:history", "im:history", "mpim:history")
+	b.backfillMu.Lock()
+	defer b.backfillMu.Unlock()
+	if b.backfill == nil {
+		b.backfill = make(map[string]bool)
+	}
+	for _, c := range channels {
+		b.backfill[c] = true
+	}
+	return b
+}
+
+// IsBackfilled returns true if the message in context was fetched after a reconnection,
+// handlers may want to skip time sensitive replies.
+func IsBackfilled(ctx context.Context) bool {
+	backfilled, _ := ctx.Value(BACKFILL_CONTEXT).(bool)
+	return backfilled
+}
+
+// lastSeenKey is the Store key of the timestamp of the last message seen in a channel.
+func lastSeenKey(channel string) string {
+	return "last_seen:" + channel
+}
+
+// markSeen records the message as the last one seen in its channel, if backfilled.
+func (b *Bot) markSeen(evt *slack.MessageEvent) {
+	b.backfillMu.Lock()
+	watched := b.backfill[evt.Channel]
+	b.backfillMu.Unlock()
+	if !watched {
+		return
+	}
+	key := lastSeenKey(evt.Channel)
+	if last, found, err := b.Store().Get(key); err == nil && found && string(last) >= evt.Timestamp {
+		return
+	}
+	_ = b.Store().Set(key, []byte(evt.Timestamp), 0)
+}
+
+// runBackfill routes the messages posted in the backfilled channels since the last seen ones.
+func (b *Bot) runBackfill(ctx context.Context) {
+	b.backfillMu.Lock()
+	channels := make([]string, 0, len(b.backfill))
+	for c := range b.backfill {
+		channels = append(channels, c)
+	}
+	b.backfillMu.Unlock()
+
+	ctx = context.WithValue(ctx, BACKFILL_CONTEXT, true)
+	for _, channel := range channels {
+		last, found, err := b.Store().Get(lastSeenKey(channel))
+		if err != nil || !found {
+			continue
+		}
+		msgs, err := b.history(channel, string(last))
+		if err != nil {
+			fmt.Printf("Error backfilling %s: %s\n", channel, err)
+			continue
+		}
+		sort.Slice(msgs, func(i, j int) bool { return msgs[i].Timestamp < msgs[j].Timestamp })
+		for _, m := range msgs {
+			ev := slack.MessageEvent(m)
+			ev.Channel = channel
+			b.handleMessage(ctx, &ev)
+		}
+	}
+}
+
+// history returns the messages posted in the channel after oldest, fetching every page.
+func (b *Bot) history(channel, oldest string) ([]slack.Message, error) {
+	var msgs []slack.Message
+	params := &slack.GetConversationHistoryParameters{ChannelID: channel, Oldest: oldest, Limit: 200}
+	for {
+		history, err := b.Client.GetConversationHistory(params)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, history.Messages...)
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			return msgs, nil
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+}
@@ -0,0 +1,46 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfill(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Backfill("C1", "C2")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": true, "has_more": true, "response_metadata": {"next_cursor": "page2"}, "messages": [
+		{"type": "message", "user": "U1", "text": "deploy db", "ts": "4.0"},
+		{"type": "message", "user": "U1", "text": "deploy web", "ts": "3.0"}
+	]}`, `{"ok": true, "messages": [
+		{"type": "message", "user": "U1", "text": "deploy cache", "ts": "2.0"}
+	]}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^deploy").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		if IsBackfilled(ctx) {
+			heard = append(heard, "backfilled "+evt.Channel+" "+evt.Text)
+		} else {
+			heard = append(heard, evt.Channel+" "+evt.Text)
+		}
+	})
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text, evt.Timestamp = "C1", "U1", "deploy api", "1.0"
+	bot.handleMessage(ctx, evt)
+	bot.runBackfill(ctx)
+
+	// channels without a message seen yet are not backfilled
+	assert.Equal([]string{"C1", "C1"}, api.values("conversations.history", "channel"))
+	assert.Equal([]string{"1.0", "1.0"}, api.values("conversations.history", "oldest"))
+	assert.Equal([]string{"", "page2"}, api.values("conversations.history", "cursor"))
+	assert.Equal([]string{"C1 deploy api", "backfilled C1 deploy cache", "backfilled C1 deploy web", "backfilled C1 deploy db"}, heard)
+	last, _, _ := bot.Store().Get(lastSeenKey("C1"))
+	assert.Equal("4.0", string(last))
+	for _, scope := range []string{"channels:history", "groups:history", "im:history", "mpim:history"} {
+		assert.True(bot.requiredScopes[scope], scope)
+	}
+}
@@ -6,7 +6,7 @@
 //
 // The package adds Reply and ReplyWithAttachments methods:
 //	func HowAreYouHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
-// 		bot.Reply(evt, "A bit tired. You get it? A bit?", slackbot.WithTyping)
+// 		bot.Reply(evt, "A bit tired. You get it? A bit?", slackbot.Typing())
 //	}
 //
 //	func HowAreYouAttachmentsHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
@@ -22,7 +22,7 @@
 // 		}
 //
 //		attachments := []slack.Attachment{attachment}
-//		bot.ReplyWithAttachments(evt, attachments, slackbot.WithTyping)
+//		bot.ReplyWithAttachments(evt, attachments, slackbot.Typing())
 //	}
 //
 // The slackbot package exposes  github.com/slack-go/slack RTM and Client objects
@@ -37,6 +37,9 @@ package slackbot
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
 	"time"
 
 	"context"
@@ -45,15 +48,15 @@ import (
 )
 
 const (
-	WithTyping    bool = true
-	WithoutTyping bool = false
-
 	maxTypingSleepMs time.Duration = time.Millisecond * 2000
 )
 
 // New constructs a new Bot using the slackToken to authorize against the Slack service.
 func New(slackToken string) *Bot {
-	b := &Bot{Client: slack.New(slackToken)}
+	b := &Bot{token: slackToken, store: NewMemoryStore(), metrics: &botMetrics{}}
+	b.httpClient = newMetricsClient(b.metrics)
+	b.Client = slack.New(slackToken, slack.OptionHTTPClient(b.httpClient))
+	b.Jobs = newJobs(b)
 	return b
 }
 
@@ -68,108 +71,361 @@ type Bot struct {
 	botEnterpriseID string
 	// Slack UserName of the bot UserName
 	botUserName string
+	// Slack TeamID of the workspace the bot connected to
+	botTeamID string
+	// Messages from external users are ignored when set
+	internalOnly bool
+	// Details of conversations
+	channels channelCache
+	// Members of user groups
+	userGroups userGroupCache
+	// Timezones of users
+	timezones timezoneCache
+	// Custom emoji of the workspace
+	emoji emojiCache
+	// Channels whose messages are fetched after reconnecting
+	backfill   map[string]bool
+	backfillMu sync.Mutex
+	// Channels joined at startup
+	watched []string
+	watchMu sync.Mutex
+	// Handling of messages too long for Slack
+	overflow         Overflow
+	maxMessageLength int
+	// Join channels when sending fails because the bot is not a member, and the events whose
+	// replies do so for the routes being handled
+	joinOnSend bool
+	routeJoin  sync.Map
+	// User preferences which may be set
+	prefs   map[string]prefSpec
+	prefsMu sync.Mutex
+	// Feature flags consulted by routes
+	flags FlagProvider
+	// Where analytics events are sent
+	analytics AnalyticsSink
+	// Typing simulation of the bot, and of the routes being handled keyed by event
+	typing      *TypingOptions
+	routeTyping sync.Map
+	// Attach correlation IDs to replies
+	correlate bool
+	// Origins of replies are recorded when set, along with the routes being handled
+	originTTL time.Duration
+	handling  sync.Map
+	// Handoff of conversations to humans
+	escalation *escalation
+	// Where handler errors are reported, and whether their panics are recovered
+	errorReporter ErrorReporter
+	recoverPanics bool
+	// Answers the messages for the bot no route matched
+	fallback Responder
+	// Conversations remembered for handlers
+	memory   *MemoryOptions
+	memoryMu sync.Mutex
+	// Moderation of incoming messages, and of the bot messages quoting them
+	moderationOpts *ModerationOptions
+	moderation     *moderation
+	moderationOnce sync.Once
+	// Settings of workspaces not edited by their admins
+	defaultWorkspace Workspace
+	// Maintenance mode, and the reply to messages then
+	maintenance     bool
+	maintenanceText string
+	maintenanceMu   sync.Mutex
+	// Callbacks run when the bot comes online and shuts down
+	onReady    []LifecycleFunc
+	onShutdown []LifecycleFunc
+	readyOnce  sync.Once
+	// When the bot started serving, and last connected
+	startedAt   time.Time
+	connectedAt time.Time
+	uptimeMu    sync.Mutex
+	// Activity of the bot, and the client of the Web API recording it
+	metrics    *botMetrics
+	httpClient *http.Client
+	// Recorders of the replies to coalesced or cached requests, keyed by event
+	recorders sync.Map
+	// Running commands which users may cancel
+	cancels cancellations
+	// Reaction votes awaited, by message
+	quorums quorums
+	// Questions of the bot awaiting an answer, by channel
+	nags nags
+	// Handlers of reactions to the messages of the bot, by emoji
+	reactionCommands reactionCommands
+	// Routers of threads, by thread ts
+	threads   map[string]*ThreadRouter
+	threadsMu sync.Mutex
+	// Intro sent to users opening a direct message with the bot
+	greeting *Greeting
+	// Rate of messages allowed in channels
+	slowMode *slowMode
+	// Messages recently received through the Events API
+	delivered deliveries
+	// Persistent values for the bot and its handlers
+	store Store
+	// Pipeline applied to incoming text before matching
+	normalizers []Normalizer
+	// Hear routes only match channel messages addressed to the bot when set
+	commandPrefix     string
+	onlyWhenAddressed bool
+	// Maximum edit distance of command suggestions, 0 when disabled
+	suggestionDistance int
+	// Values handed to injected handlers
+	provided []reflect.Value
+	// Slack UserIDs allowed to run admin commands
+	admins map[string]bool
+	// Serializes updates of the workspace aliases
+	aliasesMu sync.Mutex
+	// Configuration of the HTTP endpoints
+	httpConfig HTTPConfig
+	mounts     map[string]http.Handler
+	// Handlers of interactive components by action_id, and of slash commands
+	actions        map[string]ActionHandler
+	views          map[string]viewHandler
+	commands       map[string]SlashCommandHandler
+	interactionsMu sync.Mutex
+	// Handlers of RTM events, by type
+	events   map[string][]EventHandler
+	eventsMu sync.Mutex
+	// Functions run on a schedule
+	scheduled   []scheduled
+	scheduleCtx context.Context
+	scheduleMu  sync.Mutex
+	// OAuth scopes required by the features in use
+	requiredScopes map[string]bool
+	strictScopes   bool
+	scopesMu       sync.Mutex
+	// Hooks run around every outgoing message
+	beforeSend []SendHook
+	afterSend  []SentHook
+	// Worker slots for concurrent handlers, nil when handlers run synchronously
+	workers chan struct{}
+	// Only the HTTP endpoints are served when set
+	withoutRTM bool
+	// Background jobs enqueued by handlers
+	Jobs *Jobs
 	// Slack API
+	token  string
 	Client *slack.Client
 	RTM    *slack.RTM
 }
 
-// Run listens for incoming slack RTM events, matching them to an appropriate handler.
+// Run listens for incoming slack RTM events, matching them to an appropriate handler. It also
+// serves the HTTP endpoints when an address is configured. Use Serve to stop the bot or get
+// the error that made it stop.
 func (b *Bot) Run() {
+	if err := b.Serve(context.Background()); err != nil {
+		fmt.Printf("%s\n", err)
+	}
+}
+
+// runRTM handles RTM events until the context is done or the credentials are rejected.
+func (b *Bot) runRTM(ctx context.Context) error {
 	b.RTM = b.Client.NewRTM()
 	go b.RTM.ManageConnection()
-LOOP:
+	defer b.RTM.Disconnect()
 	for {
 		select {
+		case <-ctx.Done():
+			return nil
 		case msg := <-b.RTM.IncomingEvents:
-			ctx := context.Background()
-			ctx = AddBotToContext(ctx, b)
+			ctx := AddBotToContext(ctx, b)
 			switch ev := msg.Data.(type) {
 			case *slack.ConnectedEvent:
 				fmt.Printf("Connected: %#v, count: %d\n", ev.Info.User, ev.ConnectionCount)
 				b.botUserID = ev.Info.User.ID
 				b.botUserName = ev.Info.User.Name
+				b.botTeamID = ev.Info.Team.ID
+				b.setConnected(time.Now())
+				if ev.ConnectionCount > 0 {
+					b.metrics.update(func(m *Metrics) {
+						m.Reconnects++
+						m.LastReconnect = time.Now()
+					})
+				}
 
 				u, err := b.Client.GetUserInfo(ev.Info.User.ID)
 				if err != nil {
 					fmt.Printf("Error getting bot info: %s\n", err)
 				}
 				b.botEnterpriseID = u.Enterprise.ID
-			case *slack.MessageEvent:
-				// ignore messages from the current user, the bot user
-				// for safety compare with enterprise ID, ID, and name
-				u := ev.User
-				if b.botEnterpriseID == u || b.botUserID == u || b.botUserName == u {
-					continue LOOP
-				}
-
-				ctx = AddMessageToContext(ctx, ev)
-				var match RouteMatch
-				if matched, ctx := b.Match(ctx, &match); matched {
-					match.Handler(ctx)
+				if ev.ConnectionCount > 0 {
+					go b.runBackfill(ctx)
 				}
+				go b.ready(ctx)
+			case *slack.MessageEvent:
+				b.handleMessage(ctx, ev)
 
 			case *slack.InvalidAuthEvent:
-				fmt.Printf("Invalid credentials\n")
-				break LOOP
+				return ErrInvalidAuth
+
+			case *slack.LatencyReport:
+				b.metrics.update(func(m *Metrics) { m.RTMLatency = ev.Value })
 
 			case error:
 				fmt.Printf("Error %T: %s\n", ev, ev.Error())
 
+			case *slack.EmojiChangedEvent:
+				b.invalidateEmoji()
+				b.handleEvent(ctx, msg.Type, ev)
+
 			default:
-				// Ignore other events..
-				// fmt.Printf("Unexpected: %v\n", msg.Data)
+				b.handleEvent(ctx, msg.Type, msg.Data)
 			}
 		}
 	}
 }
 
-// Reply replies to a message event with a simple message.
-func (b *Bot) Reply(evt *slack.MessageEvent, msg string, typing bool) {
-	if typing {
-		b.Type(evt, msg)
+// handleMessage routes an incoming message, received through RTM or the Events API.
+func (b *Bot) handleMessage(ctx context.Context, ev *slack.MessageEvent) {
+	// ignore messages from the current user, the bot user
+	// for safety compare with enterprise ID, ID, and name
+	u := ev.User
+	if b.botEnterpriseID == u || b.botUserID == u || b.botUserName == u {
+		return
+	}
+
+	b.metrics.update(func(m *Metrics) { m.MessagesReceived++ })
+	b.markSeen(ev)
+	b.resolveNags(ev)
+	ctx = AddMessageToContext(ctx, ev)
+	if b.correlate {
+		ctx = context.WithValue(ctx, CORRELATION_CONTEXT, b.correlationFor(ev))
+	}
+	if ev.Team != "" {
+		ctx = AddTeamToContext(ctx, ev.Team)
+	}
+	if b.internalOnly && b.IsExternalUser(u) {
+		return
+	}
+	b.handleEvent(ctx, "message", ev)
+	// conversations handed over to humans are relayed instead
+	if b.isEscalated(ev.Channel) {
+		return
+	}
+	if b.normalizers != nil {
+		ctx = AddTextToContext(ctx, normalize(ev.Text, b.normalizers))
+	}
+	if b.requiresAddress() {
+		ctx = b.addAddressingToContext(ctx, ev)
+	}
+	if aliases, err := b.Aliases(TeamFromContext(ctx)); err == nil {
+		ctx = applyAliases(ctx, aliases)
+	}
+	if b.moderationOpts != nil && !b.moderate(ctx, b.moderationOpts, ev) {
+		return
+	}
+	if b.cancelOnReply(ctx, ev) {
+		return
+	}
+	if b.memory != nil && isForBot(ctx, b, ev) {
+		b.remember(ctx, ev, Turn{User: ev.User, Text: TextFromContext(ctx), TS: ev.Timestamp})
+	}
+	var match RouteMatch
+	if matched, ctx := b.matchRoutes(ctx, ev, &match); matched {
+		b.countFailure(ev, false)
+		b.metrics.update(func(m *Metrics) { m.MessagesHandled++ })
+		if match.Route != nil && match.Route.name != "" {
+			ctx = context.WithValue(ctx, ROUTE_CONTEXT, match.Route.name)
+		}
+		if on, text := b.InMaintenance(); on && !match.admin {
+			b.Reply(ev, text)
+			return
+		}
+		ctx, release := b.cancellable(ctx, ev)
+		handler := b.trackRoute(ev, match.Route, match.Handler)
+		b.dispatch(ctx, func(ctx context.Context) {
+			defer release()
+			handler(ctx)
+		})
+	} else if !b.suggest(ctx, ev) && b.fallback != nil && isForBot(ctx, b, ev) {
+		if on, text := b.InMaintenance(); on {
+			b.Reply(ev, text)
+			return
+		}
+		b.dispatch(ctx, b.respond)
+	} else {
+		b.countFailure(ev, true)
 	}
-	b.RTM.SendMessage(b.RTM.NewOutgoingMessage(msg, evt.Channel))
 }
 
-// ReplyPost replies to a message event with a simple message using Slack API.
-func (b *Bot) ReplyPost(evt *slack.MessageEvent, msg string, typing bool) {
-	if typing {
-		b.Type(evt, msg)
+// Workers lets up to n handlers run concurrently. By default handlers run one at a time,
+// in the order messages are received.
+func (b *Bot) Workers(n int) *Bot {
+	b.workers = make(chan struct{}, n)
+	return b
+}
+
+// dispatch runs the handler, in a worker goroutine when a pool is configured.
+func (b *Bot) dispatch(ctx context.Context, handler Handler) {
+	if b.recoverPanics {
+		handler = Recover()(handler)
 	}
-	postParams := slack.MsgOptionPostMessageParameters(slack.PostMessageParameters{
+	handler = b.timeHandler(handler)
+	if b.workers == nil {
+		handler(ctx)
+		return
+	}
+	b.workers <- struct{}{}
+	go func() {
+		defer func() { <-b.workers }()
+		handler(ctx)
+	}()
+}
+
+// Reply replies to a message event with a simple message.
+func (b *Bot) Reply(evt *slack.MessageEvent, msg string, opts ...ReplyOption) {
+	cfg := newReplyConfig(opts)
+	cfg.typeReply(b, evt, msg)
+	out := &OutgoingMessage{Channel: evt.Channel, Text: msg, RTM: true}
+	cfg.apply(out, evt)
+	b.attachCorrelation(out, evt)
+	b.attachOrigin(out, evt)
+	b.attachJoin(out, evt)
+	b.recordReply(out, evt)
+	_, _ = b.Send(out)
+}
+
+// ReplyPost replies to a message event with a simple message using Slack API.
+func (b *Bot) ReplyPost(evt *slack.MessageEvent, msg string, opts ...ReplyOption) {
+	cfg := newReplyConfig(opts)
+	cfg.typeReply(b, evt, msg)
+	postParams := slack.PostMessageParameters{
 		AsUser:    true,
 		Username:  b.BotUserID(),
 		LinkNames: 1,
-		UnfurlLinks: true,
-		UnfurlMedia: true,
-	})
-	_, _, _ = b.Client.PostMessage(evt.Channel, slack.MsgOptionText(msg, false), postParams)
+	}
+	unfurl := true
+	out := &OutgoingMessage{Channel: evt.Channel, Text: msg, Params: postParams, UnfurlLinks: &unfurl}
+	cfg.apply(out, evt)
+	b.attachCorrelation(out, evt)
+	b.attachOrigin(out, evt)
+	b.attachJoin(out, evt)
+	b.recordReply(out, evt)
+	_, _ = b.Send(out)
 }
 
 // ReplyWithAttachments replys to a message event with a Slack Attachments message.
-func (b *Bot) ReplyWithAttachments(evt *slack.MessageEvent, attachments []slack.Attachment, typing bool) {
-	if typing {
-		b.Type(evt, "attachment")
-	}
-	postParams := slack.MsgOptionPostMessageParameters(slack.PostMessageParameters{
+func (b *Bot) ReplyWithAttachments(evt *slack.MessageEvent, attachments []slack.Attachment, opts ...ReplyOption) {
+	cfg := newReplyConfig(opts)
+	cfg.typeReply(b, evt, "attachment")
+	postParams := slack.PostMessageParameters{
 		AsUser:    true,
 		Username:  b.botUserID,
 		LinkNames: 1,
-	})
-	_, _, _ = b.Client.PostMessage(evt.Msg.Channel, slack.MsgOptionAttachments(attachments...), postParams)
+	}
+	out := &OutgoingMessage{Channel: evt.Msg.Channel, Attachments: attachments, Params: postParams}
+	cfg.apply(out, evt)
+	b.attachCorrelation(out, evt)
+	b.attachOrigin(out, evt)
+	b.attachJoin(out, evt)
+	_, _ = b.Send(out)
 }
 
-// Type sends a typing message and simulates delay (max 2000ms) based on message size.
+// Type sends a typing message and simulates a delay based on message size, as configured
+// by the route or the bot.
 func (b *Bot) Type(evt *slack.MessageEvent, msg interface{}) {
-	msgLen := msgLen(msg)
-
-	sleepDuration := time.Minute * time.Duration(msgLen) / 3000
-	if sleepDuration > maxTypingSleepMs {
-		sleepDuration = maxTypingSleepMs
-	}
-
-	b.RTM.SendMessage(b.RTM.NewTypingMessage(evt.Channel))
-	time.Sleep(sleepDuration)
+	b.TypeWith(evt, msg, b.typingFor(evt))
 }
 
 // BotUserID Fetch the botUserID.
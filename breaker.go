@@ -0,0 +1,175 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const CIRCUIT_CONTEXT = "__CIRCUIT_CONTEXT__"
+
+// ErrCircuitOpen is returned by CircuitBreaker.Do while the circuit is open.
+var ErrCircuitOpen = errors.New("slackbot: circuit open")
+
+// CircuitOpenText is the reply to messages of a route whose circuit is open, unless the
+// route sets another one.
+var CircuitOpenText = "This command is unavailable for now, please try again in a few minutes."
+
+// CircuitBreaker stops calling a failing dependency for a while, so requests fail fast
+// instead of each waiting for a timeout. The circuit opens after threshold failures within
+// the cooldown, stays open for the cooldown, and then lets a single trial call through:
+// its success closes the circuit, its failure opens it again.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  []time.Time
+	openUntil time.Time
+	trial     bool
+}
+
+// NewCircuitBreaker returns a closed circuit breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Allow returns false if calls must not be made while the circuit is open.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.openUntil.IsZero() {
+		return true
+	}
+	if cb.trial || cb.now().Before(cb.openUntil) {
+		return false
+	}
+	cb.trial = true
+	return true
+}
+
+// Open returns true while calls are refused.
+func (cb *CircuitBreaker) Open() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return !cb.openUntil.IsZero()
+}
+
+// Success records a successful call, closing the circuit after a trial.
+func (cb *CircuitBreaker) Success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.trial {
+		cb.trial = false
+		cb.openUntil = time.Time{}
+		cb.failures = nil
+	}
+}
+
+// Failure records a failed call, opening the circuit after too many.
+func (cb *CircuitBreaker) Failure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := cb.now()
+	if cb.trial {
+		cb.trial = false
+		cb.openUntil = now.Add(cb.cooldown)
+		return
+	}
+	recent := cb.failures[:0]
+	for _, t := range cb.failures {
+		if now.Sub(t) < cb.cooldown {
+			recent = append(recent, t)
+		}
+	}
+	cb.failures = append(recent, now)
+	if len(cb.failures) >= cb.threshold {
+		cb.openUntil = now.Add(cb.cooldown)
+		cb.failures = nil
+	}
+}
+
+// Do calls fn unless the circuit is open, and records its result.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if !cb.Allow() {
+		return ErrCircuitOpen
+	}
+	if err := fn(); err != nil {
+		cb.Failure()
+		return err
+	}
+	cb.Success()
+	return nil
+}
+
+// circuitCall is a handler run guarded by a circuit breaker, failed by HandleError. Its
+// success is recorded once finished, when the handler returns unless its work goes on in
+// the background, see finishLater.
+type circuitCall struct {
+	cb       *CircuitBreaker
+	mu       sync.Mutex
+	failed   bool
+	async    bool
+	finished bool
+}
+
+func (c *circuitCall) fail() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.failed {
+		c.failed = true
+		c.cb.Failure()
+	}
+}
+
+func (c *circuitCall) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.finished {
+		c.finished = true
+		if !c.failed {
+			c.cb.Success()
+		}
+	}
+}
+
+// finishLater defers the outcome of the circuit call in context, if any, to the returned
+// function, called once the work started by the handler is done.
+func finishLater(ctx context.Context) func() {
+	call, ok := ctx.Value(CIRCUIT_CONTEXT).(*circuitCall)
+	if !ok {
+		return func() {}
+	}
+	call.mu.Lock()
+	call.async = true
+	call.mu.Unlock()
+	return call.finish
+}
+
+// CircuitBreaker guards the route with the circuit breaker. Errors reported by its handler
+// with HandleError, including errors of typed and acknowledged handlers and recovered
+// panics, count as failures. While the circuit is open, messages are answered with the
+// fallback text, or CircuitOpenText when empty.
+func (r *Route) CircuitBreaker(cb *CircuitBreaker, fallback string) *Route {
+	if fallback == "" {
+		fallback = CircuitOpenText
+	}
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			if !cb.Allow() {
+				BotFromContext(ctx).Reply(MessageFromContext(ctx), fallback)
+				return
+			}
+			call := &circuitCall{cb: cb}
+			next(context.WithValue(ctx, CIRCUIT_CONTEXT, call))
+			call.mu.Lock()
+			async := call.async
+			call.mu.Unlock()
+			if !async {
+				call.finish()
+			}
+		}
+	})
+}
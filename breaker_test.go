@@ -0,0 +1,73 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+	fail := func() error { return errors.New("down") }
+	ok := func() error { return nil }
+
+	assert.EqualError(cb.Do(fail), "down")
+	// failures older than the cooldown are forgotten
+	now = now.Add(2 * time.Minute)
+	assert.EqualError(cb.Do(fail), "down")
+	assert.False(cb.Open())
+	assert.EqualError(cb.Do(fail), "down")
+	assert.True(cb.Open())
+	assert.Equal(ErrCircuitOpen, cb.Do(ok))
+
+	// a failed trial opens the circuit again
+	now = now.Add(time.Minute)
+	assert.EqualError(cb.Do(fail), "down")
+	assert.Equal(ErrCircuitOpen, cb.Do(ok))
+
+	now = now.Add(time.Minute)
+	assert.NoError(cb.Do(ok))
+	assert.False(cb.Open())
+	assert.NoError(cb.Do(ok))
+}
+
+func TestCircuitBreakerAck(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreaker(1, time.Minute)
+	cb.now = func() time.Time { return now }
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	results := make(chan error)
+	bot.Hear("^deploy$").CircuitBreaker(cb, "").AckHandler(func(ctx context.Context, bot *Bot, msg *slack.MessageEvent) (string, error) {
+		return "", <-results
+	})
+	deploy := func() {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U1", "deploy"
+		bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	}
+
+	cb.Failure()
+	now = now.Add(time.Minute)
+	// the trial is decided by the acknowledged work, not by the handler returning
+	deploy()
+	assert.True(cb.Open())
+	results <- errors.New("down")
+	api.wait("chat.update", 1)
+	assert.True(cb.Open())
+	assert.False(cb.Allow())
+
+	now = now.Add(time.Minute)
+	deploy()
+	results <- nil
+	api.wait("chat.update", 2)
+	assert.False(cb.Open())
+}
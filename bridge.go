@@ -0,0 +1,68 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// BridgeOptions configures Bot.Bridge.
+type BridgeOptions struct {
+	// Post relayed messages with the name and avatar of their author instead of prefixing
+	// them with the name; requires the chat:write.customize scope
+	Impersonate bool
+	// Only relay the messages accepted by the filter, when set
+	Filter func(msg *slack.MessageEvent) bool
+}
+
+// Bridge relays the messages of each channel to the other one, attributed to their authors,
+// e.g. between a support desk and a customer channel. Messages posted by bots, including the
+// relayed ones, are not relayed so messages do not loop.
+func (b *Bot) Bridge(channelA, channelB string, opts BridgeOptions) *Bot {
+	b.RequireScopes("chat:write", "users:read")
+	if opts.Impersonate {
+		b.RequireScopes("chat:write.customize")
+	}
+	return b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.BotID != "" || msg.SubType != "" || msg.User == "" {
+			return
+		}
+		var to string
+		switch msg.Channel {
+		case channelA:
+			to = channelB
+		case channelB:
+			to = channelA
+		default:
+			return
+		}
+		if opts.Filter != nil && !opts.Filter(msg) {
+			return
+		}
+		if err := bot.bridgeMessage(msg, to, opts); err != nil {
+			fmt.Printf("Error bridging message: %s\n", err)
+		}
+	})
+}
+
+func (b *Bot) bridgeMessage(msg *slack.MessageEvent, to string, opts BridgeOptions) error {
+	out := &OutgoingMessage{Channel: to, Text: msg.Text}
+	user, err := b.Client.GetUserInfo(msg.User)
+	name := msg.User
+	if err == nil {
+		name = user.Profile.DisplayName
+		if name == "" {
+			name = user.RealName
+		}
+	}
+	if opts.Impersonate && err == nil {
+		out.Params.Username = name
+		out.Params.IconURL = user.Profile.Image72
+	} else {
+		out.Text = fmt.Sprintf("*%s*: %s", name, msg.Text)
+	}
+	_, err = b.Send(out)
+	return err
+}
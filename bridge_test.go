@@ -0,0 +1,50 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBridge(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U1", "real_name": "Ada Lovelace", "profile": {"display_name": "ada", "image_72": "https://example.com/ada.png"}}}`)
+	bot.Bridge("C1", "C2", BridgeOptions{Filter: func(msg *slack.MessageEvent) bool {
+		return !strings.HasPrefix(msg.Text, "(private)")
+	}})
+	ctx := AddBotToContext(context.Background(), bot)
+	handle := func(channel, text, botID string) {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text, evt.BotID = channel, "U1", text, botID
+		bot.handleMessage(ctx, evt)
+	}
+
+	handle("C1", "hello", "")
+	handle("C2", "hi back", "")
+	handle("C3", "elsewhere", "")
+	handle("C1", "(private) notes", "")
+	handle("C2", "*ada*: relayed", "B1")
+	assert.Equal([]string{"C2", "C1"}, api.values("chat.postMessage", "channel"))
+	assert.Equal([]string{"*ada*: hello", "*ada*: hi back"}, api.values("chat.postMessage", "text"))
+}
+
+func TestBridgeImpersonate(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U1", "real_name": "Ada Lovelace", "profile": {"image_72": "https://example.com/ada.png"}}}`)
+	bot.Bridge("C1", "C2", BridgeOptions{Impersonate: true})
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", "hello"
+	bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	assert.Equal([]string{"hello"}, api.values("chat.postMessage", "text"))
+	assert.Equal([]string{"Ada Lovelace"}, api.values("chat.postMessage", "username"))
+	assert.Equal([]string{"https://example.com/ada.png"}, api.values("chat.postMessage", "icon_url"))
+	assert.True(bot.requiredScopes["chat:write.customize"])
+}
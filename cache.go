@@ -0,0 +1,125 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	FRESH_CONTEXT = "__FRESH_CONTEXT__"
+	CACHE_CONTEXT = "__CACHE_CONTEXT__"
+)
+
+// FreshFlag, anywhere in a message to a cached route, bypasses the cache.
+const FreshFlag = "--fresh"
+
+// IsFresh returns true if the message in context asked for a fresh result with FreshFlag.
+func IsFresh(ctx context.Context) bool {
+	fresh, _ := ctx.Value(FRESH_CONTEXT).(bool)
+	return fresh
+}
+
+// stripFresh removes FreshFlag from the text routes are matched against, and records it.
+func stripFresh(ctx context.Context) context.Context {
+	words := strings.Fields(TextFromContext(ctx))
+	kept := words[:0]
+	fresh := false
+	for _, w := range words {
+		if strings.EqualFold(w, FreshFlag) {
+			fresh = true
+		} else {
+			kept = append(kept, w)
+		}
+	}
+	if !fresh {
+		return ctx
+	}
+	ctx = AddTextToContext(ctx, strings.Join(kept, " "))
+	return context.WithValue(ctx, FRESH_CONTEXT, true)
+}
+
+// Cache serves the text replies of the route from the Store of the team for ttl after the
+// handler first ran for a key, derived by keyFn or CoalesceKey when nil, and the name of the
+// route or its patterns. Messages including FreshFlag, which is removed before the route is
+// matched, bypass the cache and refresh it. Handlers replying nothing or failing, as
+// reported with HandleError, are not cached. Only idempotent commands, such as lookups,
+// should be cached.
+func (r *Route) Cache(ttl time.Duration, keyFn KeyFunc) *Route {
+	if keyFn == nil {
+		keyFn = CoalesceKey
+	}
+	r.cached = true
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			evt := MessageFromContext(ctx)
+			store := TeamStore(ctx)
+			key := "cache:" + r.cacheID() + ":" + keyFn(ctx)
+
+			if !IsFresh(ctx) {
+				var replies []string
+				data, found, err := store.Get(key)
+				if err == nil && found && json.Unmarshal(data, &replies) == nil {
+					for _, text := range replies {
+						bot.Reply(evt, text)
+					}
+					return
+				}
+			}
+
+			call := &cacheCall{}
+			rec, release := bot.recordReplies(evt)
+			func() {
+				defer release()
+				next(context.WithValue(ctx, CACHE_CONTEXT, call))
+			}()
+			texts := rec.texts()
+			if call.isFailed() || len(texts) == 0 {
+				return
+			}
+			data, err := json.Marshal(texts)
+			if err == nil {
+				err = store.Set(key, data, ttl)
+			}
+			if err != nil {
+				fmt.Printf("Error caching replies: %s\n", err)
+			}
+		}
+	})
+}
+
+// cacheID identifies the route in cache keys by its name, or its patterns when not named.
+func (r *Route) cacheID() string {
+	if r.name != "" {
+		return r.name
+	}
+	var patterns []string
+	for _, m := range r.matchers {
+		if rm, ok := m.(*RegexpMatcher); ok {
+			patterns = append(patterns, rm.regex)
+		}
+	}
+	return strings.Join(patterns, "|")
+}
+
+// cacheCall records whether the handler of a cached request failed.
+type cacheCall struct {
+	mu     sync.Mutex
+	failed bool
+}
+
+func (c *cacheCall) fail() {
+	c.mu.Lock()
+	c.failed = true
+	c.mu.Unlock()
+}
+
+func (c *cacheCall) isFailed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.failed
+}
@@ -0,0 +1,100 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var replies []string
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		replies = append(replies, msg.Text)
+		return false
+	})
+
+	calls := 0
+	route := bot.Hear("^weather (\\w+)$").Cache(time.Minute, nil).Handler(func(ctx context.Context) {
+		calls++
+		city := submatches("^weather (\\w+)$", TextFromContext(ctx))[1]
+		BotFromContext(ctx).Reply(MessageFromContext(ctx), fmt.Sprintf("%s: sunny #%d", city, calls))
+	})
+
+	run := func(text string) bool {
+		evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Text: text}}
+		ctx := AddTextToContext(AddMessageToContext(AddBotToContext(context.Background(), bot), evt), text)
+		var match RouteMatch
+		if ok, ctx := route.Match(ctx, &match); ok {
+			match.Handler(ctx)
+			return true
+		}
+		return false
+	}
+
+	assert.True(run("weather paris"))
+	assert.True(run("weather paris"))
+	assert.True(run("weather paris --fresh"))
+	assert.True(run("weather paris"))
+	assert.Equal(2, calls)
+	assert.Equal([]string{"paris: sunny #1", "paris: sunny #1", "paris: sunny #2", "paris: sunny #2"}, replies)
+}
+
+func TestCacheKeys(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var replies []string
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		replies = append(replies, msg.Text)
+		return false
+	})
+
+	calls := 0
+	fail := true
+	routes := []*Route{
+		bot.Hear("^status$").Cache(time.Minute, nil).Handler(func(ctx context.Context) {
+			calls++
+			if fail {
+				BotFromContext(ctx).HandleError(ctx, fmt.Errorf("unavailable"))
+				BotFromContext(ctx).Reply(MessageFromContext(ctx), "status unavailable")
+				return
+			}
+			BotFromContext(ctx).Reply(MessageFromContext(ctx), fmt.Sprintf("up #%d", calls))
+		}),
+		// same key, but another route
+		bot.Hear("^(?i)STATUS$").Cache(time.Minute, nil).Handler(func(ctx context.Context) {
+			BotFromContext(ctx).Reply(MessageFromContext(ctx), "other route")
+		}),
+		bot.Hear("^quiet$").Cache(time.Minute, nil).Handler(func(ctx context.Context) {
+			calls++
+		}),
+	}
+	run := func(route *Route, team, text string) {
+		evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Text: text}}
+		ctx := AddTextToContext(AddMessageToContext(AddBotToContext(context.Background(), bot), evt), text)
+		ctx = AddTeamToContext(ctx, team)
+		var match RouteMatch
+		if ok, ctx := route.Match(ctx, &match); ok {
+			match.Handler(ctx)
+		}
+	}
+
+	// failures are not cached
+	run(routes[0], "T1", "status")
+	fail = false
+	run(routes[0], "T1", "status")
+	run(routes[0], "T1", "status")
+	run(routes[1], "T1", "status")
+	// nor are handlers replying nothing
+	run(routes[2], "T1", "quiet")
+	run(routes[2], "T1", "quiet")
+	// workspaces have their own cache
+	run(routes[0], "T2", "status")
+	assert.Equal(5, calls)
+	assert.Equal([]string{"status unavailable", "up #2", "up #2", "other route", "up #5"}, replies)
+}
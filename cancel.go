@@ -0,0 +1,140 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// CancelReaction is the emoji which, added to the message of a running command, cancels it.
+// Replying "cancel" in its thread does the same. Handlers see their context cancelled, and
+// the background jobs started by the command stop. Note that messages are only received
+// while a handler runs when handlers run concurrently, see Bot.Workers.
+const CancelReaction = "x"
+
+// CancelledText acknowledges the cancellation of a command.
+var CancelledText = "Cancelled."
+
+// cancelHandle is the cancellation of a command running on behalf of a user, registered
+// under the messages which may cancel it.
+type cancelHandle struct {
+	user   string
+	ctx    context.Context
+	cancel context.CancelFunc
+	keys   []string
+}
+
+// cancellations indexes the handles of the running commands by message.
+type cancellations struct {
+	mu      sync.Mutex
+	handles map[string][]*cancelHandle
+}
+
+// messageKey identifies a message by channel and ts.
+func messageKey(channel, ts string) string {
+	return channel + ":" + ts
+}
+
+// add registers the handle under the messages, ignoring empty timestamps and finished commands.
+func (c *cancellations) add(h *cancelHandle, channel string, timestamps ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if h.ctx.Err() != nil {
+		return
+	}
+	if c.handles == nil {
+		c.handles = make(map[string][]*cancelHandle)
+	}
+	for _, ts := range timestamps {
+		if ts == "" {
+			continue
+		}
+		key := messageKey(channel, ts)
+		h.keys = append(h.keys, key)
+		c.handles[key] = append(c.handles[key], h)
+	}
+}
+
+func (c *cancellations) remove(h *cancelHandle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range h.keys {
+		handles := c.handles[key][:0]
+		for _, other := range c.handles[key] {
+			if other != h {
+				handles = append(handles, other)
+			}
+		}
+		if len(handles) == 0 {
+			delete(c.handles, key)
+		} else {
+			c.handles[key] = handles
+		}
+	}
+	h.keys = nil
+}
+
+func (c *cancellations) lookup(channel, ts string) []*cancelHandle {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*cancelHandle(nil), c.handles[messageKey(channel, ts)]...)
+}
+
+// cancelCommands cancels the commands registered under the message on behalf of the user,
+// who must have started them or be an admin, and returns how many were cancelled.
+func (b *Bot) cancelCommands(channel, ts, user string) int {
+	cancelled := 0
+	for _, h := range b.cancels.lookup(channel, ts) {
+		if h.user != user && !b.IsAdmin(user) || h.ctx.Err() != nil {
+			continue
+		}
+		h.cancel()
+		cancelled++
+	}
+	return cancelled
+}
+
+// cancellable returns a context of the handler run for the message, cancelled when its
+// sender adds CancelReaction to it or replies "cancel" in its thread, and the function
+// to call once the handler returned.
+func (b *Bot) cancellable(ctx context.Context, evt *slack.MessageEvent) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	h := &cancelHandle{user: evt.User, ctx: ctx, cancel: cancel}
+	b.cancels.add(h, evt.Channel, evt.Timestamp, evt.ThreadTimestamp)
+	return ctx, func() {
+		cancel()
+		b.cancels.remove(h)
+	}
+}
+
+// cancelOnReply cancels the commands running in the thread of the message when it is a
+// "cancel" reply. It returns false when there was nothing to cancel, the message being
+// routed as usual then.
+func (b *Bot) cancelOnReply(ctx context.Context, evt *slack.MessageEvent) bool {
+	if evt.ThreadTimestamp == "" || !strings.EqualFold(strings.TrimSpace(TextFromContext(ctx)), "cancel") {
+		return false
+	}
+	if b.cancelCommands(evt.Channel, evt.ThreadTimestamp, evt.User) == 0 {
+		return false
+	}
+	b.Reply(evt, CancelledText, InThread())
+	return true
+}
+
+// cancelOnReaction cancels the commands running for the message CancelReaction was added to.
+func (b *Bot) cancelOnReaction(evt interface{}) {
+	reaction, ok := evt.(*slack.ReactionAddedEvent)
+	if !ok || reaction.Reaction != CancelReaction || reaction.Item.Type != "message" {
+		return
+	}
+	if b.cancelCommands(reaction.Item.Channel, reaction.Item.Timestamp, reaction.User) == 0 {
+		return
+	}
+	_, _ = b.Send(&OutgoingMessage{
+		Channel: reaction.Item.Channel,
+		Text:    CancelledText,
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: reaction.Item.Timestamp},
+	})
+}
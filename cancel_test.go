@@ -0,0 +1,93 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelCommand(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Workers(2)
+	sent := make(chan *OutgoingMessage, 10)
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		sent <- msg
+		return false
+	})
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	bot.Hear("^export$").Handler(func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		result <- ctx.Err()
+	})
+
+	ctx := AddBotToContext(context.Background(), bot)
+	bot.handleMessage(ctx, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0", Text: "export"}})
+	<-started
+
+	// only the requester may cancel
+	bot.handleMessage(ctx, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U2", Timestamp: "2.0", ThreadTimestamp: "1.0", Text: "cancel"}})
+	select {
+	case <-result:
+		t.Fatal("cancelled by another user")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	bot.handleMessage(ctx, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "3.0", ThreadTimestamp: "1.0", Text: "Cancel"}})
+	assert.Equal(context.Canceled, <-result)
+	assert.Equal(CancelledText, (<-sent).Text)
+}
+
+func TestCancelJobByReaction(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.BeforeSend(func(msg *OutgoingMessage) bool { return false })
+	started := make(chan struct{})
+	bot.Jobs.Handle("backup", func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0"}}
+	job, err := NewJob(AddMessageToContext(context.Background(), evt), "backup", nil)
+	assert.NoError(err)
+	id, err := bot.Jobs.Enqueue(job)
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+	<-started
+
+	reaction := &slack.ReactionAddedEvent{User: "U1", Reaction: CancelReaction}
+	reaction.Item.Type = "message"
+	reaction.Item.Channel, reaction.Item.Timestamp = "C1", "1.0"
+	bot.handleEvent(AddBotToContext(ctx, bot), "reaction_added", reaction)
+	cancelled := waitJob(t, bot, id, JobCancelled)
+	assert.Equal(1, cancelled.Attempts)
+	assert.Equal(ErrJobNotFound, bot.Jobs.Cancel("unknown"))
+}
+
+func TestCancelPendingJob(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	runs := make(chan string, 2)
+	bot.Jobs.Handle("sync", func(ctx context.Context, job *Job) error {
+		runs <- job.ID
+		return nil
+	})
+	first, _ := bot.Jobs.Enqueue(&Job{Kind: "sync"})
+	second, _ := bot.Jobs.Enqueue(&Job{Kind: "sync"})
+	assert.NoError(bot.Jobs.Cancel(first))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+	assert.Equal(second, <-runs)
+	job, _, _ := bot.Jobs.Get(first)
+	assert.Equal(JobCancelled, job.Status)
+}
@@ -0,0 +1,43 @@
+package slackbot
+
+import (
+	"context"
+	"regexp"
+)
+
+// InChannelsMatching restricts the route to messages posted in channels whose name matches
+// the pattern, e.g. "^proj-". Channel names are resolved through the channel cache.
+func (r *Route) InChannelsMatching(pattern string) *Route {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	return r.AddMatcher(&ChannelNameMatcher{regex: re})
+}
+
+// ============================================================================
+// Channel Name Matcher
+// ============================================================================
+
+type ChannelNameMatcher struct {
+	regex     *regexp.Regexp
+	botUserID string
+}
+
+func (cm *ChannelNameMatcher) Match(ctx context.Context) (bool, context.Context) {
+	bot := BotFromContext(ctx)
+	msg := MessageFromContext(ctx)
+	if bot == nil || msg == nil {
+		return false, ctx
+	}
+	channel, err := bot.ChannelInfo(msg.Channel)
+	if err != nil {
+		return false, ctx
+	}
+	return cm.regex.MatchString(channel.Name), ctx
+}
+
+func (cm *ChannelNameMatcher) SetBotID(botID string) {
+	cm.botUserID = botID
+}
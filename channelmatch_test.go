@@ -0,0 +1,40 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInChannelsMatching(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.info", `{"ok": false, "error": "channel_not_found"}`)
+	expires := time.Now().Add(time.Hour)
+	bot.channels.channels = map[string]cachedChannel{}
+	for id, name := range map[string]string{"C1": "proj-web", "C2": "random"} {
+		channel := &slack.Channel{}
+		channel.ID, channel.Name = id, name
+		bot.channels.channels[id] = cachedChannel{channel: channel, expires: expires}
+	}
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^status$").InChannelsMatching("^proj-").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, evt.Channel)
+	})
+	invalid := bot.Hear("^status$").InChannelsMatching("(")
+
+	for _, channel := range []string{"C1", "C2", "C3"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = channel, "U1", "status"
+		bot.handleMessage(ctx, evt)
+	}
+	assert.Equal([]string{"C1"}, heard)
+	// unknown channels are looked up, and never match
+	assert.Equal([]string{"C3"}, api.values("conversations.info", "channel"))
+	assert.Error(invalid.GetError())
+}
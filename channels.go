@@ -0,0 +1,64 @@
+package slackbot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// channelCacheTTL is how long conversation details are cached.
+const channelCacheTTL = 10 * time.Minute
+
+type cachedChannel struct {
+	channel *slack.Channel
+	expires time.Time
+}
+
+// channelCache keeps the details of conversations, keyed by ID.
+type channelCache struct {
+	mu       sync.Mutex
+	channels map[string]cachedChannel
+}
+
+// ChannelInfo returns the details of a conversation, cached for a few minutes.
+func (b *Bot) ChannelInfo(channelID string) (*slack.Channel, error) {
+	b.channels.mu.Lock()
+	cached, ok := b.channels.channels[channelID]
+	b.channels.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.channel, nil
+	}
+
+	channel, err := b.Client.GetConversationInfo(channelID, false)
+	if err != nil {
+		return nil, err
+	}
+	b.channels.mu.Lock()
+	if b.channels.channels == nil {
+		b.channels.channels = make(map[string]cachedChannel)
+	}
+	b.channels.channels[channelID] = cachedChannel{channel: channel, expires: time.Now().Add(channelCacheTTL)}
+	b.channels.mu.Unlock()
+	return channel, nil
+}
+
+// IsGroupDM returns true if the conversation is a multi-person direct message.
+func (b *Bot) IsGroupDM(channelID string) bool {
+	channel, err := b.ChannelInfo(channelID)
+	return err == nil && channel.IsMpIM
+}
+
+// OpenGroupDM opens, or reuses, a multi-person direct message with the users and the bot,
+// and returns its channel ID.
+func (b *Bot) OpenGroupDM(userIDs ...string) (string, error) {
+	var channel *slack.Channel
+	err := withRetry(func() (err error) {
+		channel, _, _, err = b.Client.OpenConversation(&slack.OpenConversationParameters{Users: userIDs})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return channel.ID, nil
+}
@@ -0,0 +1,45 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMessage(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.info", `{"ok": true, "channel": {"id": "G1", "is_mpim": true}}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^standup$").Messages(GroupMessage).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, evt.Channel)
+	})
+
+	for i := 0; i < 2; i++ {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "G1", "U1", "standup"
+		bot.handleMessage(ctx, evt)
+	}
+	assert.Equal([]string{"G1", "G1"}, heard)
+	// conversation details are cached
+	assert.Equal([]string{"G1"}, api.values("conversations.info", "channel"))
+
+	api.respond("conversations.info", `{"ok": false, "error": "channel_not_found"}`)
+	assert.False(bot.IsGroupDM("C2"))
+}
+
+func TestOpenGroupDM(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.open", `{"ok": true, "channel": {"id": "G1"}}`)
+
+	channel, err := bot.OpenGroupDM("U1", "U2")
+	assert.NoError(err)
+	assert.Equal("G1", channel)
+	assert.Equal([]string{"U1,U2"}, api.values("conversations.open", "users"))
+}
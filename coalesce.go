@@ -0,0 +1,94 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// CoalesceKey identifies a request by its text, ignoring case and spacing.
+func CoalesceKey(ctx context.Context) string {
+	return strings.ToLower(strings.Join(strings.Fields(TextFromContext(ctx)), " "))
+}
+
+// replyRecorder collects the text replies to a message event.
+type replyRecorder struct {
+	mu      sync.Mutex
+	replies []string
+}
+
+func (r *replyRecorder) add(text string) {
+	r.mu.Lock()
+	r.replies = append(r.replies, text)
+	r.mu.Unlock()
+}
+
+func (r *replyRecorder) texts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.replies...)
+}
+
+// recordReplies starts collecting the text replies to the message event, until release is
+// called. Recorders of the same event are shared.
+func (b *Bot) recordReplies(evt *slack.MessageEvent) (rec *replyRecorder, release func()) {
+	v, loaded := b.recorders.LoadOrStore(evt, &replyRecorder{})
+	if loaded {
+		return v.(*replyRecorder), func() {}
+	}
+	return v.(*replyRecorder), func() { b.recorders.Delete(evt) }
+}
+
+// flight is a request being handled, whose text replies are shared with the identical
+// requests arriving meanwhile.
+type flight struct {
+	rec  *replyRecorder
+	done chan struct{}
+}
+
+// Coalesce makes identical requests to the route, as identified by keyFn or CoalesceKey when
+// nil, run the handler once while it is in flight: the requests arriving meanwhile receive
+// the text replies of the first one. Handlers must run concurrently, see Bot.Workers.
+func (r *Route) Coalesce(keyFn KeyFunc) *Route {
+	if keyFn == nil {
+		keyFn = CoalesceKey
+	}
+	var mu sync.Mutex
+	flights := make(map[string]*flight)
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			evt := MessageFromContext(ctx)
+			key := keyFn(ctx)
+
+			mu.Lock()
+			if f, ok := flights[key]; ok {
+				mu.Unlock()
+				select {
+				case <-f.done:
+				case <-ctx.Done():
+					return
+				}
+				for _, text := range f.rec.texts() {
+					bot.Reply(evt, text)
+				}
+				return
+			}
+			rec, release := bot.recordReplies(evt)
+			f := &flight{rec: rec, done: make(chan struct{})}
+			flights[key] = f
+			mu.Unlock()
+
+			defer func() {
+				release()
+				mu.Lock()
+				delete(flights, key)
+				mu.Unlock()
+				close(f.done)
+			}()
+			next(ctx)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesce(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var mu sync.Mutex
+	replies := map[string][]string{}
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		mu.Lock()
+		replies[msg.Channel] = append(replies[msg.Channel], msg.Text)
+		mu.Unlock()
+		return false
+	})
+
+	var calls int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+	route := bot.Hear("(?i)status").Coalesce(nil).Handler(func(ctx context.Context) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		BotFromContext(ctx).Reply(MessageFromContext(ctx), "prod is up")
+	})
+
+	run := func(channel, text string) {
+		evt := &slack.MessageEvent{Msg: slack.Msg{Channel: channel, Text: text}}
+		ctx := AddTextToContext(AddMessageToContext(AddBotToContext(context.Background(), bot), evt), text)
+		var match RouteMatch
+		route.Match(ctx, &match)
+		match.Handler(ctx)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); run("C1", "status") }()
+	<-started
+	go func() { defer wg.Done(); run("C2", "Status ") }()
+	// let the second request join the flight of the first
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+	assert.Equal([]string{"prod is up"}, replies["C1"])
+	assert.Equal([]string{"prod is up"}, replies["C2"])
+}
@@ -0,0 +1,94 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Command is a node of a tree of subcommands, e.g. "config get <key>" and "config set <key>
+// <value>" under "config". Invoking a command without a known subcommand replies its help.
+type Command struct {
+	path   string
+	router *SimpleRouter
+	usages []string
+}
+
+// Cmd registers a top level command.
+func (b *Bot) Cmd(name string) *Command {
+	c := &Command{path: name, router: &SimpleRouter{}}
+	b.Hear(commandRegexp(name)).Usage(name + " <subcommand>").Handler(c.dispatch).command = c
+	return c
+}
+
+// Cmd adds a nested command level, e.g. bot.Cmd("config").Cmd("user").Sub("get <key>", h).
+func (c *Command) Cmd(name string) *Command {
+	sub := &Command{path: c.path + " " + name, router: &SimpleRouter{}}
+	c.router.Hear(commandRegexp(sub.path)).Handler(sub.dispatch).command = sub
+	c.usages = append(c.usages, sub.path+" <subcommand>")
+	return sub
+}
+
+// Sub adds a subcommand described by its usage. Its arguments are validated as strings,
+// required in angle brackets and optional in square brackets, and available through
+// ArgsFromContext.
+func (c *Command) Sub(usage string, handler MessageHandler) *Command {
+	full := c.path + " " + usage
+	command := strings.Join(usageCommand(full), " ")
+	c.router.Hear(commandRegexp(command)).Usage(full).Args(usageSchema(full)).MessageHandler(handler)
+	c.usages = append(c.usages, full)
+	return c
+}
+
+// Help returns the usages of the subcommands.
+func (c *Command) Help() string {
+	lines := make([]string, len(c.usages))
+	for i, usage := range c.usages {
+		lines[i] = "• `" + usage + "`"
+	}
+	return fmt.Sprintf("Usage of `%s`:\n%s", c.path, strings.Join(lines, "\n"))
+}
+
+func (c *Command) dispatch(ctx context.Context) {
+	var match RouteMatch
+	if matched, ctx := c.router.Match(ctx, &match); matched {
+		match.Handler(ctx)
+		return
+	}
+	bot := BotFromContext(ctx)
+	msg := MessageFromContext(ctx)
+	help := c.Help()
+	if rest := c.rest(ctx); rest != "" && !strings.EqualFold(rest, "help") {
+		help = fmt.Sprintf("Unknown subcommand `%s`.\n%s", rest, help)
+	}
+	bot.Reply(msg, help)
+}
+
+// rest returns the text following the command path.
+func (c *Command) rest(ctx context.Context) string {
+	words := strings.Fields(TextFromContext(ctx))
+	n := len(strings.Fields(c.path))
+	if len(words) <= n {
+		return ""
+	}
+	return words[n]
+}
+
+// commandRegexp matches text starting with the words of the command.
+func commandRegexp(command string) string {
+	words := strings.Fields(command)
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	return `(?i)^` + strings.Join(words, `\s+`) + `(\s|$)`
+}
+
+// usageSchema derives a schema of string arguments from a usage.
+func usageSchema(usage string) Schema {
+	var schema Schema
+	for _, w := range strings.Fields(usage)[len(usageCommand(usage)):] {
+		schema = append(schema, Arg{Name: strings.Trim(w, "<>[]"), Required: strings.HasPrefix(w, "<")})
+	}
+	return schema
+}
@@ -0,0 +1,40 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmd(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	ctx := AddBotToContext(context.Background(), bot)
+	var ran []string
+	config := bot.Cmd("config")
+	config.Sub("get <key>", func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		ran = append(ran, "get "+ArgsFromContext(ctx).String("key"))
+	})
+	config.Cmd("user").Sub("set <key> [value]", func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		args := ArgsFromContext(ctx)
+		ran = append(ran, "user set "+args.String("key")+"="+args.String("value"))
+	})
+
+	for _, text := range []string{"config get region", "CONFIG user set theme dark", "config user set theme", "config"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U1", text
+		bot.handleMessage(ctx, evt)
+	}
+	assert.Equal([]string{"get region", "user set theme=dark", "user set theme="}, ran)
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", "config user drop"
+	bot.handleMessage(ctx, evt)
+	assert.Equal([]string{
+		"Usage of `config`:\n• `config get <key>`\n• `config user <subcommand>`",
+		"Unknown subcommand `drop`.\nUsage of `config user`:\n• `config user set <key> [value]`",
+	}, api.values("chat.postMessage", "text"))
+}
@@ -8,6 +8,7 @@ import (
 const (
 	BOT_CONTEXT     = "__BOT_CONTEXT__"
 	MESSAGE_CONTEXT = "__MESSAGE_CONTEXT__"
+	TEXT_CONTEXT    = "__TEXT_CONTEXT__"
 )
 
 func BotFromContext(ctx context.Context) *Bot {
@@ -33,3 +34,8 @@ func MessageFromContext(ctx context.Context) *slack.MessageEvent {
 func AddMessageToContext(ctx context.Context, msg *slack.MessageEvent) context.Context {
 	return context.WithValue(ctx, MESSAGE_CONTEXT, msg)
 }
+
+// AddTextToContext sets the text routes are matched against and returns the newly derived context
+func AddTextToContext(ctx context.Context, text string) context.Context {
+	return context.WithValue(ctx, TEXT_CONTEXT, text)
+}
@@ -0,0 +1,93 @@
+package slackbot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const CORRELATION_CONTEXT = "__CORRELATION_CONTEXT__"
+
+// CorrelationEventType is the metadata event type of replies carrying a correlation ID.
+const CorrelationEventType = "slackbot_correlation"
+
+// correlationTTL is how long the correlation ID of a message is kept.
+const correlationTTL = 7 * 24 * time.Hour
+
+// Correlate gives every conversation a correlation ID, shared by the messages of a thread,
+// including the replies of the bot and of users in the threads of those replies. It is
+// attached to the replies as message metadata, so multi-message workflows can be stitched
+// together in logs.
+func (b *Bot) Correlate() *Bot {
+	b.correlate = true
+	return b.AfterSend(func(msg *OutgoingMessage, ts string, err error) {
+		if err != nil || ts == "" || msg.Metadata == nil {
+			return
+		}
+		if id, ok := msg.Metadata.EventPayload["correlation_id"].(string); ok {
+			_ = b.Store().Set(correlationKey(msg.Channel, ts), []byte(id), correlationTTL)
+		}
+	})
+}
+
+// CorrelationID returns the correlation ID of the message in context, empty unless the bot
+// correlates messages.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(CORRELATION_CONTEXT).(string)
+	return id
+}
+
+func correlationKey(channel, ts string) string {
+	return "correlation:" + channel + ":" + ts
+}
+
+// correlationFor returns the correlation ID of the thread of the message, or a new one, and
+// records it for the message.
+func (b *Bot) correlationFor(evt *slack.MessageEvent) string {
+	var id string
+	if evt.ThreadTimestamp != "" {
+		if data, found, err := b.Store().Get(correlationKey(evt.Channel, evt.ThreadTimestamp)); err == nil && found {
+			id = string(data)
+		}
+	}
+	if id == "" {
+		id = newCorrelationID()
+		if evt.ThreadTimestamp != "" {
+			_ = b.Store().Set(correlationKey(evt.Channel, evt.ThreadTimestamp), []byte(id), correlationTTL)
+		}
+	}
+	_ = b.Store().Set(correlationKey(evt.Channel, evt.Timestamp), []byte(id), correlationTTL)
+	return id
+}
+
+// attachCorrelation adds the correlation ID of the message event to the metadata of a reply.
+func (b *Bot) attachCorrelation(msg *OutgoingMessage, evt *slack.MessageEvent) {
+	if !b.correlate || msg.EphemeralUser != "" {
+		return
+	}
+	data, found, err := b.Store().Get(correlationKey(evt.Channel, evt.Timestamp))
+	if err != nil || !found {
+		return
+	}
+	metadata := MessageMetadata{EventType: CorrelationEventType}
+	if msg.Metadata != nil {
+		metadata.EventType = msg.Metadata.EventType
+	}
+	metadata.EventPayload = map[string]interface{}{"correlation_id": string(data)}
+	if msg.Metadata != nil {
+		for k, v := range msg.Metadata.EventPayload {
+			metadata.EventPayload[k] = v
+		}
+	}
+	msg.Metadata = &metadata
+	msg.RTM = false
+}
+
+func newCorrelationID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
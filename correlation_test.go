@@ -0,0 +1,33 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Correlate()
+	assert.Empty(CorrelationID(context.Background()))
+
+	root := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Timestamp: "1.0"}}
+	id := bot.correlationFor(root)
+	assert.Len(id, 16)
+
+	reply := &OutgoingMessage{Channel: "C1", RTM: true}
+	bot.attachCorrelation(reply, root)
+	assert.False(reply.RTM)
+	assert.Equal(CorrelationEventType, reply.Metadata.EventType)
+	assert.Equal(id, reply.Metadata.EventPayload["correlation_id"])
+
+	// a user answering in the thread of the reply of the bot
+	bot.afterSend[0](reply, "2.0", nil)
+	answer := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Timestamp: "3.0", ThreadTimestamp: "2.0"}}
+	assert.Equal(id, bot.correlationFor(answer))
+
+	other := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Timestamp: "4.0"}}
+	assert.NotEqual(id, bot.correlationFor(other))
+}
@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tokens of Slack date formatting, rendered by each client in the timezone of the viewer.
+// They can be combined with any text, e.g. DateShort + " at " + DateTime.
+const (
+	DateNum         = "{date_num}"
+	Date            = "{date}"
+	DateShort       = "{date_short}"
+	DateLong        = "{date_long}"
+	DatePretty      = "{date_pretty}"
+	DateShortPretty = "{date_short_pretty}"
+	DateLongPretty  = "{date_long_pretty}"
+	DateTime        = "{time}"
+	DateTimeSecs    = "{time_secs}"
+	DateAgo         = "{ago}"
+)
+
+// DateLayoutFallback is the layout of the text shown by clients unable to format dates.
+const DateLayoutFallback = "Mon Jan 2 2006 15:04 MST"
+
+// FormatDate returns a Slack date formatting token showing t in the local timezone of
+// each viewer, e.g. FormatDate(t, DateShortPretty+" at "+DateTime).
+func FormatDate(t time.Time, format string) string {
+	return fmt.Sprintf("<!date^%d^%s|%s>", t.Unix(), format, t.UTC().Format(DateLayoutFallback))
+}
+
+// FormatDateLink is like FormatDate, with the date linking to the URL.
+func FormatDateLink(t time.Time, format, url string) string {
+	return fmt.Sprintf("<!date^%d^%s^%s|%s>", t.Unix(), format, url, t.UTC().Format(DateLayoutFallback))
+}
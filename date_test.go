@@ -0,0 +1,15 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2014, time.February, 18, 14, 39, 42, 0, time.FixedZone("PST", -8*3600))
+
+	assert.Equal(t, "<!date^1392763182^{date_short} at {time}|Tue Feb 18 2014 22:39 UTC>", FormatDate(d, DateShort+" at "+DateTime))
+	assert.Equal(t, "<!date^1392763182^{ago}^https://example.com|Tue Feb 18 2014 22:39 UTC>", FormatDateLink(d, DateAgo, "https://example.com"))
+}
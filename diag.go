@@ -0,0 +1,84 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// EnableDiagCommand adds a `diag` admin command reporting the health of the bot: RTM and
+// Web API latency, Web API error rate, handler durations, busy workers, queued jobs, cache
+// sizes and reconnections.
+func (b *Bot) EnableDiagCommand() *Bot {
+	b.Hear(`(?i)^diag$`).AdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.Reply(evt, bot.diagText())
+	})
+	return b
+}
+
+func (b *Bot) diagText() string {
+	m := b.Metrics()
+	var lines []string
+
+	latency := "unknown"
+	if m.RTMLatency > 0 {
+		latency = m.RTMLatency.Round(time.Millisecond).String()
+	} else if b.withoutRTM {
+		latency = "no RTM connection"
+	}
+	lines = append(lines, fmt.Sprintf("*RTM latency:* %s", latency))
+	l := b.Latency()
+	lines = append(lines, fmt.Sprintf("*Web API:* %d calls, %d errors (%.1f%%), %s on average", m.APICalls, m.APIErrors, 100*m.APIErrorRate(), l.API.Round(time.Millisecond)))
+	if slowest, ml := slowestMethod(l); slowest != "" {
+		lines = append(lines, fmt.Sprintf("*Slowest API method:* `%s`, %s on average, %s at most", slowest, ml.Average.Round(time.Millisecond), ml.Max.Round(time.Millisecond)))
+	}
+	lines = append(lines, fmt.Sprintf("*Handlers:* %d runs, %s on average", m.HandlerRuns, l.Handler.Round(time.Millisecond)))
+	lines = append(lines, fmt.Sprintf("*Messages:* %d received, %d handled", m.MessagesReceived, m.MessagesHandled))
+
+	if b.workers != nil {
+		lines = append(lines, fmt.Sprintf("*Workers:* %d/%d busy", len(b.workers), cap(b.workers)))
+	} else {
+		lines = append(lines, "*Workers:* handlers run one at a time")
+	}
+	lines = append(lines, fmt.Sprintf("*Jobs:* %d queued, %d running", b.Jobs.QueueLength(), b.Jobs.Running()))
+	b.scheduleMu.Lock()
+	scheduled := len(b.scheduled)
+	b.scheduleMu.Unlock()
+	lines = append(lines, fmt.Sprintf("*Scheduled functions:* %d", scheduled))
+
+	b.channels.mu.Lock()
+	channels := len(b.channels.channels)
+	b.channels.mu.Unlock()
+	b.timezones.mu.Lock()
+	timezones := len(b.timezones.users)
+	b.timezones.mu.Unlock()
+	b.userGroups.mu.Lock()
+	groups := len(b.userGroups.groups)
+	b.userGroups.mu.Unlock()
+	b.emoji.mu.Lock()
+	emoji := len(b.emoji.emoji)
+	b.emoji.mu.Unlock()
+	lines = append(lines, fmt.Sprintf("*Caches:* %d channels, %d user timezones, %d user groups, %d emoji", channels, timezones, groups, emoji))
+
+	reconnect := "never"
+	if !m.LastReconnect.IsZero() {
+		reconnect = FormatDate(m.LastReconnect, DateShortPretty+" at "+DateTime)
+	}
+	lines = append(lines, fmt.Sprintf("*Reconnections:* %d, last %s", m.Reconnects, reconnect))
+	return strings.Join(lines, "\n")
+}
+
+// slowestMethod returns the Web API method with the highest average duration.
+func slowestMethod(l Latency) (string, MethodLatency) {
+	var slowest string
+	var max MethodLatency
+	for method, ml := range l.APIMethod {
+		if ml.Average > max.Average || slowest == "" {
+			slowest, max = method, ml
+		}
+	}
+	return slowest, max
+}
@@ -0,0 +1,32 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiag(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	for _, ok := range []bool{true, true, true, false} {
+		bot.metrics.recordCall("chat.postMessage", time.Millisecond, ok)
+	}
+	bot.metrics.update(func(m *Metrics) { m.RTMLatency = 120 * time.Millisecond })
+	text := bot.diagText()
+	assert.Contains(text, "*RTM latency:* 120ms")
+	assert.Contains(text, "*Web API:* 4 calls, 1 errors (25.0%)")
+	assert.Contains(text, "*Slowest API method:* `")
+	assert.Contains(text, "*Reconnections:* 0, last never")
+
+	// jobs wait for the workers, which start when the bot serves
+	bot.Jobs.Handle("report", func(ctx context.Context, job *Job) error { return nil })
+	for i := 0; i < 2; i++ {
+		_, err := bot.Jobs.Enqueue(&Job{Kind: "report"})
+		assert.NoError(err)
+	}
+	assert.Equal(2, bot.Jobs.QueueLength())
+	assert.Contains(bot.diagText(), "*Jobs:* 2 queued, 0 running")
+}
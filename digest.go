@@ -0,0 +1,97 @@
+package slackbot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/slack-go/slack"
+)
+
+// Summarizer renders the summary of the messages collected by a digest.
+type Summarizer interface {
+	Summarize(ctx context.Context, bot *Bot, msgs []*slack.MessageEvent) (string, error)
+}
+
+// SummarizerFunc adapts a function, e.g. calling an LLM, to the Summarizer interface.
+type SummarizerFunc func(ctx context.Context, bot *Bot, msgs []*slack.MessageEvent) (string, error)
+
+func (f SummarizerFunc) Summarize(ctx context.Context, bot *Bot, msgs []*slack.MessageEvent) (string, error) {
+	return f(ctx, bot, msgs)
+}
+
+// DefaultDigestTemplate lists the collected messages with their author.
+var DefaultDigestTemplate = template.Must(template.New("digest").Parse(
+	`*Digest: {{len .}} message{{if ne (len .) 1}}s{{end}}*{{range .}}
+• <@{{.User}}> in <#{{.Channel}}>: {{.Text}}{{end}}`))
+
+type templateSummarizer struct {
+	tmpl *template.Template
+}
+
+func (t templateSummarizer) Summarize(ctx context.Context, bot *Bot, msgs []*slack.MessageEvent) (string, error) {
+	var buf bytes.Buffer
+	err := t.tmpl.Execute(&buf, msgs)
+	return buf.String(), err
+}
+
+// TemplateSummarizer renders the collected messages, a []*slack.MessageEvent, with the template.
+func TemplateSummarizer(tmpl *template.Template) Summarizer {
+	return templateSummarizer{tmpl: tmpl}
+}
+
+// Digest collects messages and posts their summary to a channel on a schedule.
+type Digest struct {
+	channel    string
+	summarizer Summarizer
+
+	mu   sync.Mutex
+	msgs []*slack.MessageEvent
+}
+
+// Digest posts the summary of the collected messages to the channel on the schedule. A nil
+// summarizer uses DefaultDigestTemplate. Messages are collected by routes using the Collect
+// handler:
+//
+//	digest := bot.Digest("C0123456", slackbot.DailyAt(17, 0, time.Local), nil)
+//	bot.Hear("(?i)incident").Handler(digest.Collect)
+func (b *Bot) Digest(channel string, schedule Schedule, summarizer Summarizer) *Digest {
+	if summarizer == nil {
+		summarizer = TemplateSummarizer(DefaultDigestTemplate)
+	}
+	d := &Digest{channel: channel, summarizer: summarizer}
+	b.Schedule(schedule, d.post)
+	return d
+}
+
+// Collect is a handler adding the message in context to the digest.
+func (d *Digest) Collect(ctx context.Context) {
+	d.mu.Lock()
+	d.msgs = append(d.msgs, MessageFromContext(ctx))
+	d.mu.Unlock()
+}
+
+func (d *Digest) post(ctx context.Context, bot *Bot) {
+	d.mu.Lock()
+	msgs := d.msgs
+	d.msgs = nil
+	d.mu.Unlock()
+	if len(msgs) == 0 {
+		return
+	}
+
+	summary, err := d.summarizer.Summarize(ctx, bot, msgs)
+	if err != nil {
+		fmt.Printf("Error summarizing digest: %s\n", err)
+		return
+	}
+	if _, err := bot.Send(&OutgoingMessage{
+		Channel: d.channel,
+		Text:    summary,
+		Params:  slack.PostMessageParameters{AsUser: true},
+	}); err != nil {
+		fmt.Printf("Error posting digest: %s\n", err)
+	}
+}
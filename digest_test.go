@@ -0,0 +1,42 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigest(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	ctx := AddBotToContext(context.Background(), bot)
+
+	digest := bot.Digest("CDIGEST", DailyAt(17, 0, time.UTC), nil)
+	bot.Hear("(?i)incident").Handler(digest.Collect)
+	for _, text := range []string{"incident in prod", "lunch?", "Incident resolved"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U1", text
+		bot.handleMessage(ctx, evt)
+	}
+	digest.post(ctx, bot)
+	assert.Equal([]string{"chat.postMessage CDIGEST"}, api.calls("channel"))
+	assert.Equal([]string{"*Digest: 2 messages*\n• <@U1> in <#C1>: incident in prod\n• <@U1> in <#C1>: Incident resolved"}, api.values("chat.postMessage", "text"))
+
+	// nothing is posted without new messages
+	digest.post(ctx, bot)
+	assert.Len(api.values("chat.postMessage", ""), 1)
+
+	// the messages of a failed summary are dropped
+	failing := bot.Digest("CDIGEST", DailyAt(17, 0, time.UTC), SummarizerFunc(func(ctx context.Context, bot *Bot, msgs []*slack.MessageEvent) (string, error) {
+		return "", errors.New("quota exceeded")
+	}))
+	failing.Collect(AddMessageToContext(ctx, &slack.MessageEvent{}))
+	failing.post(ctx, bot)
+	assert.Empty(failing.msgs)
+	assert.Len(api.values("chat.postMessage", ""), 1)
+}
@@ -0,0 +1,146 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const disabledRoutesKey = "disabled_routes"
+
+// RouteDisabledText is the reply to messages matching a disabled route. It is formatted with
+// the name of the route.
+var RouteDisabledText = "Sorry, `%s` is disabled for now."
+
+// DisableRoute disables the named route in every workspace. Messages matching it receive
+// RouteDisabledText instead of being handled.
+func (b *Bot) DisableRoute(name string) error {
+	return setRouteDisabled(b.Store(), name, true)
+}
+
+// EnableRoute enables the named route again, in the workspaces where it is not disabled.
+func (b *Bot) EnableRoute(name string) error {
+	return setRouteDisabled(b.Store(), name, false)
+}
+
+// DisableRouteIn disables the named route in the workspace in context.
+func DisableRouteIn(ctx context.Context, name string) error {
+	return setRouteDisabled(TeamStore(ctx), name, true)
+}
+
+// EnableRouteIn enables the named route in the workspace in context, unless it is disabled
+// in every workspace.
+func EnableRouteIn(ctx context.Context, name string) error {
+	return setRouteDisabled(TeamStore(ctx), name, false)
+}
+
+// RouteDisabled returns true if the named route is disabled for the workspace in context.
+func RouteDisabled(ctx context.Context, name string) bool {
+	bot := BotFromContext(ctx)
+	if bot == nil {
+		return false
+	}
+	return disabledRoutes(bot.Store())[name] || disabledRoutes(TeamStore(ctx))[name]
+}
+
+func disabledRoutes(store Store) map[string]bool {
+	disabled := make(map[string]bool)
+	data, found, err := store.Get(disabledRoutesKey)
+	if err != nil {
+		fmt.Printf("Error loading disabled routes: %s\n", err)
+	} else if found {
+		_ = json.Unmarshal(data, &disabled)
+	}
+	return disabled
+}
+
+func setRouteDisabled(store Store, name string, disabled bool) error {
+	routes := disabledRoutes(store)
+	if disabled {
+		routes[name] = true
+	} else {
+		delete(routes, name)
+	}
+	data, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+	return store.Set(disabledRoutesKey, data, 0)
+}
+
+// checkDisabled replaces the handler of a match with a reply when the route is disabled.
+func (r *Route) checkDisabled(ctx context.Context, match *RouteMatch) {
+	if r.name == "" || !RouteDisabled(ctx, r.name) {
+		return
+	}
+	name := r.name
+	match.Handler = func(ctx context.Context) {
+		if msg := MessageFromContext(ctx); msg != nil {
+			BotFromContext(ctx).Reply(msg, fmt.Sprintf(RouteDisabledText, name))
+		}
+	}
+}
+
+// EnableRouteCommands adds the commands with which admins turn named routes off and on in
+// their workspace:
+//
+//	route disable <name>
+//	route enable <name>
+//	routes
+func (b *Bot) EnableRouteCommands() *Bot {
+	b.Hear(routeToggleRegexp).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		args := submatches(routeToggleRegexp, TextFromContext(ctx))
+		name, disable := args[2], strings.EqualFold(args[1], "disable")
+		if !bot.hasRoute(name) {
+			bot.Reply(evt, fmt.Sprintf("No route named `%s`, see `routes`.", name))
+			return
+		}
+		err := EnableRouteIn(ctx, name)
+		if disable {
+			err = DisableRouteIn(ctx, name)
+		}
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not update `%s`: %s", name, err))
+			return
+		}
+		state := "enabled"
+		if RouteDisabled(ctx, name) {
+			state = "disabled"
+		}
+		bot.Reply(evt, fmt.Sprintf("`%s` is %s.", name, state))
+	})
+	b.Hear(`(?i)^routes$`).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		var lines []string
+		bot.walkRoutes(func(r *Route) {
+			if r.name == "" {
+				return
+			}
+			state := "enabled"
+			if RouteDisabled(ctx, r.name) {
+				state = "disabled"
+			}
+			lines = append(lines, fmt.Sprintf("`%s` %s", r.name, state))
+		})
+		if len(lines) == 0 {
+			bot.Reply(evt, "No named routes.")
+			return
+		}
+		sort.Strings(lines)
+		bot.Reply(evt, strings.Join(lines, "\n"))
+	})
+	return b
+}
+
+const routeToggleRegexp = `(?i)^route (enable|disable) (\S+)$`
+
+func (b *Bot) hasRoute(name string) bool {
+	found := false
+	b.walkRoutes(func(r *Route) {
+		found = found || r.name == name
+	})
+	return found
+}
@@ -0,0 +1,45 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisableRoute(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	handled := false
+	bot.Hear("deploy").Name("deploy").Handler(func(ctx context.Context) { handled = true })
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Text: "deploy"}}
+	ctx := AddMessageToContext(AddTeamToContext(AddBotToContext(context.Background(), bot), "T1"), evt)
+	ctx = AddTextToContext(ctx, "deploy")
+
+	run := func(ctx context.Context) {
+		var match RouteMatch
+		matched, ctx := bot.Match(ctx, &match)
+		assert.True(matched)
+		match.Handler(ctx)
+	}
+	var replies []string
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		replies = append(replies, msg.Text)
+		return false
+	})
+
+	assert.NoError(DisableRouteIn(ctx, "deploy"))
+	run(ctx)
+	assert.False(handled)
+	assert.Equal([]string{"Sorry, `deploy` is disabled for now."}, replies)
+	assert.False(RouteDisabled(AddTeamToContext(ctx, "T2"), "deploy"))
+
+	assert.NoError(bot.DisableRoute("deploy"))
+	assert.NoError(EnableRouteIn(ctx, "deploy"))
+	assert.True(RouteDisabled(ctx, "deploy"))
+
+	assert.NoError(bot.EnableRoute("deploy"))
+	run(ctx)
+	assert.True(handled)
+}
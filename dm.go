@@ -0,0 +1,131 @@
+package slackbot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const maxDMAttempts = 3
+
+// DMInterval is the delay between two messages sent by DMMany.
+var DMInterval = time.Second
+
+// DMResult reports the outcome of a direct message sent to a user.
+type DMResult struct {
+	UserID    string
+	Channel   string
+	Timestamp string
+	Err       error
+}
+
+// DM opens a direct message conversation with the user and sends the message. Rate limited
+// calls are retried after the delay requested by Slack.
+func (b *Bot) DM(userID, msg string) DMResult {
+	result := DMResult{UserID: userID}
+	result.Err = withRetry(func() error {
+		channel, _, _, err := b.Client.OpenConversation(&slack.OpenConversationParameters{Users: []string{userID}})
+		if err != nil {
+			return err
+		}
+		result.Channel = channel.ID
+		return nil
+	})
+	if result.Err != nil {
+		return result
+	}
+	result.Err = withRetry(func() (err error) {
+		result.Timestamp, err = b.Send(&OutgoingMessage{
+			Channel: result.Channel,
+			Text:    msg,
+			Params:  slack.PostMessageParameters{AsUser: true},
+		})
+		return err
+	})
+	return result
+}
+
+// DMMany sends the message to each user in turn, waiting DMInterval between two users, and
+// returns the result for each of them. The optional progress is updated along the way.
+func (b *Bot) DMMany(userIDs []string, msg string, progress *Progress) []DMResult {
+	results := make([]DMResult, len(userIDs))
+	for i, id := range userIDs {
+		if i > 0 {
+			time.Sleep(DMInterval)
+		}
+		results[i] = b.DM(id, msg)
+		if progress != nil {
+			progress.Set(i + 1)
+		}
+	}
+	if progress != nil {
+		progress.Done(nil)
+	}
+	return results
+}
+
+// DMReport summarizes results, listing the users that could not be reached.
+func DMReport(results []DMResult) string {
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("• <@%s>: %s", r.UserID, r.Err))
+		}
+	}
+	report := fmt.Sprintf("Sent %d of %d messages.", len(results)-len(failed), len(results))
+	if len(failed) > 0 {
+		report += " Failed:\n" + strings.Join(failed, "\n")
+	}
+	return report
+}
+
+// withRetry calls fn until it succeeds, fails with an error other than a rate limit, or
+// maxDMAttempts is reached.
+func withRetry(fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxDMAttempts; attempt++ {
+		err = WrapError(fn())
+		var rateLimited *ErrRateLimited
+		if !errors.As(err, &rateLimited) {
+			return err
+		}
+		time.Sleep(rateLimited.RetryAfter)
+	}
+	return err
+}
+
+// emailCacheTTL is how long the user ID resolved from an email address is kept.
+const emailCacheTTL = 24 * time.Hour
+
+// UserIDByEmail resolves the ID of the user with the given email address. Results are
+// cached in the bot Store.
+func (b *Bot) UserIDByEmail(email string) (string, error) {
+	key := "email:" + strings.ToLower(email)
+	if id, found, err := b.Store().Get(key); err == nil && found {
+		return string(id), nil
+	}
+	var user *slack.User
+	err := withRetry(func() (err error) {
+		user, err = b.Client.GetUserByEmail(email)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := b.Store().Set(key, []byte(user.ID), emailCacheTTL); err != nil {
+		fmt.Printf("Error caching user of %s: %s\n", email, err)
+	}
+	return user.ID, nil
+}
+
+// DMByEmail sends a direct message to the user with the given email address.
+func (b *Bot) DMByEmail(email, msg string) DMResult {
+	id, err := b.UserIDByEmail(email)
+	if err != nil {
+		return DMResult{Err: err}
+	}
+	return b.DM(id, msg)
+}
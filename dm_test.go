@@ -0,0 +1,55 @@
+package slackbot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDMMany(t *testing.T) {
+	assert := assert.New(t)
+	interval := DMInterval
+	DMInterval = 0
+	defer func() { DMInterval = interval }()
+
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.open", `{"ok": true, "channel": {"id": "D1"}}`)
+
+	results := bot.DMMany([]string{"U1", "U2"}, "Please update your profile.", nil)
+	assert.Equal([]DMResult{
+		{UserID: "U1", Channel: "D1", Timestamp: "1.0"},
+		{UserID: "U2", Channel: "D1", Timestamp: "1.0"},
+	}, results)
+	assert.Equal([]string{"U1", "U2"}, api.values("conversations.open", "users"))
+	assert.Equal("Sent 2 of 2 messages.", DMReport(results))
+
+	api.respond("conversations.open", `{"ok": false, "error": "user_not_found"}`)
+	result := bot.DM("U3", "Hi")
+	assert.Error(result.Err)
+	assert.Len(api.values("chat.postMessage", "text"), 2)
+}
+
+func TestDMReport(t *testing.T) {
+	report := DMReport([]DMResult{{UserID: "U1"}, {UserID: "U2", Err: errors.New("user_not_found")}})
+	assert.Equal(t, "Sent 1 of 2 messages. Failed:\n• <@U2>: user_not_found", report)
+}
+
+func TestDMByEmail(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("users.lookupByEmail", `{"ok": true, "user": {"id": "U9"}}`)
+	api.respond("conversations.open", `{"ok": true, "channel": {"id": "D9"}}`)
+
+	assert.Equal(DMResult{UserID: "U9", Channel: "D9", Timestamp: "1.0"}, bot.DMByEmail("Ana@example.com", "Welcome!"))
+	id, err := bot.UserIDByEmail("ana@example.com")
+	assert.NoError(err)
+	assert.Equal("U9", id)
+	// the user is looked up once
+	assert.Equal([]string{"Ana@example.com"}, api.values("users.lookupByEmail", "email"))
+
+	api.respond("users.lookupByEmail", `{"ok": false, "error": "users_not_found"}`)
+	assert.Error(bot.DMByEmail("bob@example.com", "Welcome!").Err)
+}
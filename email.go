@@ -0,0 +1,153 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Mail is an email sent by the bot.
+type Mail struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails, e.g. through SMTP or the API of an email service.
+type Mailer interface {
+	SendMail(ctx context.Context, mail Mail) error
+}
+
+// SMTPMailer is a Mailer sending emails through an SMTP server.
+type SMTPMailer struct {
+	// host:port of the server
+	Addr string
+	From string
+	// Authentication, e.g. smtp.PlainAuth, none when nil
+	Auth smtp.Auth
+}
+
+func (m *SMTPMailer) SendMail(ctx context.Context, mail Mail) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(mail.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mail.Subject)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	return smtp.SendMail(m.Addr, m.Auth, m.From, mail.To, []byte(msg.String()))
+}
+
+// EmailForward configures the forwarding of messages to email.
+type EmailForward struct {
+	Mailer Mailer
+	To     []string
+	// Messages forwarded, e.g. NewRegexpMatcher("(?i)urgent"), all the messages the bot
+	// sees when nil
+	Matcher Matcher
+	// Only forward the messages nobody else, including the bot, answered in their
+	// conversation or thread within this delay; forward immediately when 0
+	UnansweredAfter time.Duration
+}
+
+// ForwardToEmail forwards the messages matching the configuration to email, so requests
+// can be triaged outside Slack.
+func (b *Bot) ForwardToEmail(cfg EmailForward) *Bot {
+	f := &emailForward{cfg: cfg, pending: make(map[string]*pendingForward)}
+	if cfg.UnansweredAfter > 0 {
+		b.AfterSend(func(msg *OutgoingMessage, ts string, err error) {
+			if err == nil {
+				f.answered(msg.Channel, msg.Params.ThreadTimestamp, b.botUserID)
+			}
+		})
+	}
+	return b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.SubType != "" {
+			return
+		}
+		f.answered(msg.Channel, msg.ThreadTimestamp, msg.User)
+		if cfg.Matcher != nil {
+			if matched, _ := cfg.Matcher.Match(ctx); !matched {
+				return
+			}
+		}
+		if cfg.UnansweredAfter == 0 {
+			bot.forwardToEmail(ctx, cfg, msg)
+			return
+		}
+		f.wait(msg, cfg.UnansweredAfter, func() { bot.forwardToEmail(ctx, cfg, msg) })
+	})
+}
+
+type emailForward struct {
+	cfg     EmailForward
+	mu      sync.Mutex
+	pending map[string]*pendingForward
+}
+
+// pendingForward is a message forwarded unless answered, keyed by channel and ts.
+type pendingForward struct {
+	timer    *time.Timer
+	channel  string
+	threadTS string
+	user     string
+}
+
+// wait runs forward unless the message is answered within the delay.
+func (f *emailForward) wait(msg *slack.MessageEvent, delay time.Duration, forward func()) {
+	key := messageKey(msg.Channel, msg.Timestamp)
+	p := &pendingForward{channel: msg.Channel, threadTS: msg.ThreadTimestamp, user: msg.User}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if prev, ok := f.pending[key]; ok {
+		prev.timer.Stop()
+	}
+	p.timer = time.AfterFunc(delay, func() {
+		f.mu.Lock()
+		current := f.pending[key] == p
+		if current {
+			delete(f.pending, key)
+		}
+		f.mu.Unlock()
+		if current {
+			forward()
+		}
+	})
+	f.pending[key] = p
+}
+
+// answered cancels the forwarding of the messages a message of the user answers: those of
+// other users in the same conversation, and the parent message for thread replies.
+func (f *emailForward) answered(channel, threadTS, user string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for key, p := range f.pending {
+		if p.user == user || p.channel != channel {
+			continue
+		}
+		if p.threadTS == threadTS || threadTS != "" && key == messageKey(channel, threadTS) {
+			p.timer.Stop()
+			delete(f.pending, key)
+		}
+	}
+}
+
+func (b *Bot) forwardToEmail(ctx context.Context, cfg EmailForward, msg *slack.MessageEvent) {
+	body := msg.Text
+	if link, err := b.Permalink(msg.Channel, msg.Timestamp); err == nil {
+		body += "\n\n" + link
+	}
+	mail := Mail{
+		To:      cfg.To,
+		Subject: fmt.Sprintf("Slack message from %s", b.userName(msg.User, msg.Username, map[string]string{})),
+		Body:    body,
+	}
+	if err := cfg.Mailer.SendMail(ctx, mail); err != nil {
+		fmt.Printf("Error forwarding message to email: %s\n", err)
+	}
+}
@@ -0,0 +1,49 @@
+package slackbot
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailForwardUnanswered(t *testing.T) {
+	assert := assert.New(t)
+	f := &emailForward{pending: make(map[string]*pendingForward)}
+	var mu sync.Mutex
+	var forwarded []string
+	wait := func(channel, threadTS, ts, user string) {
+		msg := &slack.MessageEvent{Msg: slack.Msg{Channel: channel, ThreadTimestamp: threadTS, Timestamp: ts, User: user}}
+		f.wait(msg, 20*time.Millisecond, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			forwarded = append(forwarded, channel+" "+ts)
+		})
+	}
+
+	wait("D1", "", "1.0", "U1")
+	f.answered("D1", "", "UBOT")
+
+	// each message is forwarded, follow-ups of the same user answer nothing
+	wait("D2", "", "2.0", "U2")
+	wait("D2", "", "2.1", "U2")
+	f.answered("D2", "", "U2")
+
+	// thread replies answer their parent and the earlier replies of others
+	wait("C1", "", "3.0", "U1")
+	wait("C1", "3.0", "3.1", "U1")
+	f.answered("C1", "3.0", "U2")
+	wait("C1", "", "4.0", "U1")
+	wait("C1", "4.0", "4.1", "U1")
+	f.answered("C1", "4.0", "U1")
+
+	time.Sleep(60 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(forwarded)
+	assert.Equal([]string{"C1 4.0", "C1 4.1", "D2 2.0", "D2 2.1"}, forwarded)
+	assert.Empty(f.pending)
+}
@@ -0,0 +1,65 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// emojiCacheTTL is how long the emoji catalog is cached, unless an emoji_changed event
+// invalidates it first.
+const emojiCacheTTL = time.Hour
+
+type emojiCache struct {
+	mu      sync.Mutex
+	emoji   map[string]string
+	expires time.Time
+}
+
+// ListEmoji returns the custom emoji of the workspace mapped to their URL, or to
+// "alias:<name>" for aliases. The catalog is cached.
+func (b *Bot) ListEmoji() (map[string]string, error) {
+	b.emoji.mu.Lock()
+	defer b.emoji.mu.Unlock()
+	if b.emoji.emoji != nil && time.Now().Before(b.emoji.expires) {
+		return b.emoji.emoji, nil
+	}
+	emoji, err := b.Client.GetEmoji()
+	if err != nil {
+		return nil, err
+	}
+	b.emoji.emoji = emoji
+	b.emoji.expires = time.Now().Add(emojiCacheTTL)
+	return emoji, nil
+}
+
+// EmojiExists returns true if the workspace has a custom emoji with that name. The name may
+// be wrapped in colons.
+func (b *Bot) EmojiExists(name string) bool {
+	emoji, err := b.ListEmoji()
+	if err != nil {
+		return false
+	}
+	_, ok := emoji[strings.Trim(name, ":")]
+	return ok
+}
+
+// OnEmojiChanged registers a handler called when custom emoji are added, removed or renamed.
+func (b *Bot) OnEmojiChanged(fn func(ctx context.Context, bot *Bot, evt *slack.EmojiChangedEvent)) *Bot {
+	b.RequireScopes("emoji:read")
+	return b.OnEvent("emoji_changed", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if e, ok := evt.(*slack.EmojiChangedEvent); ok {
+			fn(ctx, bot, e)
+		}
+	})
+}
+
+// invalidateEmoji drops the cached emoji catalog.
+func (b *Bot) invalidateEmoji() {
+	b.emoji.mu.Lock()
+	b.emoji.emoji = nil
+	b.emoji.mu.Unlock()
+}
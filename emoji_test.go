@@ -0,0 +1,41 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListEmoji(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("emoji.list", `{"ok": true, "emoji": {"shipit": "https://emoji/shipit.png", "squirrel": "alias:shipit"}}`)
+
+	assert.True(bot.EmojiExists(":shipit:"))
+	assert.True(bot.EmojiExists("squirrel"))
+	assert.False(bot.EmojiExists("parrot"))
+	assert.Len(api.calls(""), 1)
+
+	bot.invalidateEmoji()
+	emoji, err := bot.ListEmoji()
+	assert.NoError(err)
+	assert.Equal("alias:shipit", emoji["squirrel"])
+	assert.Len(api.calls(""), 2)
+}
+
+func TestOnEmojiChanged(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var changed []string
+	bot.OnEmojiChanged(func(ctx context.Context, bot *Bot, evt *slack.EmojiChangedEvent) {
+		changed = append(changed, evt.SubType+" "+evt.Name)
+	})
+	ctx := AddBotToContext(context.Background(), bot)
+	bot.handleEvent(ctx, "emoji_changed", &slack.EmojiChangedEvent{SubType: "add", Name: "parrot"})
+	bot.handleEvent(ctx, "emoji_changed", "not an event")
+	assert.Equal([]string{"add parrot"}, changed)
+	assert.True(bot.requiredScopes["emoji:read"])
+}
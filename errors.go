@@ -0,0 +1,73 @@
+package slackbot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Failures of Slack API calls, to be tested with errors.Is on the errors returned by the bot
+// or by WrapError.
+var (
+	ErrChannelNotFound = errors.New("slackbot: channel not found")
+	ErrNotInChannel    = errors.New("slackbot: not in channel")
+	ErrMissingScope    = errors.New("slackbot: missing scope")
+)
+
+// ErrRateLimited is returned when Slack rate limits a call, to be retried after a delay.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("slackbot: rate limited, retry after %s", e.RetryAfter)
+}
+
+// apiError keeps the original error while matching the failure it stands for.
+type apiError struct {
+	kind error
+	err  error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// apiErrorKinds maps the error codes of the Slack API to the failures they stand for.
+var apiErrorKinds = map[string]error{
+	"channel_not_found": ErrChannelNotFound,
+	"not_in_channel":    ErrNotInChannel,
+	"missing_scope":     ErrMissingScope,
+}
+
+// WrapError converts an error returned by the slack package so it can be tested with
+// errors.Is against ErrChannelNotFound, ErrNotInChannel and ErrMissingScope, or with
+// errors.As against *ErrRateLimited. Other errors are returned unchanged.
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var wrapped *apiError
+	var limited *ErrRateLimited
+	if errors.As(err, &wrapped) || errors.As(err, &limited) {
+		return err
+	}
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return &ErrRateLimited{RetryAfter: rateLimited.RetryAfter}
+	}
+	if kind, ok := apiErrorKinds[err.Error()]; ok {
+		return &apiError{kind: kind, err: err}
+	}
+	return err
+}
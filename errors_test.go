@@ -0,0 +1,29 @@
+package slackbot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(WrapError(nil))
+
+	err := WrapError(errors.New("not_in_channel"))
+	assert.True(errors.Is(err, ErrNotInChannel))
+	assert.False(errors.Is(err, ErrChannelNotFound))
+	assert.Equal("not_in_channel", err.Error())
+	assert.Equal(err, WrapError(err))
+
+	var limited *ErrRateLimited
+	assert.True(errors.As(WrapError(&slack.RateLimitedError{RetryAfter: time.Second}), &limited))
+	assert.Equal(time.Second, limited.RetryAfter)
+
+	other := errors.New("boom")
+	assert.Equal(other, WrapError(other))
+}
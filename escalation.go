@@ -0,0 +1,170 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultEscalationIntent matches the messages of users asking for a human.
+const DefaultEscalationIntent = `(?i)\b(talk to a (human|person)|human please|real person)\b`
+
+// escalationTTL is how long an escalation lasts unless closed.
+const escalationTTL = 24 * time.Hour
+
+// EscalationConfig configures the handoff of direct message conversations to humans.
+type EscalationConfig struct {
+	// Channel where escalated conversations are posted, as threads
+	SupportChannel string
+	// ID of the user group pinged on escalation, e.g. the on-call group
+	OnCallGroup string
+	// Escalate after this many consecutive messages matching no route, never if 0
+	AfterFailures int
+	// Messages asking for a human, DefaultEscalationIntent if empty
+	Intent string
+	// Messages sent in the support thread to hand the conversation back to the bot,
+	// "resolved" if empty
+	CloseCommand string
+}
+
+type escalation struct {
+	cfg      EscalationConfig
+	close    *regexp.Regexp
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// Escalation hands direct message conversations over to humans when users ask for it or
+// after repeated messages the bot does not understand. The conversation is posted as a thread
+// of the support channel, pinging the on-call group, and messages are then relayed between the
+// direct message and the thread, without routing, until a human closes the thread.
+func (b *Bot) Escalation(cfg EscalationConfig) *Bot {
+	if cfg.Intent == "" {
+		cfg.Intent = DefaultEscalationIntent
+	}
+	if cfg.CloseCommand == "" {
+		cfg.CloseCommand = "resolved"
+	}
+	b.escalation = &escalation{
+		cfg:      cfg,
+		close:    regexp.MustCompile(`(?i)^\s*` + regexp.QuoteMeta(cfg.CloseCommand) + `\s*$`),
+		failures: make(map[string]int),
+	}
+	b.Hear(cfg.Intent).Messages(DirectMessage).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.Escalate(evt)
+	})
+	return b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if msg, ok := evt.(*slack.MessageEvent); ok {
+			bot.relayEscalated(msg)
+		}
+	})
+}
+
+func escalationUserKey(channel string) string {
+	return "escalation:dm:" + channel
+}
+
+func escalationThreadKey(ts string) string {
+	return "escalation:thread:" + ts
+}
+
+// Escalate hands the direct message conversation of the message over to humans.
+func (b *Bot) Escalate(evt *slack.MessageEvent) error {
+	e := b.escalation
+	if e == nil {
+		return fmt.Errorf("slackbot: escalation not configured")
+	}
+	if b.isEscalated(evt.Channel) {
+		return nil
+	}
+	text := fmt.Sprintf("<@%s> needs help:\n%s", evt.User, quoteText(evt.Text))
+	if e.cfg.OnCallGroup != "" {
+		text = fmt.Sprintf("<!subteam^%s> %s", e.cfg.OnCallGroup, text)
+	}
+	ts, err := b.Send(&OutgoingMessage{Channel: e.cfg.SupportChannel, Text: text})
+	if err != nil {
+		return err
+	}
+	store := b.Store()
+	if err := store.Set(escalationUserKey(evt.Channel), []byte(ts), escalationTTL); err != nil {
+		return err
+	}
+	if err := store.Set(escalationThreadKey(ts), []byte(evt.Channel), escalationTTL); err != nil {
+		return err
+	}
+	b.Reply(evt, "I've asked a human to help you, they will answer here.")
+	return nil
+}
+
+// isEscalated returns true if the direct message conversation is handled by humans.
+func (b *Bot) isEscalated(channel string) bool {
+	if b.escalation == nil {
+		return false
+	}
+	_, found, _ := b.Store().Get(escalationUserKey(channel))
+	return found
+}
+
+// relayEscalated relays the messages of escalated conversations between the direct message
+// and the support thread.
+func (b *Bot) relayEscalated(msg *slack.MessageEvent) {
+	e := b.escalation
+	if msg.SubType != "" || msg.BotID != "" {
+		return
+	}
+	store := b.Store()
+	if data, found, err := store.Get(escalationUserKey(msg.Channel)); err == nil && found {
+		_, _ = b.Send(&OutgoingMessage{
+			Channel: e.cfg.SupportChannel,
+			Text:    fmt.Sprintf("<@%s>: %s", msg.User, msg.Text),
+			Params:  slack.PostMessageParameters{ThreadTimestamp: string(data)},
+		})
+		return
+	}
+	if msg.Channel != e.cfg.SupportChannel || msg.ThreadTimestamp == "" {
+		return
+	}
+	data, found, err := store.Get(escalationThreadKey(msg.ThreadTimestamp))
+	if err != nil || !found {
+		return
+	}
+	dm := string(data)
+	if e.close.MatchString(msg.Text) {
+		_ = store.Delete(escalationThreadKey(msg.ThreadTimestamp))
+		_ = store.Delete(escalationUserKey(dm))
+		_, _ = b.Send(&OutgoingMessage{Channel: dm, Text: "The conversation is closed, I'm back to help you."})
+		return
+	}
+	_, _ = b.Send(&OutgoingMessage{Channel: dm, Text: fmt.Sprintf("<@%s>: %s", msg.User, msg.Text)})
+}
+
+// countFailure escalates direct message conversations after too many messages matching no
+// route, and resets the count otherwise.
+func (b *Bot) countFailure(evt *slack.MessageEvent, failed bool) {
+	e := b.escalation
+	if e == nil || e.cfg.AfterFailures == 0 || !strings.HasPrefix(evt.Channel, "D") {
+		return
+	}
+	e.mu.Lock()
+	if !failed {
+		delete(e.failures, evt.Channel)
+		e.mu.Unlock()
+		return
+	}
+	e.failures[evt.Channel]++
+	escalate := e.failures[evt.Channel] >= e.cfg.AfterFailures
+	if escalate {
+		delete(e.failures, evt.Channel)
+	}
+	e.mu.Unlock()
+	if escalate {
+		if err := b.Escalate(evt); err != nil {
+			fmt.Printf("Error escalating: %s\n", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscalation(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.postMessage", `{"ok": true, "channel": "CSUPPORT", "ts": "5.0"}`)
+	bot.Escalation(EscalationConfig{SupportChannel: "CSUPPORT", OnCallGroup: "S1"})
+	var routed []string
+	bot.Hear(".").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		routed = append(routed, evt.Text)
+	})
+	ctx := AddBotToContext(context.Background(), bot)
+	handle := func(channel, thread, user, text string) {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.ThreadTimestamp, evt.User, evt.Text = channel, thread, user, text
+		bot.handleMessage(ctx, evt)
+	}
+
+	handle("D1", "", "U1", "can I talk to a human please")
+	assert.Equal([]string{
+		"chat.postMessage <!subteam^S1> <@U1> needs help:\n> can I talk to a human please",
+		"chat.postMessage I've asked a human to help you, they will answer here.",
+	}, api.calls("text"))
+	assert.Equal([]string{"CSUPPORT", "D1"}, api.values("chat.postMessage", "channel"))
+
+	// messages are relayed both ways instead of being routed
+	handle("D1", "", "U1", "my deploy is stuck")
+	handle("CSUPPORT", "5.0", "U2", "looking into it")
+	handle("CSUPPORT", "5.0", "U2", "resolved")
+	assert.Equal([]string{"CSUPPORT", "D1", "CSUPPORT", "D1", "D1"}, api.values("chat.postMessage", "channel"))
+	texts := api.values("chat.postMessage", "text")
+	assert.Equal([]string{"<@U1>: my deploy is stuck", "<@U2>: looking into it", "The conversation is closed, I'm back to help you."}, texts[2:])
+	assert.Equal("5.0", api.values("chat.postMessage", "thread_ts")[2])
+
+	// once closed, the bot answers again
+	handle("D1", "", "U1", "thanks")
+	assert.Contains(routed, "thanks")
+	assert.NotContains(routed, "my deploy is stuck")
+	assert.Len(api.values("chat.postMessage", "text"), 5)
+}
+
+func TestEscalationAfterFailures(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	bot.Escalation(EscalationConfig{SupportChannel: "CSUPPORT", AfterFailures: 2})
+	bot.Hear("^help$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {})
+	ctx := AddBotToContext(context.Background(), bot)
+
+	for _, text := range []string{"huh", "help", "what", "nope"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "D1", "U1", text
+		bot.handleMessage(ctx, evt)
+	}
+	// the count restarts after a message is understood
+	assert.Equal([]string{"CSUPPORT", "D1"}, api.values("chat.postMessage", "channel"))
+	assert.Equal("<@U1> needs help:\n> nope", api.values("chat.postMessage", "text")[0])
+	assert.Error(New("").Escalate(&slack.MessageEvent{}))
+}
@@ -0,0 +1,40 @@
+package slackbot
+
+import (
+	"context"
+)
+
+// EventHandler handles an RTM event. evt is the event value decoded by the slack package,
+// e.g. *slack.ReactionAddedEvent.
+type EventHandler func(ctx context.Context, bot *Bot, evt interface{})
+
+// OnEvent registers a handler for RTM events of the given type, e.g. "reaction_added".
+// Handlers run in registration order. Handlers of "message" events observe every message
+// not sent by the bot, before it is routed.
+func (b *Bot) OnEvent(eventType string, handler EventHandler) *Bot {
+	b.eventsMu.Lock()
+	defer b.eventsMu.Unlock()
+	if b.events == nil {
+		b.events = make(map[string][]EventHandler)
+	}
+	b.events[eventType] = append(b.events[eventType], handler)
+	return b
+}
+
+// handleEvent runs the handlers registered for the event type, once the commands a
+// CancelReaction targets are cancelled and the votes of reactions are counted.
+func (b *Bot) handleEvent(ctx context.Context, eventType string, evt interface{}) {
+	if eventType == "reaction_added" {
+		b.cancelOnReaction(evt)
+	}
+	if eventType == "reaction_added" || eventType == "reaction_removed" {
+		b.countVote(evt)
+	}
+	b.eventsMu.Lock()
+	handlers := b.events[eventType]
+	b.eventsMu.Unlock()
+	for _, handler := range handlers {
+		handler := handler
+		b.dispatch(ctx, func(ctx context.Context) { handler(ctx, b, evt) })
+	}
+}
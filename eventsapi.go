@@ -0,0 +1,164 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// eventsEnvelope is the outer payload of the Events API requests.
+type eventsEnvelope struct {
+	Type         string          `json:"type"`
+	Challenge    string          `json:"challenge"`
+	TeamID       string          `json:"team_id"`
+	EnterpriseID string          `json:"enterprise_id"`
+	Event        json.RawMessage `json:"event"`
+}
+
+// EventsHandler returns the HTTP handler to configure as the Event Subscriptions Request URL
+// of the Slack app, an alternative to RTM. Messages and app mentions are routed like RTM
+// messages, other events are handed to the handlers registered with OnEvent.
+func (b *Bot) EventsHandler(signingSecret string) http.Handler {
+	return VerifySignature(signingSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var envelope eventsEnvelope
+		if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if envelope.Type == "url_verification" {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(envelope.Challenge))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		// Slack retries deliveries it considers failed, they were already handled
+		if envelope.Type != "event_callback" || r.Header.Get("X-Slack-Retry-Num") != "" {
+			return
+		}
+		b.goRecover(func() { b.handleCallbackEvent(envelope.TeamID, envelope.EnterpriseID, envelope.Event) })
+	}))
+}
+
+// goRecover runs fn in its own goroutine once a request is answered, reporting its panics
+// with HandleError as net/http would have recovered them within the request.
+func (b *Bot) goRecover(fn func()) {
+	go func() {
+		defer recoverHandler(AddBotToContext(context.Background(), b))
+		fn()
+	}()
+}
+
+func (b *Bot) handleCallbackEvent(teamID, enterpriseID string, data json.RawMessage) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		fmt.Printf("Error decoding event: %s\n", err)
+		return
+	}
+	ctx := AddTeamToContext(AddBotToContext(context.Background(), b), teamID)
+	if enterpriseID != "" {
+		ctx = AddEnterpriseToContext(ctx, enterpriseID)
+	}
+
+	switch header.Type {
+	case "message", "app_mention":
+		var ev slack.MessageEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			fmt.Printf("Error decoding message: %s\n", err)
+			return
+		}
+		// mentions of the bot are delivered as both events, routed once
+		if !b.delivered.first(ev.Channel, ev.Timestamp, time.Now()) {
+			return
+		}
+		b.handleMessage(ctx, &ev)
+	case "message_metadata_posted":
+		var ev MetadataEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			fmt.Printf("Error decoding metadata: %s\n", err)
+			return
+		}
+		b.handleEvent(ctx, header.Type, &ev)
+	case "app_home_opened":
+		var ev AppHomeOpenedEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			fmt.Printf("Error decoding app home event: %s\n", err)
+			return
+		}
+		b.handleEvent(ctx, header.Type, &ev)
+	default:
+		proto, ok := slack.EventMapping[header.Type]
+		if !ok {
+			return
+		}
+		ev := reflect.New(reflect.TypeOf(proto)).Interface()
+		if err := json.Unmarshal(data, ev); err != nil {
+			fmt.Printf("Error decoding %s event: %s\n", header.Type, err)
+			return
+		}
+		if header.Type == "emoji_changed" {
+			b.invalidateEmoji()
+		}
+		b.handleEvent(ctx, header.Type, ev)
+	}
+}
+
+// deliveryTTL is how long messages received through the Events API are remembered.
+const deliveryTTL = time.Minute
+
+// deliveries remembers the messages received recently, as a message mentioning the bot is
+// delivered both as a message and an app_mention event.
+type deliveries struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	pruned time.Time
+}
+
+// first returns true unless the message was already received within deliveryTTL.
+func (d *deliveries) first(channel, ts string, now time.Time) bool {
+	if ts == "" {
+		return true
+	}
+	key := channel + ":" + ts
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if received, ok := d.seen[key]; ok && now.Sub(received) < deliveryTTL {
+		return false
+	}
+	if d.seen == nil {
+		d.seen = make(map[string]time.Time)
+	}
+	if now.Sub(d.pruned) >= deliveryTTL {
+		for k, received := range d.seen {
+			if now.Sub(received) >= deliveryTTL {
+				delete(d.seen, k)
+			}
+		}
+		d.pruned = now
+	}
+	d.seen[key] = now
+	return true
+}
+
+// identify resolves the identity of the bot when it does not connect through RTM.
+func (b *Bot) identify() {
+	if b.botUserID != "" {
+		return
+	}
+	resp, err := b.Client.AuthTest()
+	if err != nil {
+		fmt.Printf("Error getting bot info: %s\n", err)
+		return
+	}
+	b.botUserID = resp.UserID
+	b.botUserName = resp.User
+	b.botTeamID = resp.TeamID
+}
@@ -0,0 +1,69 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsHandlerChallenge(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	handler := bot.EventsHandler("secret")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest("secret", "/events", `{"type": "url_verification", "challenge": "3eZbrw1a"}`))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("3eZbrw1a", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest("secret", "/events", `not json`))
+	assert.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func TestEventsRouting(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var heard []string
+	bot.Hear("^deploy$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, TeamFromContext(ctx)+" "+EnterpriseFromContext(ctx)+" "+evt.Channel)
+	})
+	var reactions []string
+	bot.OnEvent("reaction_added", func(ctx context.Context, bot *Bot, evt interface{}) {
+		reactions = append(reactions, evt.(*slack.ReactionAddedEvent).Reaction)
+	})
+
+	message := json.RawMessage(`{"type": "message", "channel": "C1", "user": "U1", "text": "deploy", "ts": "1.5"}`)
+	mention := json.RawMessage(`{"type": "app_mention", "channel": "C1", "user": "U1", "text": "deploy", "ts": "1.5"}`)
+	other := json.RawMessage(`{"type": "message", "channel": "C1", "user": "U1", "text": "deploy", "ts": "1.6"}`)
+	bot.handleCallbackEvent("T1", "E1", message)
+	// a mention arrives as both a message and an app_mention, and is routed once
+	bot.handleCallbackEvent("T1", "", mention)
+	bot.handleCallbackEvent("T1", "", other)
+	bot.handleCallbackEvent("T1", "", json.RawMessage(`{"type": "reaction_added", "user": "U1", "reaction": "eyes"}`))
+	bot.handleCallbackEvent("T1", "", json.RawMessage(`{"type": "unknown_event"}`))
+
+	assert.Equal([]string{"T1 E1 C1", "T1  C1"}, heard)
+	assert.Equal([]string{"eyes"}, reactions)
+}
+
+func TestDeliveries(t *testing.T) {
+	assert := assert.New(t)
+	var d deliveries
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	assert.True(d.first("C1", "1.5", now))
+	assert.False(d.first("C1", "1.5", now.Add(time.Second)))
+	assert.True(d.first("C2", "1.5", now))
+	assert.True(d.first("C1", "", now))
+	assert.True(d.first("C1", "", now))
+
+	// deliveries are forgotten after a while
+	assert.True(d.first("C1", "1.5", now.Add(2*deliveryTTL)))
+	assert.Len(d.seen, 1)
+}
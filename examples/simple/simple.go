@@ -20,11 +20,11 @@ func main() {
 }
 
 func HelloHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
-	bot.Reply(evt, "Oh hello!", slackbot.WithTyping)
+	bot.Reply(evt, "Oh hello!", slackbot.Typing())
 }
 
 func HowAreYouHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
-	bot.Reply(evt, "A bit tired. You get it? A bit?", slackbot.WithTyping)
+	bot.Reply(evt, "A bit tired. You get it? A bit?", slackbot.Typing())
 }
 
 func AttachmentsHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
@@ -42,5 +42,5 @@ func AttachmentsHandler(ctx context.Context, bot *slackbot.Bot, evt *slack.Messa
 	// supports multiple attachments
 	attachments := []slack.Attachment{attachment}
 
-	bot.ReplyWithAttachments(evt, attachments, slackbot.WithTyping)
+	bot.ReplyWithAttachments(evt, attachments, slackbot.Typing())
 }
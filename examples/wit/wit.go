@@ -21,15 +21,15 @@ func main() {
 }
 
 func HelloHandler(ctx context.Context, bot *slackbot.Bot, msg *slack.MessageEvent) {
-	bot.Reply(msg, "Oh hello!", slackbot.WithTyping)
+	bot.Reply(msg, "Oh hello!", slackbot.Typing())
 }
 
 func HowAreYouHandler(ctx context.Context, bot *slackbot.Bot, msg *slack.MessageEvent) {
-	bot.Reply(msg, "A bit tired. You get it? A bit?", slackbot.WithTyping)
+	bot.Reply(msg, "A bit tired. You get it? A bit?", slackbot.Typing())
 }
 
 func ConfusedHandler(ctx context.Context, bot *slackbot.Bot, msg *slack.MessageEvent) {
-	bot.Reply(msg, "I don't understand 😰", slackbot.WithTyping)
+	bot.Reply(msg, "I don't understand 😰", slackbot.Typing())
 }
 
 func WitPreprocess(ctx context.Context) context.Context {
@@ -39,7 +39,7 @@ func WitPreprocess(ctx context.Context) context.Context {
 	witMessage, err := wit.NewClient(os.Getenv("WIT_TOKEN")).Message(req)
 	if err != nil {
 		bot := slackbot.BotFromContext(ctx)
-		bot.Reply(msg, "Uh oh, I seem to be out of sorts :dizzy_face", slackbot.WithTyping)
+		bot.Reply(msg, "Uh oh, I seem to be out of sorts :dizzy_face", slackbot.Typing())
 		return ctx
 	}
 	fmt.Printf("WIT: %#v\n", witMessage)
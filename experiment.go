@@ -0,0 +1,83 @@
+package slackbot
+
+import (
+	"context"
+	"hash/fnv"
+)
+
+// AnalyticsSink receives the events recorded by the bot, e.g. experiment exposures.
+type AnalyticsSink interface {
+	Track(ctx context.Context, event string, props map[string]string)
+}
+
+// AnalyticsFunc adapts a function to the AnalyticsSink interface.
+type AnalyticsFunc func(ctx context.Context, event string, props map[string]string)
+
+func (f AnalyticsFunc) Track(ctx context.Context, event string, props map[string]string) {
+	f(ctx, event, props)
+}
+
+// SetAnalyticsSink sets where analytics events are sent. Without one, they are dropped.
+func (b *Bot) SetAnalyticsSink(sink AnalyticsSink) *Bot {
+	b.analytics = sink
+	return b
+}
+
+// Track sends an event to the analytics sink of the bot in context.
+func Track(ctx context.Context, event string, props map[string]string) {
+	if bot := BotFromContext(ctx); bot != nil && bot.analytics != nil {
+		bot.analytics.Track(ctx, event, props)
+	}
+}
+
+// ExposureEvent is the analytics event recorded when a user is shown a variant.
+const ExposureEvent = "experiment_exposure"
+
+// Experiment assigns users to variants of a response. A user always gets the same variant
+// of an experiment, while different experiments bucket users independently.
+type Experiment struct {
+	Name     string
+	Variants []string
+}
+
+// NewExperiment returns an experiment between the variants, e.g. "control" and "short".
+func NewExperiment(name string, variants ...string) *Experiment {
+	return &Experiment{Name: name, Variants: variants}
+}
+
+// Variant returns the variant for the author of the message in context and records the
+// exposure.
+func (e *Experiment) Variant(ctx context.Context) string {
+	if len(e.Variants) == 0 {
+		return ""
+	}
+	user := ""
+	if msg := MessageFromContext(ctx); msg != nil {
+		user = msg.User
+	}
+	variant := e.Variants[e.bucket(TeamFromContext(ctx)+":"+user)]
+	Track(ctx, ExposureEvent, map[string]string{
+		"experiment": e.Name,
+		"variant":    variant,
+		"user":       user,
+	})
+	return variant
+}
+
+// Pick returns the response of the variant for the author of the message in context,
+// responses being given in the order of the variants.
+func (e *Experiment) Pick(ctx context.Context, responses ...string) string {
+	variant := e.Variant(ctx)
+	for i, v := range e.Variants {
+		if v == variant && i < len(responses) {
+			return responses[i]
+		}
+	}
+	return ""
+}
+
+func (e *Experiment) bucket(id string) int {
+	h := fnv.New32a()
+	h.Write([]byte(e.Name + ":" + id))
+	return int(h.Sum32() % uint32(len(e.Variants)))
+}
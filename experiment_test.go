@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperiment(t *testing.T) {
+	assert := assert.New(t)
+	var exposures []map[string]string
+	bot := New("").SetAnalyticsSink(AnalyticsFunc(func(ctx context.Context, event string, props map[string]string) {
+		assert.Equal(ExposureEvent, event)
+		exposures = append(exposures, props)
+	}))
+	ctx := AddBotToContext(context.Background(), bot)
+	forUser := func(user string) context.Context {
+		return AddMessageToContext(ctx, &slack.MessageEvent{Msg: slack.Msg{User: user}})
+	}
+
+	e := NewExperiment("greeting", "control", "short")
+	first := e.Variant(forUser("U1"))
+	assert.Equal(first, e.Variant(forUser("U1")))
+	assert.Len(exposures, 2)
+	assert.Equal(map[string]string{"experiment": "greeting", "variant": first, "user": "U1"}, exposures[0])
+
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		seen[e.Pick(forUser(fmt.Sprintf("U%d", i)), "Hello there!", "Hi")] = true
+	}
+	assert.Equal(map[string]bool{"Hello there!": true, "Hi": true}, seen)
+}
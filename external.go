@@ -0,0 +1,71 @@
+package slackbot
+
+import (
+	"context"
+	"time"
+)
+
+// userTeamCacheTTL is how long the team of a user is kept in the Store.
+const userTeamCacheTTL = 24 * time.Hour
+
+// BotTeamID returns the ID of the workspace the bot is connected to.
+func (b *Bot) BotTeamID() string {
+	return b.botTeamID
+}
+
+// IsSharedChannel returns true if the conversation is shared with other organizations
+// through Slack Connect.
+func (b *Bot) IsSharedChannel(channelID string) bool {
+	channel, err := b.ChannelInfo(channelID)
+	return err == nil && channel.IsExtShared
+}
+
+// IsExternalUser returns true if the user belongs to another workspace than the bot. Users
+// that cannot be resolved are considered external.
+func (b *Bot) IsExternalUser(userID string) bool {
+	if userID == "" || b.botTeamID == "" {
+		return false
+	}
+	key := "user_team:" + userID
+	team, found, err := b.Store().Get(key)
+	if err != nil || !found {
+		user, err := b.Client.GetUserInfo(userID)
+		if err != nil {
+			return true
+		}
+		team = []byte(user.TeamID)
+		_ = b.Store().Set(key, team, userTeamCacheTTL)
+	}
+	return string(team) != b.botTeamID
+}
+
+// IsExternal returns true if the message in context was posted by an external user.
+func IsExternal(ctx context.Context) bool {
+	bot := BotFromContext(ctx)
+	msg := MessageFromContext(ctx)
+	return bot != nil && msg != nil && bot.IsExternalUser(msg.User)
+}
+
+// IsSharedChannel returns true if the message in context was posted in a shared channel.
+func IsSharedChannel(ctx context.Context) bool {
+	bot := BotFromContext(ctx)
+	msg := MessageFromContext(ctx)
+	return bot != nil && msg != nil && bot.IsSharedChannel(msg.Channel)
+}
+
+// InternalOnly makes the route ignore messages from external users.
+func (r *Route) InternalOnly() *Route {
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			if !IsExternal(ctx) {
+				next(ctx)
+			}
+		}
+	})
+}
+
+// InternalOnly makes the bot ignore messages from external users altogether.
+func (b *Bot) InternalOnly() *Bot {
+	b.internalOnly = true
+	return b
+}
@@ -0,0 +1,63 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExternalUser(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botTeamID = "T1"
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U2", "team_id": "T2"}}`)
+
+	assert.True(bot.IsExternalUser("U2"))
+	assert.True(bot.IsExternalUser("U2"))
+	// the team of the user is cached
+	assert.Equal([]string{"U2"}, api.values("users.info", "user"))
+	assert.False(bot.IsExternalUser(""))
+
+	api.respond("users.info", `{"ok": true, "user": {"id": "U1", "team_id": "T1"}}`)
+	assert.False(bot.IsExternalUser("U1"))
+	// users who cannot be resolved are external
+	api.respond("users.info", `{"ok": false, "error": "user_not_found"}`)
+	assert.True(bot.IsExternalUser("U3"))
+}
+
+func TestInternalOnly(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botTeamID = "T1"
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U2", "team_id": "T2"}}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	var shared []bool
+	bot.Hear("^deploy$").InternalOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, evt.User)
+	})
+	bot.Hear("^where$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		heard = append(heard, evt.User)
+		shared = append(shared, IsSharedChannel(ctx))
+	})
+	api.respond("conversations.info", `{"ok": true, "channel": {"id": "C1", "is_ext_shared": true}}`)
+
+	for _, text := range []string{"deploy", "where"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U2", text
+		bot.handleMessage(ctx, evt)
+	}
+	assert.Equal([]string{"U2"}, heard)
+	assert.Equal([]bool{true}, shared)
+
+	// the bot may ignore external users altogether
+	bot.InternalOnly()
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U2", "where"
+	bot.handleMessage(ctx, evt)
+	assert.Len(heard, 1)
+}
@@ -0,0 +1,75 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Responder answers a message addressed to the bot, typically with an LLM.
+type Responder interface {
+	Respond(ctx context.Context, text string) (string, error)
+}
+
+// ResponderFunc adapts a function to a Responder.
+type ResponderFunc func(ctx context.Context, text string) (string, error)
+
+func (f ResponderFunc) Respond(ctx context.Context, text string) (string, error) {
+	return f(ctx, text)
+}
+
+// Fallback sets the responder answering the messages addressed to the bot that match no
+// route and no suggested command.
+func (b *Bot) Fallback(r Responder) *Bot {
+	b.fallback = r
+	return b
+}
+
+func (b *Bot) respond(ctx context.Context) {
+	evt := MessageFromContext(ctx)
+	answer, err := b.fallback.Respond(ctx, TextFromContext(ctx))
+	if err != nil {
+		b.HandleError(ctx, err)
+		return
+	}
+	if answer != "" {
+		b.Reply(evt, answer)
+	}
+}
+
+// Generator answers a question from the documents retrieved for it, typically by prompting
+// an LLM with them.
+type Generator func(ctx context.Context, question string, docs []Document) (string, error)
+
+// RetrievalResponder returns a Responder querying the retriever for documents relevant to the
+// message before calling generate with them. Without generator, the documents are listed.
+func RetrievalResponder(retriever Retriever, generate Generator) Responder {
+	return ResponderFunc(func(ctx context.Context, text string) (string, error) {
+		docs, err := retriever.Query(ctx, text)
+		if err != nil {
+			return "", err
+		}
+		if generate != nil {
+			return generate(ctx, text, docs)
+		}
+		return listDocuments(docs), nil
+	})
+}
+
+// NoDocumentsText is the answer of a RetrievalResponder without generator finding nothing.
+var NoDocumentsText = "I could not find anything about that."
+
+func listDocuments(docs []Document) string {
+	if len(docs) == 0 {
+		return NoDocumentsText
+	}
+	lines := []string{"These might help:"}
+	for _, d := range docs {
+		title := d.Title
+		if d.URL != "" {
+			title = fmt.Sprintf("<%s|%s>", d.URL, d.Title)
+		}
+		lines = append(lines, "• "+title)
+	}
+	return strings.Join(lines, "\n")
+}
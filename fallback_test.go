@@ -0,0 +1,69 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticRetriever []Document
+
+func (r staticRetriever) Query(ctx context.Context, text string) ([]Document, error) {
+	return r, nil
+}
+
+func TestFallback(t *testing.T) {
+	assert := assert.New(t)
+	var reports []*ErrorReport
+	bot := New("").SetErrorReporter(ErrorReporterFunc(func(ctx context.Context, report *ErrorReport) {
+		reports = append(reports, report)
+	}))
+	api := newSlackAPI(t, bot)
+	var asked []string
+	bot.Fallback(ResponderFunc(func(ctx context.Context, text string) (string, error) {
+		asked = append(asked, text)
+		switch text {
+		case "fail":
+			return "", errors.New("model unavailable")
+		case "shrug":
+			return "", nil
+		}
+		return "An answer", nil
+	}))
+	bot.Hear("^ping$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.Reply(evt, "pong")
+	})
+
+	for _, text := range []string{"ping", "what is the weather?", "shrug", "fail"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "D1", "U1", text
+		bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	}
+	assert.Equal([]string{"what is the weather?", "shrug", "fail"}, asked)
+	assert.Equal([]string{"pong", "An answer"}, api.values("chat.postMessage", "text"))
+	if assert.Len(reports, 1) {
+		assert.EqualError(reports[0].Err, "model unavailable")
+	}
+}
+
+func TestRetrievalResponder(t *testing.T) {
+	assert := assert.New(t)
+	docs := staticRetriever{{Title: "VPN", URL: "https://wiki/vpn"}, {Title: "Wifi"}}
+	answer, err := RetrievalResponder(docs, nil).Respond(context.Background(), "vpn")
+	assert.NoError(err)
+	assert.Equal("These might help:\n• <https://wiki/vpn|VPN>\n• Wifi", answer)
+
+	answer, err = RetrievalResponder(staticRetriever{}, nil).Respond(context.Background(), "vpn")
+	assert.NoError(err)
+	assert.Equal(NoDocumentsText, answer)
+
+	// the generator answers from the documents
+	answer, err = RetrievalResponder(docs, func(ctx context.Context, question string, docs []Document) (string, error) {
+		return question + " is in " + docs[0].Title, nil
+	}).Respond(context.Background(), "vpn")
+	assert.NoError(err)
+	assert.Equal("vpn is in VPN", answer)
+}
@@ -0,0 +1,78 @@
+package slackbot
+
+import (
+	"context"
+)
+
+// FlagTarget identifies who a feature flag is evaluated for.
+type FlagTarget struct {
+	Team    string
+	Channel string
+	User    string
+}
+
+// FlagProvider decides whether feature flags are enabled, so features can be rolled out per
+// workspace, channel or user without redeploying the bot. The integrations/launchdarkly
+// package provides one for LaunchDarkly, and adapting another feature flag service takes a
+// few lines:
+//
+//	slackbot.FlagFunc(func(ctx context.Context, flag string, t slackbot.FlagTarget) bool {
+//		return client.IsEnabled(flag, t.User)
+//	})
+type FlagProvider interface {
+	Enabled(ctx context.Context, flag string, target FlagTarget) bool
+}
+
+// FlagFunc adapts a function to the FlagProvider interface.
+type FlagFunc func(ctx context.Context, flag string, target FlagTarget) bool
+
+func (f FlagFunc) Enabled(ctx context.Context, flag string, target FlagTarget) bool {
+	return f(ctx, flag, target)
+}
+
+// ConstFlags is a FlagProvider enabling flags for everyone, or no one.
+type ConstFlags map[string]bool
+
+func (f ConstFlags) Enabled(ctx context.Context, flag string, target FlagTarget) bool {
+	return f[flag]
+}
+
+// SetFlagProvider sets the provider of feature flags. Without one, all flags are disabled.
+func (b *Bot) SetFlagProvider(p FlagProvider) *Bot {
+	b.flags = p
+	return b
+}
+
+// FlagEnabled returns true if the flag is enabled for the message in context.
+func FlagEnabled(ctx context.Context, flag string) bool {
+	bot := BotFromContext(ctx)
+	if bot == nil || bot.flags == nil {
+		return false
+	}
+	target := FlagTarget{Team: TeamFromContext(ctx)}
+	if msg := MessageFromContext(ctx); msg != nil {
+		target.Channel, target.User = msg.Channel, msg.User
+	}
+	return bot.flags.Enabled(ctx, flag, target)
+}
+
+// Flag restricts the route to messages for which the feature flag is enabled. Messages
+// are matched against the following routes otherwise.
+func (r *Route) Flag(flag string) *Route {
+	return r.AddMatcher(&FlagMatcher{flag: flag})
+}
+
+// ============================================================================
+// Flag Matcher
+// ============================================================================
+
+type FlagMatcher struct {
+	flag string
+}
+
+func (fm *FlagMatcher) Match(ctx context.Context) (bool, context.Context) {
+	return FlagEnabled(ctx, fm.flag), ctx
+}
+
+// SetBotID does nothing, flags do not depend on the bot user.
+func (fm *FlagMatcher) SetBotID(botID string) {}
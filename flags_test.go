@@ -0,0 +1,28 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlags(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+	ctx = AddMessageToContext(ctx, &slack.MessageEvent{Msg: slack.Msg{User: "U1", Channel: "C1"}})
+
+	assert.False(FlagEnabled(ctx, "new-deploy-flow"))
+
+	bot.SetFlagProvider(ConstFlags{"new-deploy-flow": true})
+	assert.True(FlagEnabled(ctx, "new-deploy-flow"))
+	assert.False(FlagEnabled(ctx, "other"))
+
+	bot.SetFlagProvider(FlagFunc(func(ctx context.Context, flag string, target FlagTarget) bool {
+		return target.Channel == "C1"
+	}))
+	ok, _ := (&FlagMatcher{flag: "new-deploy-flow"}).Match(ctx)
+	assert.True(ok)
+}
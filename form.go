@@ -0,0 +1,115 @@
+package slackbot
+
+import (
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// FormField declares a text input of a form.
+type FormField struct {
+	Name        string
+	Label       string
+	Placeholder string
+	Multiline   bool
+	Optional    bool
+	// Validate checks the submitted value, its error is displayed under the field
+	Validate func(value string) error
+}
+
+// FormSpec declares a modal form.
+type FormSpec struct {
+	Title  string
+	Submit string
+	Fields []FormField
+}
+
+// FormAnswers holds the submitted values of a form by field name.
+type FormAnswers map[string]string
+
+// viewHandler handles the submission of a view, returning the response to send to Slack.
+type viewHandler func(callback *slack.InteractionCallback) *slack.ViewSubmissionResponse
+
+// AskForm opens a modal built from the spec. Once submitted and valid, the answers are sent
+// on the returned channel, which is closed if the user dismisses the modal. The trigger ID
+// comes from a slash command or an interaction, and submissions are received through the
+// InteractionHandler.
+func (b *Bot) AskForm(triggerID string, spec FormSpec) (<-chan FormAnswers, error) {
+	callbackID := newActionID("form")
+	view := slack.ModalViewRequest{
+		Type:          slack.VTModal,
+		Title:         slack.NewTextBlockObject(slack.PlainTextType, spec.Title, false, false),
+		Submit:        slack.NewTextBlockObject(slack.PlainTextType, orDefault(spec.Submit, "Submit"), false, false),
+		Close:         slack.NewTextBlockObject(slack.PlainTextType, "Cancel", false, false),
+		CallbackID:    callbackID,
+		NotifyOnClose: true,
+	}
+	for _, f := range spec.Fields {
+		input := slack.NewPlainTextInputBlockElement(slack.NewTextBlockObject(slack.PlainTextType, f.Placeholder, false, false), f.Name)
+		if f.Placeholder == "" {
+			input.Placeholder = nil
+		}
+		input.Multiline = f.Multiline
+		block := slack.NewInputBlock(f.Name, slack.NewTextBlockObject(slack.PlainTextType, orDefault(f.Label, f.Name), false, false), input)
+		block.Optional = f.Optional
+		view.Blocks.BlockSet = append(view.Blocks.BlockSet, block)
+	}
+
+	answers := make(chan FormAnswers, 1)
+	b.onView(callbackID, func(callback *slack.InteractionCallback) *slack.ViewSubmissionResponse {
+		if callback.Type == slack.InteractionTypeViewClosed {
+			b.removeView(callbackID)
+			close(answers)
+			return nil
+		}
+
+		values := FormAnswers{}
+		errs := map[string]string{}
+		for _, f := range spec.Fields {
+			value := strings.TrimSpace(callback.View.State.Values[f.Name][f.Name].Value)
+			if f.Validate != nil && (value != "" || !f.Optional) {
+				if err := f.Validate(value); err != nil {
+					errs[f.Name] = err.Error()
+					continue
+				}
+			}
+			values[f.Name] = value
+		}
+		if len(errs) > 0 {
+			return slack.NewErrorsViewSubmissionResponse(errs)
+		}
+
+		b.removeView(callbackID)
+		answers <- values
+		close(answers)
+		return nil
+	})
+
+	if _, err := b.Client.OpenView(triggerID, view); err != nil {
+		b.removeView(callbackID)
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (b *Bot) onView(callbackID string, handler viewHandler) {
+	b.interactionsMu.Lock()
+	defer b.interactionsMu.Unlock()
+	if b.views == nil {
+		b.views = make(map[string]viewHandler)
+	}
+	b.views[callbackID] = handler
+}
+
+func (b *Bot) removeView(callbackID string) {
+	b.interactionsMu.Lock()
+	delete(b.views, callbackID)
+	b.interactionsMu.Unlock()
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// submitView posts a view interaction to the bot and returns the response body.
+func submitView(bot *Bot, kind, callbackID string, values map[string]string) string {
+	state := map[string]map[string]map[string]string{}
+	for name, value := range values {
+		state[name] = map[string]map[string]string{name: {"value": value}}
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"type": kind,
+		"view": map[string]interface{}{"callback_id": callbackID, "state": map[string]interface{}{"values": state}},
+	})
+	rec := httptest.NewRecorder()
+	body := url.Values{"payload": {string(payload)}}.Encode()
+	bot.InteractionHandler("secret").ServeHTTP(rec, signedRequest("secret", "/interactivity", body))
+	return rec.Body.String()
+}
+
+func TestAskForm(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	answers, err := bot.AskForm("trigger", FormSpec{Title: "Incident", Fields: []FormField{
+		{Name: "title", Validate: func(value string) error {
+			if value == "" {
+				return errors.New("A title is required")
+			}
+			return nil
+		}},
+		{Name: "details", Multiline: true, Optional: true},
+	}})
+	assert.NoError(err)
+	assert.Equal([]string{"trigger"}, api.values("views.open", "trigger_id"))
+	var callbackID string
+	for id := range bot.views {
+		callbackID = id
+	}
+
+	response := submitView(bot, "view_submission", callbackID, map[string]string{"title": " "})
+	assert.True(strings.Contains(response, "A title is required"))
+	assert.Equal("", submitView(bot, "view_submission", callbackID, map[string]string{"title": " Database down "}))
+	assert.Equal(FormAnswers{"title": "Database down", "details": ""}, <-answers)
+	assert.Empty(bot.views)
+}
+
+func TestAskFormClosed(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	newSlackAPI(t, bot)
+	answers, err := bot.AskForm("trigger", FormSpec{Title: "Incident", Fields: []FormField{{Name: "title"}}})
+	assert.NoError(err)
+	for id := range bot.views {
+		submitView(bot, "view_closed", id, nil)
+	}
+	_, open := <-answers
+	assert.False(open)
+}
+
+func TestAskFormFailure(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("views.open", `{"ok": false, "error": "expired_trigger_id"}`)
+	_, err := bot.AskForm("trigger", FormSpec{Title: "Incident"})
+	assert.Error(err)
+	assert.Empty(bot.views)
+}
@@ -1,11 +1,18 @@
 module github.com/lazappa/go-slackbot
 
-go 1.14
+go 1.18
 
 require (
 	github.com/chris-skud/go-wit v0.0.0-20160116012338-c5c44784af9f
-	github.com/pkg/errors v0.9.1 // indirect
 	github.com/slack-go/slack v0.6.5
 	github.com/stretchr/testify v1.2.2
+	gopkg.in/yaml.v3 v3.0.1
 	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,133 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// GreetingText is the default intro sent to users opening a direct message with the bot.
+var GreetingText = "Hi! Send me `help` to see what I can do."
+
+// QuickStart is a button of the greeting, running a command on behalf of the user.
+type QuickStart struct {
+	Label string
+	// Command run as if the user sent it, e.g. "help"
+	Command string
+}
+
+// Greeting is the intro sent once to each user opening a direct message with the bot.
+type Greeting struct {
+	// Text of the intro, GreetingText when empty
+	Text    string
+	Buttons []QuickStart
+}
+
+// AppHomeOpenedEvent is an app_home_opened event, sent through the Events API when a user
+// opens a tab of the app home, its Messages tab included.
+type AppHomeOpenedEvent struct {
+	Type    string `json:"type"`
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+	Tab     string `json:"tab"`
+	EventTS string `json:"event_ts"`
+}
+
+const greetingAction = "slackbot_greeting"
+
+// GreetOnFirstDM sends the greeting to users the first time they open a direct message with
+// the bot, on im_created RTM events or app_home_opened events of the Messages tab. Each user
+// is greeted once, as recorded in the Store. Quick-start buttons are received through the
+// InteractionHandler:
+//
+//	bot.GreetOnFirstDM(slackbot.Greeting{
+//		Text:    "Hi, I deploy our services.",
+//		Buttons: []slackbot.QuickStart{{Label: "Show commands", Command: "help"}},
+//	})
+func (b *Bot) GreetOnFirstDM(g Greeting) *Bot {
+	if g.Text == "" {
+		g.Text = GreetingText
+	}
+	first := b.greeting == nil
+	b.greeting = &g
+	for i, button := range g.Buttons {
+		command := button.Command
+		b.OnAction(greetingAction+"_"+strconv.Itoa(i), func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+			bot.runQuickStart(ctx, callback, command)
+		})
+	}
+	if !first {
+		return b
+	}
+
+	b.RequireScopes("im:read", "im:write", "chat:write")
+	b.OnEvent("im_created", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if e, ok := evt.(*slack.IMCreatedEvent); ok {
+			bot.greet(e.User, e.Channel.ID)
+		}
+	})
+	b.OnEvent("app_home_opened", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if e, ok := evt.(*AppHomeOpenedEvent); ok && e.Tab == "messages" {
+			bot.greet(e.User, e.Channel)
+		}
+	})
+	return b
+}
+
+// greet sends the greeting to the user in the direct message channel, unless already greeted.
+func (b *Bot) greet(user, channel string) {
+	if user == "" || user == b.botUserID || b.greeting == nil {
+		return
+	}
+	key := greetedKey(user)
+	if _, found, err := b.Store().Get(key); err != nil || found {
+		return
+	}
+	// marked first, as both events may arrive for the same conversation
+	if err := b.Store().Set(key, []byte("1"), 0); err != nil {
+		fmt.Printf("Error greeting %s: %s\n", user, err)
+		return
+	}
+	if !strings.HasPrefix(channel, "D") {
+		// posting to the user opens the direct message
+		channel = user
+	}
+	if _, err := b.Send(&OutgoingMessage{Channel: channel, Blocks: b.greetingBlocks(), Text: b.greeting.Text,
+		Params: slack.PostMessageParameters{AsUser: true}}); err != nil {
+		fmt.Printf("Error greeting %s: %s\n", user, err)
+		_ = b.Store().Delete(key)
+	}
+}
+
+func (b *Bot) greetingBlocks() []slack.Block {
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, b.greeting.Text, false, false), nil, nil),
+	}
+	if len(b.greeting.Buttons) == 0 {
+		return blocks
+	}
+	var buttons []slack.BlockElement
+	for i, q := range b.greeting.Buttons {
+		buttons = append(buttons, slack.NewButtonBlockElement(greetingAction+"_"+strconv.Itoa(i), q.Command,
+			slack.NewTextBlockObject(slack.PlainTextType, q.Label, false, false)))
+	}
+	return append(blocks, slack.NewActionBlock("", buttons...))
+}
+
+// runQuickStart routes the command as a direct message of the user who clicked.
+func (b *Bot) runQuickStart(ctx context.Context, callback *slack.InteractionCallback, command string) {
+	evt := &slack.MessageEvent{}
+	evt.Type = "message"
+	evt.Channel = callback.Channel.ID
+	evt.User = callback.User.ID
+	evt.Text = command
+	evt.Timestamp = callback.ActionTs
+	b.handleMessage(ctx, evt)
+}
+
+func greetedKey(user string) string {
+	return "greeted:" + user
+}
@@ -0,0 +1,48 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreetOnFirstDM(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	bot.GreetOnFirstDM(Greeting{Buttons: []QuickStart{{Label: "Show commands", Command: "help"}}})
+	ctx := AddBotToContext(context.Background(), bot)
+
+	created := &slack.IMCreatedEvent{User: "U1"}
+	created.Channel.ID = "D1"
+	bot.handleEvent(ctx, "im_created", created)
+	bot.handleEvent(ctx, "app_home_opened", &AppHomeOpenedEvent{User: "U1", Channel: "D1", Tab: "messages"})
+	bot.handleEvent(ctx, "app_home_opened", &AppHomeOpenedEvent{User: "U2", Channel: "D2", Tab: "home"})
+	bot.handleEvent(ctx, "app_home_opened", &AppHomeOpenedEvent{User: "U3", Tab: "messages"})
+
+	assert.Equal([]string{"D1", "U3"}, api.values("chat.postMessage", "channel"))
+
+	blocks := bot.greetingBlocks()
+	if assert.Len(blocks, 2) {
+		assert.Equal(GreetingText, blocks[0].(*slack.SectionBlock).Text.Text)
+	}
+}
+
+func TestQuickStart(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.GreetOnFirstDM(Greeting{Text: "Hi", Buttons: []QuickStart{{Label: "Status", Command: "status"}}})
+	ran := make(chan string, 1)
+	bot.Hear("^status$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		ran <- evt.User
+	})
+
+	callback := &slack.InteractionCallback{Type: slack.InteractionTypeBlockActions}
+	callback.User.ID = "U1"
+	callback.Channel.ID = "D1"
+	callback.ActionCallback.BlockActions = []*slack.BlockAction{{ActionID: greetingAction + "_0", Value: "status"}}
+	bot.handleInteraction(callback)
+	assert.Equal("U1", <-ran)
+}
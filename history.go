@@ -0,0 +1,131 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultMemoryTurns is the number of turns remembered by default.
+const DefaultMemoryTurns = 20
+
+// Turn is a message of a conversation with the bot.
+type Turn struct {
+	// User who sent the message, empty for the bot
+	User string `json:"user,omitempty"`
+	Text string `json:"text"`
+	TS   string `json:"ts,omitempty"`
+}
+
+// Conversation is the recent history of a conversation with the bot: its last turns, and a
+// summary of the turns before them when a summarizer is set.
+type Conversation struct {
+	Summary string `json:"summary,omitempty"`
+	Turns   []Turn `json:"turns"`
+}
+
+// TurnSummarizer folds turns leaving the memory window into the summary of the conversation,
+// typically with an LLM.
+type TurnSummarizer func(ctx context.Context, summary string, turns []Turn) (string, error)
+
+// MemoryOptions configures the conversation memory.
+type MemoryOptions struct {
+	// Number of turns kept, DefaultMemoryTurns when zero
+	Turns int
+	// Summarizes the turns beyond the window when set, otherwise they are dropped
+	Summarize TurnSummarizer
+	// Conversations idle longer are forgotten, never when zero
+	TTL time.Duration
+}
+
+// Memory makes the bot remember the messages addressed to it and its replies, per thread, or
+// per user and channel outside threads. Conversations are kept in the bot Store, scoped to
+// the workspace, and read by handlers with History.
+func (b *Bot) Memory(opts MemoryOptions) *Bot {
+	if opts.Turns == 0 {
+		opts.Turns = DefaultMemoryTurns
+	}
+	b.memory = &opts
+	return b
+}
+
+// History returns the conversation the message in context belongs to, including the
+// message itself. It is empty when the bot memory is not enabled.
+func History(ctx context.Context) *Conversation {
+	bot := BotFromContext(ctx)
+	evt := MessageFromContext(ctx)
+	if bot == nil || evt == nil || bot.memory == nil {
+		return &Conversation{}
+	}
+	bot.memoryMu.Lock()
+	defer bot.memoryMu.Unlock()
+	return bot.loadConversation(evt)
+}
+
+// historyKey returns the key of the conversation of a message: its thread, or its
+// author outside threads.
+func historyKey(evt *slack.MessageEvent) string {
+	if evt.ThreadTimestamp != "" {
+		return fmt.Sprintf("history:%s:%s", evt.Channel, evt.ThreadTimestamp)
+	}
+	return fmt.Sprintf("history:%s:user:%s", evt.Channel, evt.User)
+}
+
+func (b *Bot) loadConversation(evt *slack.MessageEvent) *Conversation {
+	c := &Conversation{}
+	data, found, err := b.StoreFor(evt.Team).Get(historyKey(evt))
+	if err != nil {
+		fmt.Printf("Error loading conversation: %s\n", err)
+	} else if found {
+		_ = json.Unmarshal(data, c)
+	}
+	return c
+}
+
+// remember appends a turn to the conversation of the message, summarizing or dropping the
+// oldest turns beyond the window.
+func (b *Bot) remember(ctx context.Context, evt *slack.MessageEvent, turn Turn) {
+	if b.memory == nil {
+		return
+	}
+	b.memoryMu.Lock()
+	defer b.memoryMu.Unlock()
+
+	c := b.loadConversation(evt)
+	c.Turns = append(c.Turns, turn)
+	if overflow := len(c.Turns) - b.memory.Turns; overflow > 0 {
+		if b.memory.Summarize != nil {
+			summary, err := b.memory.Summarize(ctx, c.Summary, c.Turns[:overflow])
+			if err != nil {
+				fmt.Printf("Error summarizing conversation: %s\n", err)
+			} else {
+				c.Summary = summary
+			}
+		}
+		c.Turns = append([]Turn(nil), c.Turns[overflow:]...)
+	}
+	data, err := json.Marshal(c)
+	if err == nil {
+		err = b.StoreFor(evt.Team).Set(historyKey(evt), data, b.memory.TTL)
+	}
+	if err != nil {
+		fmt.Printf("Error saving conversation: %s\n", err)
+	}
+}
+
+// recordReply records a text reply to the message event, in the conversation memory and for
+// the requests coalesced with it or cached.
+func (b *Bot) recordReply(out *OutgoingMessage, evt *slack.MessageEvent) {
+	if out.EphemeralUser != "" {
+		return
+	}
+	if b.memory != nil {
+		b.remember(context.Background(), evt, Turn{Text: out.Text})
+	}
+	if rec, ok := b.recorders.Load(evt); ok {
+		rec.(*replyRecorder).add(out.Text)
+	}
+}
@@ -0,0 +1,36 @@
+package slackbot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Memory(MemoryOptions{Turns: 2, Summarize: func(ctx context.Context, summary string, turns []Turn) (string, error) {
+		for _, t := range turns {
+			summary = strings.TrimSpace(summary + " " + t.Text)
+		}
+		return summary, nil
+	}})
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "D1", User: "U1", Timestamp: "1.0"}}
+	ctx := AddMessageToContext(AddBotToContext(context.Background(), bot), evt)
+	assert.Empty(History(ctx).Turns)
+
+	bot.remember(ctx, evt, Turn{User: "U1", Text: "hi"})
+	bot.recordReply(&OutgoingMessage{Text: "hello"}, evt)
+	bot.remember(ctx, evt, Turn{User: "U1", Text: "deploy"})
+	bot.recordReply(&OutgoingMessage{Text: "done"}, evt)
+
+	history := History(ctx)
+	assert.Equal("hi hello", history.Summary)
+	assert.Equal([]Turn{{User: "U1", Text: "deploy"}, {Text: "done"}}, history.Turns)
+
+	// threads are separate conversations
+	thread := &slack.MessageEvent{Msg: slack.Msg{Channel: "D1", User: "U1", ThreadTimestamp: "1.0"}}
+	assert.Empty(History(AddMessageToContext(ctx, thread)).Turns)
+}
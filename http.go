@@ -0,0 +1,162 @@
+package slackbot
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// HTTPConfig configures the HTTP endpoints of the bot, such as interactivity.
+type HTTPConfig struct {
+	// Address to listen on, e.g. ":8080"
+	Addr string
+	// Signing secret of the Slack app, used to authenticate requests
+	SigningSecret string
+	// Path prefix of the endpoints, e.g. "/slack" when a reverse proxy forwards that path
+	BasePath string
+	// URL the endpoints are reachable at from Slack, including the base path
+	PublicURL string
+	// Certificate and key to serve HTTPS, plain HTTP is served when empty
+	CertFile string
+	KeyFile  string
+	// CA certificates authenticating clients, e.g. the reverse proxy, when set
+	ClientCAFile string
+	// Bearer token of the admin API, which is disabled when empty
+	AdminToken string
+}
+
+// SetHTTPConfig configures the HTTP endpoints of the bot.
+func (b *Bot) SetHTTPConfig(cfg HTTPConfig) *Bot {
+	cfg.BasePath = "/" + strings.Trim(cfg.BasePath, "/")
+	b.httpConfig = cfg
+	return b
+}
+
+// path returns the path of an endpoint under the base path.
+func (cfg HTTPConfig) path(endpoint string) string {
+	return strings.TrimSuffix(cfg.BasePath, "/") + endpoint
+}
+
+// Mount adds an HTTP handler served by HTTPHandler under the base path.
+func (b *Bot) Mount(endpoint string, handler http.Handler) *Bot {
+	if b.mounts == nil {
+		b.mounts = make(map[string]http.Handler)
+	}
+	b.mounts[endpoint] = handler
+	return b
+}
+
+// HTTPHandler returns a handler serving all the HTTP endpoints of the bot under the base
+// path, to embed the bot in an existing HTTP server:
+//
+//	<base path>/events		Event Subscriptions Request URL
+//	<base path>/commands		Slash commands Request URL
+//	<base path>/interactivity	Interactivity Request URL
+//	<base path>/healthz		Health check
+//	<base path>/admin/maintenance	Maintenance mode, with the admin token
+//
+// along with the endpoints added with Mount.
+func (b *Bot) HTTPHandler() http.Handler {
+	b.identify()
+	cfg := b.httpConfig
+	mux := http.NewServeMux()
+	mux.Handle(cfg.path("/events"), b.EventsHandler(cfg.SigningSecret))
+	mux.Handle(cfg.path("/commands"), b.SlashCommandsHandler(cfg.SigningSecret))
+	mux.Handle(cfg.path("/interactivity"), b.InteractionHandler(cfg.SigningSecret))
+	mux.HandleFunc(cfg.path("/healthz"), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	if cfg.AdminToken != "" {
+		mux.Handle(cfg.path("/admin/maintenance"), adminAuth(cfg.AdminToken, http.HandlerFunc(b.maintenanceAPI)))
+	}
+	for endpoint, handler := range b.mounts {
+		mux.Handle(cfg.path(endpoint), handler)
+	}
+	return mux
+}
+
+// adminAuth restricts the handler to requests bearing the admin token.
+func adminAuth(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ListenAndServe serves HTTPHandler, with TLS and client certificate authentication when
+// configured.
+func (b *Bot) ListenAndServe() error {
+	server, err := b.httpServer()
+	if err != nil {
+		return err
+	}
+	return listen(server)
+}
+
+// serveHTTP serves HTTPHandler until the context is done.
+func (b *Bot) serveHTTP(ctx context.Context) error {
+	server, err := b.httpServer()
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+	if err := listen(server); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (b *Bot) httpServer() (*http.Server, error) {
+	cfg := b.httpConfig
+	if cfg.Addr == "" {
+		return nil, errors.New("slackbot: no HTTP address configured")
+	}
+
+	server := &http.Server{Addr: cfg.Addr, Handler: b.HTTPHandler()}
+	if cfg.CertFile == "" {
+		if cfg.ClientCAFile != "" {
+			return nil, errors.New("slackbot: client certificate authentication requires a server certificate")
+		}
+		return server, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if cfg.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("slackbot: no certificate found in %s", cfg.ClientCAFile)
+		}
+		server.TLSConfig.ClientCAs = pool
+		server.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return server, nil
+}
+
+// listen serves HTTPS when the server has a certificate, HTTP otherwise.
+func listen(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
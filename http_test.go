@@ -0,0 +1,59 @@
+package slackbot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetHTTPConfig(HTTPConfig{BasePath: "slack/", SigningSecret: "secret", AdminToken: "admin"})
+	bot.botUserID = "UBOT"
+	bot.Mount("/metrics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("metrics"))
+	}))
+	handler := bot.HTTPHandler()
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	assert.Equal("ok", get("/slack/healthz", "").Body.String())
+	assert.Equal("metrics", get("/slack/metrics", "").Body.String())
+	assert.Equal(http.StatusNotFound, get("/healthz", "").Code)
+	// endpoints of Slack require signed requests
+	assert.Equal(http.StatusUnauthorized, get("/slack/events", "").Code)
+	assert.Equal(http.StatusForbidden, get("/slack/admin/maintenance", "").Code)
+	assert.Equal(http.StatusForbidden, get("/slack/admin/maintenance", "wrong").Code)
+	assert.NotEqual(http.StatusForbidden, get("/slack/admin/maintenance", "admin").Code)
+}
+
+func TestHTTPServer(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botUserID = "UBOT"
+
+	_, err := bot.httpServer()
+	assert.Error(err)
+
+	bot.SetHTTPConfig(HTTPConfig{Addr: ":8080", ClientCAFile: "ca.pem"})
+	_, err = bot.httpServer()
+	assert.Error(err)
+
+	bot.SetHTTPConfig(HTTPConfig{Addr: ":8080", CertFile: "missing.pem", KeyFile: "missing.key"})
+	_, err = bot.httpServer()
+	assert.Error(err)
+
+	bot.SetHTTPConfig(HTTPConfig{Addr: ":8080"})
+	server, err := bot.httpServer()
+	assert.NoError(err)
+	assert.Nil(server.TLSConfig)
+}
@@ -0,0 +1,88 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const IDEMPOTENCY_CONTEXT = "__IDEMPOTENCY_CONTEXT__"
+
+// DuplicateText is the reply sent for a repeated command whose first result was not recorded.
+var DuplicateText = "I already did that a moment ago."
+
+// KeyFunc derives a key identifying a request from the handler context.
+type KeyFunc func(ctx context.Context) string
+
+// MessageKey identifies a message by its author, channel and text, ignoring a leading mention.
+func MessageKey(ctx context.Context) string {
+	msg := MessageFromContext(ctx)
+	return msg.User + "|" + msg.Channel + "|" + StripDirectMention(msg.Text)
+}
+
+// Idempotent makes repeated requests with the same key within ttl reuse the result of the
+// first one instead of running the handler again. Results are recorded with RecordResult,
+// which AckHandler does automatically, and kept in the bot Store. Requests failing, as
+// reported with HandleError, or panicking are forgotten so that they may be retried.
+func (r *Route) Idempotent(keyFn KeyFunc, ttl time.Duration) *Route {
+	var mu sync.Mutex
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			key := "idempotent:" + keyFn(ctx)
+
+			mu.Lock()
+			result, found, err := bot.Store().Get(key)
+			if err == nil && !found {
+				err = bot.Store().Set(key, []byte{}, ttl)
+			}
+			mu.Unlock()
+			if err != nil {
+				fmt.Printf("Error accessing idempotency key: %s\n", err)
+			}
+
+			if found {
+				text := DuplicateText
+				if len(result) > 0 {
+					text = string(result)
+				}
+				bot.Reply(MessageFromContext(ctx), text)
+				return
+			}
+			call := &idempotentCall{bot: bot, key: key, ttl: ttl}
+			defer func() {
+				if p := recover(); p != nil {
+					call.forget()
+					panic(p)
+				}
+			}()
+			next(context.WithValue(ctx, IDEMPOTENCY_CONTEXT, call))
+		}
+	})
+}
+
+type idempotentCall struct {
+	bot *Bot
+	key string
+	ttl time.Duration
+}
+
+// forget deletes the key of a failed request.
+func (c *idempotentCall) forget() {
+	if err := c.bot.Store().Delete(c.key); err != nil {
+		fmt.Printf("Error deleting idempotency key: %s\n", err)
+	}
+}
+
+// RecordResult records the text of the reply to an idempotent request so that duplicates
+// receive it too. It does nothing outside of an Idempotent route.
+func RecordResult(ctx context.Context, text string) {
+	call, ok := ctx.Value(IDEMPOTENCY_CONTEXT).(*idempotentCall)
+	if !ok {
+		return
+	}
+	if err := BotFromContext(ctx).Store().Set(call.key, []byte(text), call.ttl); err != nil {
+		fmt.Printf("Error recording idempotent result: %s\n", err)
+	}
+}
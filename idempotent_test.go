@@ -0,0 +1,62 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func idempotentMessage(text string) *slack.MessageEvent {
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", text
+	return evt
+}
+
+func TestIdempotent(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	ctx := AddBotToContext(context.Background(), bot)
+	runs := 0
+	bot.Hear("^restart (.*)$").Idempotent(MessageKey, time.Minute).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		runs++
+		if evt.Text == "restart web" {
+			RecordResult(ctx, "web restarted")
+		}
+	})
+
+	bot.handleMessage(ctx, idempotentMessage("restart web"))
+	bot.handleMessage(ctx, idempotentMessage("restart web"))
+	bot.handleMessage(ctx, idempotentMessage("restart db"))
+	bot.handleMessage(ctx, idempotentMessage("restart db"))
+	assert.Equal(2, runs)
+	assert.Equal([]string{"web restarted", DuplicateText}, api.values("chat.postMessage", "text"))
+}
+
+func TestIdempotentFailure(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	newSlackAPI(t, bot)
+	ctx := AddBotToContext(context.Background(), bot)
+	runs := 0
+	bot.Hear("^restart (.*)$").Idempotent(MessageKey, time.Minute).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		runs++
+		switch runs {
+		case 1:
+			bot.HandleError(ctx, errors.New("timeout"))
+		case 2:
+			panic("boom")
+		}
+	})
+
+	// failed requests run again
+	bot.handleMessage(ctx, idempotentMessage("restart web"))
+	assert.Panics(func() { bot.handleMessage(ctx, idempotentMessage("restart web")) })
+	bot.handleMessage(ctx, idempotentMessage("restart web"))
+	bot.handleMessage(ctx, idempotentMessage("restart web"))
+	assert.Equal(3, runs)
+}
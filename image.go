@@ -0,0 +1,54 @@
+package slackbot
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/slack-go/slack"
+)
+
+// ChartRenderer renders a chart, or any picture, as PNG. Adapters for chart libraries are
+// typically a few lines, e.g. with go-chart:
+//
+//	slackbot.ChartRendererFunc(func(w io.Writer) error { return graph.Render(chart.PNG, w) })
+type ChartRenderer interface {
+	RenderPNG(w io.Writer) error
+}
+
+// ChartRendererFunc adapts a function to the ChartRenderer interface.
+type ChartRendererFunc func(w io.Writer) error
+
+func (f ChartRendererFunc) RenderPNG(w io.Writer) error {
+	return f(w)
+}
+
+// ReplyImage replies to a message event with an image, uploaded as PNG. The reply goes in
+// the thread of the message when it has one.
+func (b *Bot) ReplyImage(evt *slack.MessageEvent, img image.Image, title string) error {
+	return b.ReplyChart(evt, ChartRendererFunc(func(w io.Writer) error { return png.Encode(w, img) }), title)
+}
+
+// ReplyChart replies to a message event with the picture drawn by the renderer.
+func (b *Bot) ReplyChart(evt *slack.MessageEvent, r ChartRenderer, title string) error {
+	var buf bytes.Buffer
+	if err := r.RenderPNG(&buf); err != nil {
+		return err
+	}
+	return b.ReplyPNG(evt, buf.Bytes(), title)
+}
+
+// ReplyPNG replies to a message event with an image already encoded as PNG.
+func (b *Bot) ReplyPNG(evt *slack.MessageEvent, data []byte, title string) error {
+	b.RequireScopes("files:write")
+	_, err := b.Client.UploadFile(slack.FileUploadParameters{
+		Reader:          bytes.NewReader(data),
+		Filename:        "image.png",
+		Filetype:        "png",
+		Title:           title,
+		Channels:        []string{evt.Channel},
+		ThreadTimestamp: evt.ThreadTimestamp,
+	})
+	return err
+}
@@ -0,0 +1,32 @@
+package slackbot
+
+import (
+	"errors"
+	"image"
+	"io"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyImage(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.ThreadTimestamp = "C1", "1.0"
+
+	assert.NoError(bot.ReplyImage(evt, image.NewRGBA(image.Rect(0, 0, 4, 4)), "Latency"))
+	assert.Equal([]string{"image.png"}, api.values("files.upload", "file"))
+	assert.Equal([]string{"png"}, api.values("files.upload", "filetype"))
+	assert.Equal([]string{"Latency"}, api.values("files.upload", "title"))
+	assert.Equal([]string{"C1"}, api.values("files.upload", "channels"))
+	assert.Equal([]string{"1.0"}, api.values("files.upload", "thread_ts"))
+	assert.True(bot.requiredScopes["files:write"])
+
+	// nothing is uploaded when the chart fails to render
+	err := bot.ReplyChart(evt, ChartRendererFunc(func(w io.Writer) error { return errors.New("no data") }), "Latency")
+	assert.EqualError(err, "no data")
+	assert.Len(api.values("files.upload", "file"), 1)
+}
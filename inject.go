@@ -0,0 +1,88 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/slack-go/slack"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	botType     = reflect.TypeOf((*Bot)(nil))
+	messageType = reflect.TypeOf((*slack.MessageEvent)(nil))
+	argsType    = reflect.TypeOf(Args(nil))
+)
+
+// Provide registers values handed to injected handlers asking for a parameter of their type,
+// or of an interface they implement.
+func (b *Bot) Provide(values ...interface{}) *Bot {
+	for _, v := range values {
+		b.provided = append(b.provided, reflect.ValueOf(v))
+	}
+	return b
+}
+
+// InjectHandler sets a handler declared as a function with typed parameters, populated when
+// the route matches. Besides provided values, it may ask for the context.Context, the *Bot,
+// the *slack.MessageEvent and the parsed Args. It may return an error, which is replied.
+//
+//	bot.Provide(db)
+//	bot.Hear("^users$").InjectHandler(func(bot *slackbot.Bot, evt *slack.MessageEvent, db *sql.DB) error {...})
+func (r *Route) InjectHandler(fn interface{}) *Route {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func {
+		r.err = fmt.Errorf("slackbot: injected handler must be a function, got %s", t)
+		return r
+	}
+	if t.NumOut() > 1 || (t.NumOut() == 1 && t.Out(0) != errorType) {
+		r.err = fmt.Errorf("slackbot: injected handler must return nothing or an error, got %s", t)
+		return r
+	}
+
+	return r.Handler(func(ctx context.Context) {
+		bot := BotFromContext(ctx)
+		msg := MessageFromContext(ctx)
+		in := make([]reflect.Value, t.NumIn())
+		for i := range in {
+			arg, err := bot.resolve(ctx, t.In(i))
+			if err != nil {
+				fmt.Printf("Error injecting handler: %s\n", err)
+				return
+			}
+			in[i] = arg
+		}
+		out := v.Call(in)
+		if len(out) == 1 && !out[0].IsNil() {
+			bot.Reply(msg, fmt.Sprintf(":x: %s", out[0].Interface()))
+		}
+	})
+}
+
+// resolve returns the value of a handler parameter of the given type.
+func (b *Bot) resolve(ctx context.Context, t reflect.Type) (reflect.Value, error) {
+	switch t {
+	case contextType:
+		return reflect.ValueOf(&ctx).Elem(), nil
+	case botType:
+		return reflect.ValueOf(b), nil
+	case messageType:
+		return reflect.ValueOf(MessageFromContext(ctx)), nil
+	case argsType:
+		return reflect.ValueOf(ArgsFromContext(ctx)), nil
+	}
+	for _, v := range b.provided {
+		if v.Type() == t {
+			return v, nil
+		}
+	}
+	for _, v := range b.provided {
+		if t.Kind() == reflect.Interface && v.Type().Implements(t) {
+			return v, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("no value provided for %s", t)
+}
@@ -0,0 +1,50 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+type greeter interface {
+	Greet(user string) string
+}
+
+type politeGreeter struct{ greeting string }
+
+func (g *politeGreeter) Greet(user string) string { return fmt.Sprintf("%s <@%s>", g.greeting, user) }
+
+func TestInjectHandler(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Provide(&politeGreeter{greeting: "Hello"}, 42)
+	api := newSlackAPI(t, bot)
+	ctx := AddBotToContext(context.Background(), bot)
+
+	bot.Hear("^hi$").InjectHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent, g greeter, n int) {
+		bot.Reply(evt, fmt.Sprintf("%s, %d", g.Greet(evt.User), n))
+	})
+	bot.Hear("^fail$").InjectHandler(func() error { return errors.New("no luck") })
+
+	for _, text := range []string{"hi", "fail"} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U1", text
+		bot.handleMessage(ctx, evt)
+	}
+	assert.Equal([]string{"Hello <@U1>, 42", ":x: no luck"}, api.values("chat.postMessage", "text"))
+}
+
+func TestInjectHandlerInvalid(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	assert.Error(bot.Hear("^a$").InjectHandler("not a function").GetError())
+	assert.Error(bot.Hear("^b$").InjectHandler(func() string { return "" }).GetError())
+
+	// nothing implements the interface
+	_, err := bot.resolve(context.Background(), reflect.TypeOf((*greeter)(nil)).Elem())
+	assert.Error(err)
+}
@@ -0,0 +1,305 @@
+// Package alertmanager receives Prometheus Alertmanager notifications in a slackbot.Bot.
+// Alerts are posted as one message per alertname, updated in place as alerts fire and
+// resolve, with buttons silencing the alert through the Alertmanager API.
+//
+// Configure the receiver in Alertmanager with:
+//
+//	receivers:
+//	- name: slack
+//	  webhook_configs:
+//	  - url: https://bot.example.com/alertmanager
+//	    http_config:
+//	      authorization:
+//	        credentials: <token>
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+)
+
+// Alert is an alert of a notification.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Notification is the payload of Alertmanager webhooks.
+type Notification struct {
+	Version     string  `json:"version"`
+	GroupKey    string  `json:"groupKey"`
+	Status      string  `json:"status"`
+	Receiver    string  `json:"receiver"`
+	ExternalURL string  `json:"externalURL"`
+	Alerts      []Alert `json:"alerts"`
+}
+
+// Config configures the receiver.
+type Config struct {
+	// Channel where alerts are posted
+	Channel string
+	// Base URL of the Alertmanager API, e.g. http://alertmanager:9093
+	AlertmanagerURL string
+	// Bearer token expected from Alertmanager, which is rejected when empty
+	Token string
+	// Endpoint of the receiver, "/alertmanager" if empty
+	Endpoint string
+	// Durations offered by the silence buttons, 1 hour and 1 day when empty
+	SilenceDurations []time.Duration
+	// User groups whose members may silence alerts, anyone if empty
+	SilencerGroups []string
+	// HTTP client calling the Alertmanager API, http.DefaultClient when nil
+	Client *http.Client
+}
+
+const silenceAction = "alertmanager_silence"
+
+// Register mounts the receiver on the bot HTTP handler and handles the silence buttons.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "/alertmanager"
+	}
+	if len(cfg.SilenceDurations) == 0 {
+		cfg.SilenceDurations = []time.Duration{time.Hour, 24 * time.Hour}
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	r := &receiver{cfg: cfg, alerts: make(map[string]map[string]Alert)}
+	bot.RequireScopes("chat:write")
+	bot.Mount(cfg.Endpoint, r.webhook(bot))
+	bot.OnAction(silenceAction, func(ctx context.Context, bot *slackbot.Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		r.silence(bot, callback, action.Value)
+	})
+}
+
+// receiver keeps the alerts of each alertname, deduplicated by fingerprint.
+type receiver struct {
+	cfg    Config
+	mu     sync.Mutex
+	alerts map[string]map[string]Alert
+}
+
+// webhook receives the notifications of Alertmanager, authenticated by the token.
+func (r *receiver) webhook(bot *slackbot.Bot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if r.cfg.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(r.cfg.Token)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var n Notification
+		if err := json.NewDecoder(req.Body).Decode(&n); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := r.notify(bot, n); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func messageKey(alertname string) string {
+	return "alertmanager:" + alertname
+}
+
+// notify merges the alerts of the notification and updates the message of each alertname.
+func (r *receiver) notify(bot *slackbot.Bot, n Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range r.merge(n.Alerts) {
+		alerts := sortedAlerts(r.alerts[name])
+		msg := &slackbot.OutgoingMessage{
+			Channel: r.cfg.Channel,
+			Text:    summary(name, alerts),
+			Blocks:  r.blocks(name, alerts, n.ExternalURL),
+		}
+		store := bot.Store()
+		if ts, found, err := store.Get(messageKey(name)); err == nil && found {
+			msg.Timestamp = string(ts)
+		}
+		ts, err := bot.Send(msg)
+		if err != nil {
+			return err
+		}
+		if firing(alerts) == 0 {
+			// the next firing starts a new message
+			delete(r.alerts, name)
+			_ = store.Delete(messageKey(name))
+		} else if msg.Timestamp == "" {
+			_ = store.Set(messageKey(name), []byte(ts), 0)
+		}
+	}
+	return nil
+}
+
+// merge records the alerts, replacing the previous state of each, and returns the alertnames
+// updated.
+func (r *receiver) merge(alerts []Alert) []string {
+	updated := make(map[string]bool)
+	for _, a := range alerts {
+		name := a.Labels["alertname"]
+		if r.alerts[name] == nil {
+			r.alerts[name] = make(map[string]Alert)
+		}
+		r.alerts[name][a.Fingerprint] = a
+		updated[name] = true
+	}
+	names := make([]string, 0, len(updated))
+	for name := range updated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedAlerts(alerts map[string]Alert) []Alert {
+	sorted := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		sorted = append(sorted, a)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartsAt.Before(sorted[j].StartsAt) })
+	return sorted
+}
+
+func firing(alerts []Alert) int {
+	n := 0
+	for _, a := range alerts {
+		if a.Status == "firing" {
+			n++
+		}
+	}
+	return n
+}
+
+func summary(name string, alerts []Alert) string {
+	if n := firing(alerts); n > 0 {
+		return fmt.Sprintf(":rotating_light: *%s* firing (%d)", name, n)
+	}
+	return fmt.Sprintf(":white_check_mark: *%s* resolved", name)
+}
+
+// describe returns a line describing an alert from its annotations and labels.
+func describe(a Alert) string {
+	text := a.Annotations["summary"]
+	if text == "" {
+		text = a.Annotations["description"]
+	}
+	var labels []string
+	for k, v := range a.Labels {
+		if k != "alertname" {
+			labels = append(labels, k+"="+v)
+		}
+	}
+	sort.Strings(labels)
+	line := "• "
+	if a.Status != "firing" {
+		line += "~" + strings.Join(labels, " ") + "~"
+	} else {
+		line += "`" + strings.Join(labels, " ") + "`"
+	}
+	if text != "" {
+		line += " " + text
+	}
+	if a.GeneratorURL != "" {
+		line += " <" + a.GeneratorURL + "|graph>"
+	}
+	return line
+}
+
+func (r *receiver) blocks(name string, alerts []Alert, externalURL string) []slack.Block {
+	lines := []string{summary(name, alerts)}
+	for _, a := range alerts {
+		lines = append(lines, describe(a))
+	}
+	text := strings.Join(lines, "\n")
+	if externalURL != "" {
+		text += "\n<" + externalURL + "|Alertmanager>"
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+	}
+	if firing(alerts) > 0 && r.cfg.AlertmanagerURL != "" {
+		var buttons []slack.BlockElement
+		for _, d := range r.cfg.SilenceDurations {
+			label := slack.NewTextBlockObject(slack.PlainTextType, "Silence "+formatDuration(d), false, false)
+			buttons = append(buttons, slack.NewButtonBlockElement(silenceAction, name+"|"+d.String(), label))
+		}
+		blocks = append(blocks, slack.NewActionBlock("", buttons...))
+	}
+	return blocks
+}
+
+func formatDuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
+
+// silence creates a silence of the alertname for the duration of the button clicked.
+func (r *receiver) silence(bot *slackbot.Bot, callback *slack.InteractionCallback, value string) {
+	user := callback.User.ID
+	reply := func(text string) {
+		_, _ = bot.Send(&slackbot.OutgoingMessage{Channel: callback.Channel.ID, Text: text, EphemeralUser: user})
+	}
+	if len(r.cfg.SilencerGroups) > 0 && !bot.Allows(slackbot.Allowlist{Groups: r.cfg.SilencerGroups}, user) {
+		reply("You are not allowed to silence alerts.")
+		return
+	}
+	name, duration, _ := strings.Cut(value, "|")
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	silence := map[string]interface{}{
+		"matchers":  []map[string]interface{}{{"name": "alertname", "value": name, "isRegex": false, "isEqual": true}},
+		"startsAt":  now,
+		"endsAt":    now.Add(d),
+		"createdBy": callback.User.Name,
+		"comment":   fmt.Sprintf("Silenced from Slack by %s", callback.User.Name),
+	}
+	body, err := json.Marshal(silence)
+	if err != nil {
+		return
+	}
+	resp, err := r.cfg.Client.Post(strings.TrimSuffix(r.cfg.AlertmanagerURL, "/")+"/api/v2/silences", "application/json", bytes.NewReader(body))
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			err = fmt.Errorf("%s", resp.Status)
+		}
+	}
+	if err != nil {
+		reply(fmt.Sprintf("Could not silence *%s*: %s", name, err))
+		return
+	}
+	_, _ = bot.Send(&slackbot.OutgoingMessage{
+		Channel: callback.Channel.ID,
+		Text:    fmt.Sprintf(":no_bell: <@%s> silenced *%s* for %s", user, name, formatDuration(d)),
+		Params:  slack.PostMessageParameters{ThreadTimestamp: callback.Message.Timestamp},
+	})
+}
@@ -0,0 +1,93 @@
+package alertmanager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+	r := &receiver{alerts: make(map[string]map[string]Alert)}
+	start := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	alert := func(name, instance, status, fingerprint string) Alert {
+		return Alert{
+			Status:      status,
+			Labels:      map[string]string{"alertname": name, "instance": instance},
+			Annotations: map[string]string{"summary": "down"},
+			StartsAt:    start,
+			Fingerprint: fingerprint,
+		}
+	}
+
+	names := r.merge([]Alert{alert("InstanceDown", "a:9100", "firing", "1"), alert("DiskFull", "b:9100", "firing", "2")})
+	assert.Equal([]string{"DiskFull", "InstanceDown"}, names)
+
+	// the same alert notified again is deduplicated
+	r.merge([]Alert{alert("InstanceDown", "a:9100", "firing", "1")})
+	alerts := sortedAlerts(r.alerts["InstanceDown"])
+	assert.Len(alerts, 1)
+	assert.Equal(":rotating_light: *InstanceDown* firing (1)", summary("InstanceDown", alerts))
+	assert.Equal("• `instance=a:9100` down", describe(alerts[0]))
+
+	r.merge([]Alert{alert("InstanceDown", "a:9100", "resolved", "1")})
+	alerts = sortedAlerts(r.alerts["InstanceDown"])
+	assert.Equal(":white_check_mark: *InstanceDown* resolved", summary("InstanceDown", alerts))
+	assert.Equal("• ~instance=a:9100~ down", describe(alerts[0]))
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "1h", formatDuration(time.Hour))
+	assert.Equal(t, "2d", formatDuration(48*time.Hour))
+	assert.Equal(t, "30m0s", formatDuration(30*time.Minute))
+}
+
+func TestWebhook(t *testing.T) {
+	assert := assert.New(t)
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		sent = append(sent, strings.TrimPrefix(r.URL.Path, "/")+" "+r.FormValue("ts")+" "+r.FormValue("text"))
+		w.Write([]byte(`{"ok": true, "channel": "C1", "ts": "1.0"}`))
+	}))
+	defer server.Close()
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	post := func(r *receiver, token, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/alertmanager", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		r.webhook(bot).ServeHTTP(rec, req)
+		return rec.Code
+	}
+	notification := func(status string) string {
+		return `{"status": "` + status + `", "alerts": [{"status": "` + status + `", "labels": {"alertname": "InstanceDown", "instance": "a:9100"}, "fingerprint": "1"}]}`
+	}
+
+	// without a token every notification is rejected
+	r := &receiver{cfg: Config{Channel: "C1"}, alerts: make(map[string]map[string]Alert)}
+	assert.Equal(http.StatusForbidden, post(r, "", notification("firing")))
+
+	r = &receiver{cfg: Config{Channel: "C1", Token: "secret"}, alerts: make(map[string]map[string]Alert)}
+	assert.Equal(http.StatusForbidden, post(r, "wrong", notification("firing")))
+	assert.Equal(http.StatusBadRequest, post(r, "secret", "{"))
+	assert.Empty(sent)
+
+	// the message of the alertname is updated until resolved
+	assert.Equal(http.StatusOK, post(r, "secret", notification("firing")))
+	assert.Equal(http.StatusOK, post(r, "secret", notification("resolved")))
+	assert.Equal(http.StatusOK, post(r, "secret", notification("firing")))
+	assert.Equal([]string{
+		"chat.postMessage  :rotating_light: *InstanceDown* firing (1)",
+		"chat.update 1.0 :white_check_mark: *InstanceDown* resolved",
+		"chat.postMessage  :rotating_light: *InstanceDown* firing (1)",
+	}, sent)
+}
@@ -0,0 +1,277 @@
+// Package archive archives the channels of a workspace left inactive, on behalf of a
+// slackbot.Bot. Once a day, public channels without messages for InactiveDays are warned
+// that they will be archived, and are archived after the grace period unless someone posts
+// in them. Admins may preview the outcome at any time:
+//
+//	@bot archive report
+//
+// In dry-run mode nothing is warned nor archived, the report is posted instead. Reports also
+// list the channels the bot must be invited in to read their history.
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// Config configures the archive policy.
+type Config struct {
+	// Days without messages after which a channel is warned, 90 when zero
+	InactiveDays int
+	// Delay between the warning and archiving, 7 days when zero
+	Grace time.Duration
+	// Channels never archived, by name or ID; the general channel never is
+	Allow []string
+	// Only report what would be done
+	DryRun bool
+	// Channel receiving the reports, none when empty
+	ReportChannel string
+	// When the policy runs, daily at 9:00 UTC when nil
+	Schedule slackbot.Schedule
+	// Text of the warnings, WarningText when empty; %s is replaced by the archive date
+	Warning string
+}
+
+// WarningText warns a channel that it will be archived.
+var WarningText = "This channel has been inactive for a while and will be archived on %s, unless someone posts in it."
+
+// api is the part of the Slack API used by the policy.
+type api interface {
+	GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	ArchiveConversation(channelID string) error
+}
+
+// Action is what the policy does with a channel.
+type Action string
+
+const (
+	Warn    Action = "warn"
+	Archive Action = "archive"
+	// The channel was warned and is waiting for the end of the grace period
+	Wait Action = "wait"
+	// The bot cannot read the history of the channel until invited in it
+	NotInChannel Action = "not_in_channel"
+)
+
+// Decision is the action taken for an inactive channel.
+type Decision struct {
+	Channel      slack.Channel
+	LastActivity time.Time
+	Action       Action
+}
+
+type policy struct {
+	cfg   Config
+	api   api
+	store slackbot.Store
+	botID string
+	*integration.Module
+}
+
+// Register schedules the archive policy and adds the `archive report` admin command.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.InactiveDays == 0 {
+		cfg.InactiveDays = 90
+	}
+	if cfg.Grace == 0 {
+		cfg.Grace = 7 * 24 * time.Hour
+	}
+	if cfg.Schedule == nil {
+		cfg.Schedule = slackbot.DailyAt(9, 0, time.UTC)
+	}
+	if cfg.Warning == "" {
+		cfg.Warning = WarningText
+	}
+	p := &policy{cfg: cfg, api: bot.Client, store: bot.Store(), Module: integration.NewModule(bot)}
+	bot.RequireScopes("channels:read", "channels:history", "channels:manage", "chat:write")
+
+	bot.Schedule(cfg.Schedule, func(ctx context.Context, bot *slackbot.Bot) {
+		p.botID = bot.BotUserID()
+		decisions, err := p.run(!cfg.DryRun)
+		if err != nil {
+			fmt.Printf("Error applying the archive policy: %s\n", err)
+			return
+		}
+		if cfg.ReportChannel != "" && (cfg.DryRun || len(decisions) > 0) {
+			_, _ = p.Send(&slackbot.OutgoingMessage{Channel: cfg.ReportChannel, Text: p.report(decisions),
+				Params: slack.PostMessageParameters{AsUser: true}})
+		}
+	})
+	bot.Hear(`(?i)^archive report$`).AdminOnly().MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		p.botID = bot.BotUserID()
+		decisions, err := p.run(false)
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not check the channels: %s", err))
+			return
+		}
+		bot.Reply(evt, p.report(decisions))
+	})
+}
+
+// run decides the action for each inactive channel, and applies it unless dry.
+func (p *policy) run(apply bool) ([]Decision, error) {
+	channels, err := p.channels()
+	if err != nil {
+		return nil, err
+	}
+	var decisions []Decision
+	for _, ch := range channels {
+		if ch.IsArchived || ch.IsGeneral || p.allowed(ch) {
+			continue
+		}
+		last, err := p.lastActivity(ch)
+		if errors.Is(slackbot.WrapError(err), slackbot.ErrNotInChannel) {
+			decisions = append(decisions, Decision{Channel: ch, Action: NotInChannel})
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error reading #%s: %s\n", ch.Name, err)
+			continue
+		}
+		warned, isWarned := p.warned(ch.ID)
+		if isWarned && last.After(warned) {
+			// someone posted since the warning
+			_ = p.store.Delete(warnedKey(ch.ID))
+			isWarned = false
+		}
+		if p.Now().Sub(last) < time.Duration(p.cfg.InactiveDays)*24*time.Hour {
+			continue
+		}
+
+		d := Decision{Channel: ch, LastActivity: last, Action: Warn}
+		if isWarned {
+			d.Action = Wait
+			if p.Now().Sub(warned) >= p.cfg.Grace {
+				d.Action = Archive
+			}
+		}
+		decisions = append(decisions, d)
+		if apply {
+			p.apply(d)
+		}
+	}
+	return decisions, nil
+}
+
+func (p *policy) apply(d Decision) {
+	switch d.Action {
+	case Warn:
+		date := p.Now().Add(p.cfg.Grace).Format("Monday, January 2")
+		if _, err := p.Send(&slackbot.OutgoingMessage{Channel: d.Channel.ID, Text: fmt.Sprintf(p.cfg.Warning, date),
+			Params: slack.PostMessageParameters{AsUser: true}}); err != nil {
+			fmt.Printf("Error warning #%s: %s\n", d.Channel.Name, err)
+			return
+		}
+		_ = p.store.Set(warnedKey(d.Channel.ID), []byte(strconv.FormatInt(p.Now().Unix(), 10)), 0)
+	case Archive:
+		if err := p.api.ArchiveConversation(d.Channel.ID); err != nil {
+			fmt.Printf("Error archiving #%s: %s\n", d.Channel.Name, err)
+			return
+		}
+		_ = p.store.Delete(warnedKey(d.Channel.ID))
+	}
+}
+
+func (p *policy) channels() ([]slack.Channel, error) {
+	var all []slack.Channel
+	params := &slack.GetConversationsParameters{Types: []string{"public_channel"}, Limit: 200}
+	for {
+		channels, cursor, err := p.api.GetConversations(params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, channels...)
+		if cursor == "" {
+			return all, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+func (p *policy) allowed(ch slack.Channel) bool {
+	for _, a := range p.cfg.Allow {
+		a = strings.TrimPrefix(a, "#")
+		if a == ch.ID || strings.EqualFold(a, ch.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastActivity returns the time of the last message not sent by the bot, or the creation
+// of the channel without one, reading the history back until one is found.
+func (p *policy) lastActivity(ch slack.Channel) (time.Time, error) {
+	params := &slack.GetConversationHistoryParameters{ChannelID: ch.ID, Limit: 100}
+	for {
+		history, err := p.api.GetConversationHistory(params)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, msg := range history.Messages {
+			if msg.User == p.botID && p.botID != "" || msg.SubType == "channel_join" || msg.SubType == "channel_leave" {
+				continue
+			}
+			return slackbot.TimestampTime(msg.Timestamp), nil
+		}
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			return ch.Created.Time(), nil
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+}
+
+func (p *policy) warned(channel string) (time.Time, bool) {
+	data, found, err := p.store.Get(warnedKey(channel))
+	if err != nil || !found {
+		return time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(unix, 0), true
+}
+
+// report describes the decisions.
+func (p *policy) report(decisions []Decision) string {
+	if len(decisions) == 0 {
+		return fmt.Sprintf("No channel inactive for %d days.", p.cfg.InactiveDays)
+	}
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].LastActivity.Before(decisions[j].LastActivity) })
+	titles := map[Action]string{Archive: "To archive", Warn: "To warn", Wait: "Warned, in grace period",
+		NotInChannel: "Not checked, the bot is not in the channel"}
+	var lines []string
+	if p.cfg.DryRun {
+		lines = append(lines, "_Dry run, nothing was changed._")
+	}
+	for _, action := range []Action{Archive, Warn, Wait, NotInChannel} {
+		var names []string
+		for _, d := range decisions {
+			switch {
+			case d.Action != action:
+			case action == NotInChannel:
+				names = append(names, fmt.Sprintf("<#%s>", d.Channel.ID))
+			default:
+				names = append(names, fmt.Sprintf("<#%s> (last active %s)", d.Channel.ID, d.LastActivity.Format("2006-01-02")))
+			}
+		}
+		if len(names) > 0 {
+			lines = append(lines, fmt.Sprintf("*%s:*\n• %s", titles[action], strings.Join(names, "\n• ")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func warnedKey(channel string) string {
+	return "archive:warned:" + channel
+}
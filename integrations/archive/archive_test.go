@@ -0,0 +1,118 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAPI struct {
+	channels []slack.Channel
+	last     map[string]time.Time
+	archived []string
+	// channels the bot is not in
+	outside map[string]bool
+}
+
+func (f *fakeAPI) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	// one channel per page
+	i := 0
+	fmt.Sscan(params.Cursor, &i)
+	next := ""
+	if i+1 < len(f.channels) {
+		next = fmt.Sprint(i + 1)
+	}
+	return f.channels[i : i+1], next, nil
+}
+
+func (f *fakeAPI) GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	if f.outside[params.ChannelID] {
+		return nil, errors.New("not_in_channel")
+	}
+	resp := &slack.GetConversationHistoryResponse{}
+	last, ok := f.last[params.ChannelID]
+	switch {
+	case !ok:
+	case params.Cursor == "":
+		// the bot posted last, the activity is on the next page
+		resp.Messages = []slack.Message{{Msg: slack.Msg{User: "UBOT", Timestamp: fmt.Sprintf("%d.000100", last.Add(time.Hour).Unix())}}}
+		resp.HasMore = true
+		resp.ResponseMetaData.NextCursor = "2"
+	default:
+		resp.Messages = []slack.Message{{Msg: slack.Msg{User: "U1", Timestamp: fmt.Sprintf("%d.000100", last.Unix())}}}
+	}
+	return resp, nil
+}
+
+func (f *fakeAPI) ArchiveConversation(channelID string) error {
+	f.archived = append(f.archived, channelID)
+	return nil
+}
+
+func channel(id, name string) slack.Channel {
+	var ch slack.Channel
+	ch.ID, ch.Name = id, name
+	return ch
+}
+
+func TestPolicy(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	api := &fakeAPI{
+		channels: []slack.Channel{channel("C1", "busy"), channel("C2", "stale"), channel("C3", "kept"), channel("C4", "empty"), channel("C5", "private")},
+		last: map[string]time.Time{
+			"C1": now.Add(-24 * time.Hour),
+			"C2": now.Add(-100 * 24 * time.Hour),
+			"C3": now.Add(-200 * 24 * time.Hour),
+		},
+		outside: map[string]bool{"C5": true},
+	}
+	var sent []string
+	p := &policy{
+		cfg:   Config{InactiveDays: 90, Grace: 7 * 24 * time.Hour, Allow: []string{"#kept"}, Warning: WarningText},
+		api:   api,
+		store: slackbot.NewMemoryStore(),
+		botID: "UBOT",
+		Module: &integration.Module{
+			Send: func(msg *slackbot.OutgoingMessage) (string, error) {
+				sent = append(sent, msg.Channel+": "+msg.Text)
+				return "1.0", nil
+			},
+			Now: func() time.Time { return now },
+		},
+	}
+
+	decisions, err := p.run(false)
+	assert.NoError(err)
+	assert.Len(decisions, 3)
+	assert.Len(sent, 0)
+	report := p.report(decisions)
+	assert.Contains(report, "*To warn:*\n• <#C4> (last active 1970-01-01)\n• <#C2> (last active 2025-11-21)")
+	assert.Contains(report, "*Not checked, the bot is not in the channel:*\n• <#C5>")
+
+	_, err = p.run(true)
+	assert.NoError(err)
+	assert.Equal([]string{"C2: " + fmt.Sprintf(WarningText, "Sunday, March 8"), "C4: " + fmt.Sprintf(WarningText, "Sunday, March 8")}, sent)
+
+	now = now.Add(3 * 24 * time.Hour)
+	decisions, _ = p.run(true)
+	assert.Equal(Wait, decisions[0].Action)
+	assert.Len(api.archived, 0)
+
+	// someone posted in C4, and the grace period of C2 is over
+	api.last["C4"] = now.Add(-time.Hour)
+	now = now.Add(5 * 24 * time.Hour)
+	decisions, _ = p.run(true)
+	assert.Len(decisions, 2)
+	assert.Equal(Archive, decisions[0].Action)
+	assert.Equal(NotInChannel, decisions[1].Action)
+	assert.Equal([]string{"C2"}, api.archived)
+	_, warned := p.warned("C4")
+	assert.False(warned)
+}
@@ -0,0 +1,209 @@
+// Package calendar integrates calendars with a slackbot.Bot: users ask when colleagues are
+// free, and reminders of upcoming meetings, with a button to join them, are posted to
+// channels. Calendars are accessed through a Provider, so Google Calendar and Microsoft
+// Graph backends, or any other, can be plugged in.
+//
+//	calendar.Register(bot, calendar.Config{
+//		Provider:  googleProvider,
+//		Reminders: []calendar.Reminder{{CalendarID: "team@example.com", Channel: "C0123", Before: 5 * time.Minute}},
+//	})
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+)
+
+// Busy is a period during which a person is not available.
+type Busy struct {
+	Start, End time.Time
+}
+
+// Event is a calendar event.
+type Event struct {
+	ID      string
+	Title   string
+	Start   time.Time
+	End     time.Time
+	JoinURL string
+}
+
+// Provider gives access to calendars.
+type Provider interface {
+	// Busy returns the busy periods of the person with the email address, between from and to.
+	Busy(ctx context.Context, email string, from, to time.Time) ([]Busy, error)
+	// Events returns the events of the calendar starting between from and to.
+	Events(ctx context.Context, calendarID string, from, to time.Time) ([]Event, error)
+}
+
+// Reminder posts reminders of the events of a calendar to a channel.
+type Reminder struct {
+	CalendarID string
+	Channel    string
+	// How long before events reminders are posted
+	Before time.Duration
+}
+
+// Config configures the calendar integration.
+type Config struct {
+	Provider  Provider
+	Reminders []Reminder
+	// Working hours, in the timezone of each person, 9 to 18 when zero
+	WorkdayStart, WorkdayEnd int
+	// How far ahead free slots are looked for, 3 days when zero
+	Lookahead time.Duration
+}
+
+const freeRegexp = `(?i)^when is <@(\w+)(?:\|[^>]*)?> free\??$`
+
+// Register adds the calendar commands and reminders to the bot.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.WorkdayStart == 0 && cfg.WorkdayEnd == 0 {
+		cfg.WorkdayStart, cfg.WorkdayEnd = 9, 18
+	}
+	if cfg.Lookahead == 0 {
+		cfg.Lookahead = 3 * 24 * time.Hour
+	}
+	bot.RequireScopes("users:read", "users:read.email")
+
+	re := regexp.MustCompile(freeRegexp)
+	bot.Hear(freeRegexp).Usage("when is @person free").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		userID := re.FindStringSubmatch(slackbot.TextFromContext(ctx))[1]
+		bot.Reply(evt, whenFree(ctx, bot, cfg, userID))
+	})
+
+	if len(cfg.Reminders) > 0 {
+		bot.Schedule(slackbot.Every(time.Minute), func(ctx context.Context, bot *slackbot.Bot) {
+			now := time.Now()
+			for _, r := range cfg.Reminders {
+				remind(ctx, bot, cfg.Provider, r, now)
+			}
+		})
+	}
+}
+
+func whenFree(ctx context.Context, bot *slackbot.Bot, cfg Config, userID string) string {
+	user, err := bot.Client.GetUserInfo(userID)
+	if err != nil || user.Profile.Email == "" {
+		return fmt.Sprintf("I can't find the calendar of <@%s>.", userID)
+	}
+	loc, err := bot.UserLocation(userID)
+	if err != nil {
+		loc = time.UTC
+	}
+	from := time.Now()
+	to := from.Add(cfg.Lookahead)
+	busy, err := cfg.Provider.Busy(ctx, user.Profile.Email, from, to)
+	if err != nil {
+		return fmt.Sprintf("I can't read the calendar of <@%s>: %s", userID, err)
+	}
+	slots := FreeSlots(busy, from, to, cfg.WorkdayStart, cfg.WorkdayEnd, loc)
+	if len(slots) == 0 {
+		return fmt.Sprintf("<@%s> is not free in the coming days.", userID)
+	}
+	if len(slots) > 5 {
+		slots = slots[:5]
+	}
+	lines := make([]string, len(slots))
+	for i, s := range slots {
+		lines[i] = fmt.Sprintf("• %s to %s",
+			slackbot.FormatDate(s.Start, slackbot.DateShortPretty+" "+slackbot.DateTime),
+			slackbot.FormatDate(s.End, slackbot.DateTime))
+	}
+	return fmt.Sprintf("<@%s> is free:\n%s", userID, strings.Join(lines, "\n"))
+}
+
+// Slot is a free period.
+type Slot struct {
+	Start, End time.Time
+}
+
+// FreeSlots returns the periods between from and to, within working hours of the location,
+// not overlapping any busy period.
+func FreeSlots(busy []Busy, from, to time.Time, workdayStart, workdayEnd int, loc *time.Location) []Slot {
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+
+	var slots []Slot
+	day := from.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		start := maxTime(day.Add(time.Duration(workdayStart)*time.Hour), from)
+		end := minTime(day.Add(time.Duration(workdayEnd)*time.Hour), to)
+		for _, b := range busy {
+			if !start.Before(end) {
+				break
+			}
+			if !b.End.After(start) || !b.Start.Before(end) {
+				continue
+			}
+			if b.Start.After(start) {
+				slots = append(slots, Slot{Start: start, End: b.Start})
+			}
+			start = maxTime(start, b.End)
+		}
+		if start.Before(end) {
+			slots = append(slots, Slot{Start: start, End: end})
+		}
+	}
+	return slots
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// remindedKey is the Store key recording the reminder of an event to a channel, the event
+// being reminded again if rescheduled.
+func remindedKey(channel string, e Event) string {
+	return fmt.Sprintf("calendar:reminded:%s:%s:%d", channel, e.ID, e.Start.Unix())
+}
+
+// remind posts the reminders of the events starting within the minute after the reminder
+// delay. Events are reminded once, even when returned again by the next runs.
+func remind(ctx context.Context, bot *slackbot.Bot, provider Provider, r Reminder, now time.Time) {
+	from := now.Add(r.Before)
+	events, err := provider.Events(ctx, r.CalendarID, from, from.Add(time.Minute))
+	if err != nil {
+		fmt.Printf("Error reading calendar %s: %s\n", r.CalendarID, err)
+		return
+	}
+	store := bot.Store()
+	for _, e := range events {
+		key := remindedKey(r.Channel, e)
+		if _, found, err := store.Get(key); err == nil && found {
+			continue
+		}
+		text := fmt.Sprintf("*%s* starts %s", e.Title, slackbot.FormatDate(e.Start, slackbot.DateAgo))
+		blocks := []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		}
+		if e.JoinURL != "" {
+			join := slack.NewButtonBlockElement("calendar_join", e.ID, slack.NewTextBlockObject(slack.PlainTextType, "Join", false, false))
+			join.URL = e.JoinURL
+			blocks = append(blocks, slack.NewActionBlock("", join))
+		}
+		if _, err := bot.Send(&slackbot.OutgoingMessage{Channel: r.Channel, Text: text, Blocks: blocks}); err != nil {
+			fmt.Printf("Error posting reminder: %s\n", err)
+			continue
+		}
+		// kept until the event starts, after which it is no longer returned
+		_ = store.Set(key, []byte{1}, e.Start.Sub(now)+time.Hour)
+	}
+}
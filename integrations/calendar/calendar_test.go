@@ -0,0 +1,76 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProvider returns its events whatever the period asked.
+type fakeProvider struct {
+	events []Event
+}
+
+func (p *fakeProvider) Busy(ctx context.Context, email string, from, to time.Time) ([]Busy, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) Events(ctx context.Context, calendarID string, from, to time.Time) ([]Event, error) {
+	return p.events, nil
+}
+
+func TestFreeSlots(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	busy := []Busy{
+		{Start: at(1, 14, 0), End: at(1, 15, 30)},
+		{Start: at(1, 8, 0), End: at(1, 10, 0)},
+		{Start: at(2, 9, 0), End: at(2, 18, 0)},
+	}
+
+	slots := FreeSlots(busy, at(1, 7, 0), at(3, 12, 0), 9, 18, time.UTC)
+	assert.Equal(t, []Slot{
+		{Start: at(1, 10, 0), End: at(1, 14, 0)},
+		{Start: at(1, 15, 30), End: at(1, 18, 0)},
+		{Start: at(3, 9, 0), End: at(3, 12, 0)},
+	}, slots)
+}
+
+func TestRemind(t *testing.T) {
+	assert := assert.New(t)
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		posted = append(posted, r.FormValue("channel")+" "+r.FormValue("text"))
+		w.Write([]byte(`{"ok": true, "channel": "C1", "ts": "1.0"}`))
+	}))
+	defer server.Close()
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+
+	now := time.Now()
+	start := now.Add(5 * time.Minute)
+	provider := &fakeProvider{events: []Event{{ID: "standup", Title: "Standup", Start: start}}}
+	team := Reminder{CalendarID: "team", Channel: "C1", Before: 5 * time.Minute}
+	other := Reminder{CalendarID: "team", Channel: "C2", Before: 5 * time.Minute}
+
+	// events returned by consecutive runs are reminded once per channel
+	remind(context.Background(), bot, provider, team, now)
+	remind(context.Background(), bot, provider, team, now.Add(30*time.Second))
+	remind(context.Background(), bot, provider, other, now)
+	assert.Len(posted, 2)
+	assert.Contains(posted[0], "C1 *Standup* starts")
+	assert.Contains(posted[1], "C2 *Standup* starts")
+
+	// a rescheduled event is reminded again
+	provider.events[0].Start = start.Add(time.Minute)
+	remind(context.Background(), bot, provider, team, now.Add(time.Minute))
+	assert.Len(posted, 3)
+}
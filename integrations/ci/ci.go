@@ -0,0 +1,175 @@
+// Package ci triggers CI/CD jobs from a slackbot.Bot:
+//
+//	@bot build <job> [key=value...]
+//	@bot deploy <service> to <env>
+//
+// The status of the job is streamed in the channel while it runs, followed by the result
+// with a link to the job. CI servers are reached through a Backend; Jenkins is supported
+// out of the box.
+package ci
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+)
+
+// States of a build.
+const (
+	StateQueued  = "queued"
+	StateRunning = "running"
+	StateSuccess = "success"
+	StateFailure = "failure"
+	StateAborted = "aborted"
+)
+
+// Build is a triggered job.
+type Build struct {
+	Job string
+	// Backend specific reference of the build, e.g. the URL of a Jenkins queue item
+	Ref string
+}
+
+// Status is the status of a build.
+type Status struct {
+	State string
+	// Link to the build, once known
+	URL string
+}
+
+// Done returns true once the build finished.
+func (s *Status) Done() bool {
+	return s.State != StateQueued && s.State != StateRunning
+}
+
+// Backend triggers and follows jobs on a CI server.
+type Backend interface {
+	Trigger(ctx context.Context, job string, params map[string]string) (*Build, error)
+	Status(ctx context.Context, build *Build) (*Status, error)
+}
+
+// Config configures the CI commands.
+type Config struct {
+	Backend Backend
+	// Jobs which may be built, any job if empty
+	Jobs []string
+	// Job run by the deploy command, with the "service" and "env" parameters
+	DeployJob string
+	// Delay between status checks, 10 seconds when zero
+	PollInterval time.Duration
+	// Maximum duration followed, 1 hour when zero
+	Timeout time.Duration
+}
+
+const (
+	buildRegexp  = `(?i)^build (\S+)((?: \S+=\S*)*)$`
+	deployRegexp = `(?i)^deploy (\S+) to (\S+)$`
+)
+
+// Register adds the CI commands to the bot.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 10 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = time.Hour
+	}
+
+	build := regexp.MustCompile(buildRegexp)
+	bot.Hear(buildRegexp).Usage("build <job> [key=value...]").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		args := build.FindStringSubmatch(slackbot.TextFromContext(ctx))
+		if !cfg.allowed(args[1]) {
+			bot.Reply(evt, fmt.Sprintf("Unknown job `%s`.", args[1]))
+			return
+		}
+		run(ctx, bot, evt, cfg, args[1], parseParams(args[2]))
+	})
+
+	if cfg.DeployJob != "" {
+		deploy := regexp.MustCompile(deployRegexp)
+		bot.Hear(deployRegexp).Usage("deploy <service> to <env>").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+			args := deploy.FindStringSubmatch(slackbot.TextFromContext(ctx))
+			run(ctx, bot, evt, cfg, cfg.DeployJob, map[string]string{"service": args[1], "env": args[2]})
+		})
+	}
+}
+
+func (cfg Config) allowed(job string) bool {
+	if len(cfg.Jobs) == 0 {
+		return true
+	}
+	for _, j := range cfg.Jobs {
+		if j == job {
+			return true
+		}
+	}
+	return false
+}
+
+// parseParams parses space separated key=value pairs.
+func parseParams(s string) map[string]string {
+	params := make(map[string]string)
+	for _, field := range strings.Fields(s) {
+		if k, v, ok := strings.Cut(field, "="); ok {
+			params[k] = v
+		}
+	}
+	return params
+}
+
+// run triggers the job and streams its status until it finishes.
+func run(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent, cfg Config, job string, params map[string]string) {
+	b, err := cfg.Backend.Trigger(ctx, job, params)
+	if err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not start `%s`: %s", job, err))
+		return
+	}
+
+	stream := bot.StartStream(evt)
+	defer stream.Close()
+	fmt.Fprintf(stream, "`%s` %s", job, StateQueued)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	state := StateQueued
+	for {
+		select {
+		case <-ctx.Done():
+			bot.Reply(evt, fmt.Sprintf("Stopped following `%s`, still %s.", job, state))
+			return
+		case <-ticker.C:
+		}
+		status, err := cfg.Backend.Status(ctx, b)
+		if err != nil {
+			fmt.Printf("Error getting the status of %s: %s\n", job, err)
+			continue
+		}
+		if status.State != state {
+			state = status.State
+			fmt.Fprintf(stream, " → %s", state)
+		}
+		if status.Done() {
+			bot.Reply(evt, result(job, status))
+			return
+		}
+	}
+}
+
+func result(job string, status *Status) string {
+	icon := ":x:"
+	if status.State == StateSuccess {
+		icon = ":white_check_mark:"
+	}
+	text := fmt.Sprintf("%s `%s` finished: %s", icon, job, status.State)
+	if status.URL != "" {
+		text += fmt.Sprintf(" (<%s|details>)", status.URL)
+	}
+	return text
+}
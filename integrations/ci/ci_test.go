@@ -0,0 +1,45 @@
+package ci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParams(t *testing.T) {
+	assert.Equal(t, map[string]string{"branch": "main", "dry": ""}, parseParams(" branch=main dry= ignored"))
+}
+
+func TestJenkins(t *testing.T) {
+	assert := assert.New(t)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/folder/job/app/buildWithParameters":
+			assert.NoError(r.ParseForm())
+			assert.Equal("main", r.PostForm.Get("branch"))
+			w.Header().Set("Location", server.URL+"/queue/item/7/")
+			w.WriteHeader(http.StatusCreated)
+		case "/queue/item/7/api/json":
+			fmt.Fprintf(w, `{"executable":{"url":"%s/job/folder/job/app/12/"}}`, server.URL)
+		case "/job/folder/job/app/12/api/json":
+			fmt.Fprint(w, `{"building":false,"result":"SUCCESS"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	j := &Jenkins{URL: server.URL, User: "bot", Token: "secret"}
+	build, err := j.Trigger(context.Background(), "folder/app", map[string]string{"branch": "main"})
+	assert.NoError(err)
+	status, err := j.Status(context.Background(), build)
+	assert.NoError(err)
+	assert.Equal(StateSuccess, status.State)
+	assert.Equal(server.URL+"/job/folder/job/app/12/", status.URL)
+	assert.True(status.Done())
+}
@@ -0,0 +1,111 @@
+package ci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Jenkins is a Backend triggering parameterized Jenkins jobs.
+type Jenkins struct {
+	// Base URL of the server, e.g. https://jenkins.example.com
+	URL string
+	// User and API token
+	User, Token string
+	// HTTP client, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func (j *Jenkins) Trigger(ctx context.Context, job string, params map[string]string) (*Build, error) {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	path := "/job/" + strings.ReplaceAll(url.PathEscape(job), "%2F", "/job/") + "/buildWithParameters"
+	resp, err := j.do(ctx, http.MethodPost, j.URL+path, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	// the queue item of the build
+	queue := resp.Header.Get("Location")
+	if queue == "" {
+		return nil, fmt.Errorf("jenkins: no queue item for %s", job)
+	}
+	return &Build{Job: job, Ref: queue}, nil
+}
+
+func (j *Jenkins) Status(ctx context.Context, build *Build) (*Status, error) {
+	var item struct {
+		Cancelled  bool `json:"cancelled"`
+		Executable *struct {
+			URL string `json:"url"`
+		} `json:"executable"`
+	}
+	if err := j.getJSON(ctx, strings.TrimSuffix(build.Ref, "/")+"/api/json", &item); err != nil {
+		return nil, err
+	}
+	if item.Cancelled {
+		return &Status{State: StateAborted}, nil
+	}
+	if item.Executable == nil {
+		return &Status{State: StateQueued}, nil
+	}
+
+	var run struct {
+		Building bool   `json:"building"`
+		Result   string `json:"result"`
+	}
+	if err := j.getJSON(ctx, strings.TrimSuffix(item.Executable.URL, "/")+"/api/json", &run); err != nil {
+		return nil, err
+	}
+	status := &Status{URL: item.Executable.URL}
+	switch {
+	case run.Building:
+		status.State = StateRunning
+	case run.Result == "SUCCESS":
+		status.State = StateSuccess
+	case run.Result == "ABORTED":
+		status.State = StateAborted
+	default:
+		status.State = StateFailure
+	}
+	return status, nil
+}
+
+func (j *Jenkins) getJSON(ctx context.Context, u string, v interface{}) error {
+	resp, err := j.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (j *Jenkins) do(ctx context.Context, method, u string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.SetBasicAuth(j.User, j.Token)
+	client := j.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("jenkins: %s %s: %s", method, u, resp.Status)
+	}
+	return resp, nil
+}
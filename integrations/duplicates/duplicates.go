@@ -0,0 +1,235 @@
+// Package duplicates points askers to the answers of similar questions in a slackbot.Bot.
+// Questions asked in channels, messages ending with a question mark outside threads, are
+// indexed with the number of replies in their thread. When a question close to an answered
+// one is asked, the bot replies in its thread with links to the previous threads:
+//
+//	duplicates.Register(bot, duplicates.Config{Channels: []string{supportChannelID}})
+//
+// Questions are scored by keywords, tolerating typos, or by an Embedder when set, as in the
+// faq package.
+package duplicates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/faq"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// Config configures the detector.
+type Config struct {
+	// Channels watched, by ID; all the channels of the bot when empty
+	Channels []string
+	// Minimum score, between 0 and 1, of the similar questions; 0.6 when zero
+	Threshold float64
+	// Scores questions by embeddings when set, by keywords otherwise
+	Embedder faq.Embedder
+	// How long questions are remembered, 30 days when zero
+	Window time.Duration
+	// Questions remembered per channel, the most recent, 500 when zero
+	MaxQuestions int
+	// Previous questions linked in a reply, 3 when zero
+	MaxLinks int
+	// Text introducing the links, DuplicateText when empty
+	Text string
+}
+
+// DuplicateText introduces the links to the previous questions.
+var DuplicateText = "This looks similar to questions answered before:"
+
+// Question is an indexed question.
+type Question struct {
+	TS   string `json:"ts"`
+	User string `json:"user"`
+	Text string `json:"text"`
+	// Replies of other users in its thread
+	Answers int       `json:"answers"`
+	Vector  []float64 `json:"vector,omitempty"`
+}
+
+// Match is a previous question similar to a new one.
+type Match struct {
+	Question Question
+	Score    float64
+}
+
+type detector struct {
+	cfg   Config
+	store slackbot.Store
+	*integration.Module
+	// how the previous questions are linked, replaced in tests
+	permalink func(channel, ts string) (string, error)
+}
+
+// Register indexes the questions of the channels and replies to the duplicates.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.Threshold == 0 {
+		cfg.Threshold = 0.6
+	}
+	if cfg.Window == 0 {
+		cfg.Window = 30 * 24 * time.Hour
+	}
+	if cfg.MaxQuestions == 0 {
+		cfg.MaxQuestions = 500
+	}
+	if cfg.MaxLinks == 0 {
+		cfg.MaxLinks = 3
+	}
+	if cfg.Text == "" {
+		cfg.Text = DuplicateText
+	}
+	d := &detector{cfg: cfg, store: bot.Store(), Module: integration.NewModule(bot), permalink: bot.Permalink}
+	bot.RequireScopes("channels:history", "groups:history", "chat:write")
+
+	bot.OnEvent("message", func(ctx context.Context, bot *slackbot.Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.SubType != "" || msg.BotID != "" || !integration.Watched(d.cfg.Channels, msg.Channel) {
+			return
+		}
+		if msg.ThreadTimestamp != "" && msg.ThreadTimestamp != msg.Timestamp {
+			d.answer(msg.Channel, msg.ThreadTimestamp, msg.User)
+			return
+		}
+		if !isQuestion(msg.Text) {
+			return
+		}
+		matches, err := d.ask(ctx, msg.Channel, Question{TS: msg.Timestamp, User: msg.User, Text: msg.Text})
+		if err != nil {
+			fmt.Printf("Error indexing question: %s\n", err)
+		}
+		if len(matches) > 0 {
+			d.reply(msg.Channel, msg.Timestamp, matches)
+		}
+	})
+}
+
+func isQuestion(text string) bool {
+	return strings.HasSuffix(strings.TrimSpace(text), "?")
+}
+
+// ask returns the answered questions similar to q, the closest first, and indexes q.
+func (d *detector) ask(ctx context.Context, channel string, q Question) ([]Match, error) {
+	if d.cfg.Embedder != nil {
+		vectors, err := d.cfg.Embedder.Embed(ctx, []string{q.Text})
+		if err == nil && len(vectors) == 1 {
+			q.Vector = vectors[0]
+		} else if err != nil {
+			fmt.Printf("Error embedding question: %s\n", err)
+		}
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	questions, err := d.load(channel)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Match
+	for _, prev := range questions {
+		if prev.Answers == 0 || prev.TS == q.TS {
+			continue
+		}
+		if score := d.score(q, prev); score >= d.cfg.Threshold {
+			matches = append(matches, Match{Question: prev, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > d.cfg.MaxLinks {
+		matches = matches[:d.cfg.MaxLinks]
+	}
+
+	questions = append(questions, q)
+	if len(questions) > d.cfg.MaxQuestions {
+		questions = questions[len(questions)-d.cfg.MaxQuestions:]
+	}
+	return matches, d.save(channel, questions)
+}
+
+// score compares the questions by embeddings when both have one, by keywords otherwise.
+func (d *detector) score(q, prev Question) float64 {
+	if len(q.Vector) > 0 && len(prev.Vector) > 0 {
+		return faq.Cosine(q.Vector, prev.Vector)
+	}
+	return faq.Similarity(q.Text, prev.Text)
+}
+
+// answer counts a reply in the thread of an indexed question, unless from its asker.
+func (d *detector) answer(channel, threadTS, user string) {
+	d.Lock()
+	defer d.Unlock()
+	questions, err := d.load(channel)
+	if err != nil {
+		fmt.Printf("Error loading questions: %s\n", err)
+		return
+	}
+	for i := range questions {
+		if questions[i].TS == threadTS && questions[i].User != user {
+			questions[i].Answers++
+			if err := d.save(channel, questions); err != nil {
+				fmt.Printf("Error saving questions: %s\n", err)
+			}
+			return
+		}
+	}
+}
+
+// reply links the previous questions in the thread of the new one.
+func (d *detector) reply(channel, ts string, matches []Match) {
+	lines := []string{d.cfg.Text}
+	for _, m := range matches {
+		text := m.Question.Text
+		if link, err := d.permalink(channel, m.Question.TS); err == nil {
+			text = fmt.Sprintf("<%s|%s>", link, text)
+		}
+		replies := "replies"
+		if m.Question.Answers == 1 {
+			replies = "reply"
+		}
+		lines = append(lines, fmt.Sprintf("• %s (%d %s)", text, m.Question.Answers, replies))
+	}
+	if _, err := d.Send(&slackbot.OutgoingMessage{
+		Channel: channel,
+		Text:    strings.Join(lines, "\n"),
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: ts},
+	}); err != nil {
+		fmt.Printf("Error linking similar questions: %s\n", err)
+	}
+}
+
+// load returns the questions of the channel asked within the window.
+func (d *detector) load(channel string) ([]Question, error) {
+	data, found, err := d.store.Get(questionsKey(channel))
+	if err != nil || !found {
+		return nil, err
+	}
+	var questions []Question
+	if err := json.Unmarshal(data, &questions); err != nil {
+		return nil, err
+	}
+	recent := questions[:0]
+	for _, q := range questions {
+		if d.Now().Sub(slackbot.TimestampTime(q.TS)) < d.cfg.Window {
+			recent = append(recent, q)
+		}
+	}
+	return recent, nil
+}
+
+func (d *detector) save(channel string, questions []Question) error {
+	data, err := json.Marshal(questions)
+	if err != nil {
+		return err
+	}
+	return d.store.Set(questionsKey(channel), data, d.cfg.Window)
+}
+
+func questionsKey(channel string) string {
+	return "duplicates:" + channel
+}
@@ -0,0 +1,110 @@
+package duplicates
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/faq"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDetector(now time.Time) (*detector, *[]*slackbot.OutgoingMessage) {
+	var sent []*slackbot.OutgoingMessage
+	d := &detector{
+		cfg:   Config{Threshold: 0.6, Window: 30 * 24 * time.Hour, MaxQuestions: 3, MaxLinks: 2, Text: DuplicateText},
+		store: slackbot.NewMemoryStore(),
+		Module: &integration.Module{
+			Send: func(msg *slackbot.OutgoingMessage) (string, error) {
+				sent = append(sent, msg)
+				return "1.0", nil
+			},
+			Now: func() time.Time { return now },
+		},
+		permalink: func(channel, ts string) (string, error) {
+			return fmt.Sprintf("https://example.slack.com/archives/%s/p%s", channel, ts), nil
+		},
+	}
+	return d, &sent
+}
+
+func ts(t time.Time) string {
+	return fmt.Sprintf("%d.000100", t.Unix())
+}
+
+func TestDuplicates(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	d, sent := newDetector(now)
+	ctx := context.Background()
+
+	first := Question{TS: ts(now.Add(-time.Hour)), User: "U1", Text: "How do I reset my VPN password?"}
+	matches, err := d.ask(ctx, "C1", first)
+	assert.NoError(err)
+	assert.Len(matches, 0)
+
+	// unanswered questions are not linked
+	matches, _ = d.ask(ctx, "C1", Question{TS: ts(now.Add(-time.Minute)), User: "U2", Text: "How can I reset the VPN password?"})
+	assert.Len(matches, 0)
+
+	d.answer("C1", first.TS, "U1")
+	d.answer("C1", first.TS, "U3")
+	matches, _ = d.ask(ctx, "C1", Question{TS: ts(now), User: "U4", Text: "reset vpn passwd?"})
+	if assert.Len(matches, 1) {
+		assert.Equal(first.TS, matches[0].Question.TS)
+		assert.Equal(1, matches[0].Question.Answers)
+	}
+	matches, _ = d.ask(ctx, "C1", Question{TS: ts(now), User: "U4", Text: "Where is the lunch menu?"})
+	assert.Len(matches, 0)
+	matches, _ = d.ask(ctx, "C2", Question{TS: ts(now), User: "U4", Text: "How do I reset my VPN password?"})
+	assert.Len(matches, 0)
+
+	d.reply("C1", ts(now), []Match{{Question: first}})
+	if assert.Len(*sent, 1) {
+		msg := (*sent)[0]
+		assert.Equal(ts(now), msg.Params.ThreadTimestamp)
+		assert.Contains(msg.Text, "<https://example.slack.com/archives/C1/p"+first.TS+"|How do I reset my VPN password?>")
+	}
+}
+
+func TestIndexLimits(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	d, _ := newDetector(now)
+	ctx := context.Background()
+
+	_, _ = d.ask(ctx, "C1", Question{TS: ts(now.Add(-40 * 24 * time.Hour)), Text: "Old question?"})
+	for i := 0; i < 4; i++ {
+		_, _ = d.ask(ctx, "C1", Question{TS: ts(now.Add(time.Duration(i) * time.Second)), Text: fmt.Sprintf("Question %d?", i)})
+	}
+	questions, err := d.load("C1")
+	assert.NoError(err)
+	if assert.Len(questions, 3) {
+		assert.Equal("Question 1?", questions[0].Text)
+	}
+}
+
+func TestEmbeddings(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	d, _ := newDetector(now)
+	d.cfg.Threshold = 0.9
+	d.cfg.Embedder = faq.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float64, error) {
+		if texts[0] == "Is the build broken?" || texts[0] == "Why is CI red?" {
+			return [][]float64{{1, 0.1}}, nil
+		}
+		return [][]float64{{0, 1}}, nil
+	})
+	ctx := context.Background()
+
+	first := Question{TS: ts(now.Add(-time.Hour)), User: "U1", Text: "Is the build broken?"}
+	_, _ = d.ask(ctx, "C1", first)
+	d.answer("C1", first.TS, "U2")
+	matches, _ := d.ask(ctx, "C1", Question{TS: ts(now), User: "U3", Text: "Why is CI red?"})
+	assert.Len(matches, 1)
+	matches, _ = d.ask(ctx, "C1", Question{TS: ts(now), User: "U3", Text: "Is the build broken today?"})
+	assert.Len(matches, 0)
+}
@@ -0,0 +1,266 @@
+// Package emojistats collects the emoji used in the channels of a slackbot.Bot, in reactions
+// and in messages, and posts a leaderboard of the past week every Monday. The current week
+// of a channel is shown on demand:
+//
+//	@bot emoji stats
+//	@bot emoji stats #random
+//
+// Every use is also sent to the analytics sink of the bot as an EmojiEvent, with the
+// channel, user, emoji and source ("reaction" or "message") as properties.
+package emojistats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// EmojiEvent is the analytics event recorded for each emoji used.
+const EmojiEvent = "emoji_used"
+
+// Config configures the statistics.
+type Config struct {
+	// Channels watched, by ID; all the channels of the bot when empty
+	Channels []string
+	// Channel receiving the weekly leaderboards; each channel gets its own when empty
+	ReportChannel string
+	// Entries of the leaderboards, 5 when zero
+	Top int
+	// When the leaderboards are posted, Mondays at 9:00 UTC when nil
+	Schedule slackbot.Schedule
+	// How long the statistics of a week are kept, 12 weeks when zero
+	Retention time.Duration
+}
+
+// Usage is the emoji usage of a channel over a week.
+type Usage struct {
+	// Uses by emoji, reactions and messages together
+	Emoji map[string]int `json:"emoji"`
+	// Reactions added by user
+	Reactors map[string]int `json:"reactors"`
+}
+
+// Week is the usage of each channel over a week, by channel ID.
+type Week map[string]*Usage
+
+var emojiRe = regexp.MustCompile(`:([a-z0-9_+'-]+):`)
+
+type stats struct {
+	cfg   Config
+	store slackbot.Store
+	*integration.Module
+}
+
+// Register collects the emoji usage, schedules the leaderboards and adds the
+// `emoji stats` command.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.Top == 0 {
+		cfg.Top = 5
+	}
+	if cfg.Schedule == nil {
+		cfg.Schedule = slackbot.WeeklyAt(time.Monday, 9, 0, time.UTC)
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = 12 * 7 * 24 * time.Hour
+	}
+	s := &stats{cfg: cfg, store: bot.Store(), Module: integration.NewModule(bot)}
+	bot.RequireScopes("reactions:read", "channels:history", "chat:write")
+
+	bot.OnEvent("reaction_added", func(ctx context.Context, bot *slackbot.Bot, evt interface{}) {
+		if r, ok := evt.(*slack.ReactionAddedEvent); ok && integration.Watched(s.cfg.Channels, r.Item.Channel) {
+			s.record(r.Item.Channel, r.User, r.Reaction, 1)
+			track(ctx, r.Item.Channel, r.User, r.Reaction, "reaction")
+		}
+	})
+	bot.OnEvent("reaction_removed", func(ctx context.Context, bot *slackbot.Bot, evt interface{}) {
+		if r, ok := evt.(*slack.ReactionRemovedEvent); ok && integration.Watched(s.cfg.Channels, r.Item.Channel) {
+			s.record(r.Item.Channel, r.User, r.Reaction, -1)
+		}
+	})
+	bot.OnEvent("message", func(ctx context.Context, bot *slackbot.Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.SubType != "" || msg.BotID != "" || !integration.Watched(s.cfg.Channels, msg.Channel) {
+			return
+		}
+		for _, emoji := range parseEmoji(msg.Text) {
+			s.record(msg.Channel, "", emoji, 1)
+			track(ctx, msg.Channel, msg.User, emoji, "message")
+		}
+	})
+
+	bot.Schedule(cfg.Schedule, func(ctx context.Context, bot *slackbot.Bot) {
+		s.report(s.Now().AddDate(0, 0, -7))
+	})
+	statsRe := regexp.MustCompile(`(?i)^emoji stats(?: <#(\w+)(?:\|[^>]*)?>)?$`)
+	bot.Hear(statsRe.String()).Usage("emoji stats [#channel]").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		channel := statsRe.FindStringSubmatch(slackbot.TextFromContext(ctx))[1]
+		if channel == "" {
+			channel = evt.Channel
+		}
+		week, err := s.load(weekKey(s.Now()))
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not read the statistics: %s", err))
+			return
+		}
+		bot.Reply(evt, s.leaderboard(channel, week[channel], "this week"))
+	})
+}
+
+func track(ctx context.Context, channel, user, emoji, source string) {
+	slackbot.Track(ctx, EmojiEvent, map[string]string{
+		"channel": channel,
+		"user":    user,
+		"emoji":   emoji,
+		"source":  source,
+	})
+}
+
+// parseEmoji returns the emoji of a message text, without skin tones.
+func parseEmoji(text string) []string {
+	var emoji []string
+	for _, m := range emojiRe.FindAllStringSubmatch(text, -1) {
+		if !strings.HasPrefix(m[1], "skin-tone-") {
+			emoji = append(emoji, m[1])
+		}
+	}
+	return emoji
+}
+
+// record counts a use of the emoji in the channel this week, by the user when a reactor.
+func (s *stats) record(channel, user, emoji string, delta int) {
+	emoji, _, _ = strings.Cut(emoji, "::")
+	s.Lock()
+	defer s.Unlock()
+	key := weekKey(s.Now())
+	week, err := s.load(key)
+	if err != nil {
+		fmt.Printf("Error loading emoji statistics: %s\n", err)
+		return
+	}
+	usage := week[channel]
+	if usage == nil {
+		usage = &Usage{Emoji: make(map[string]int), Reactors: make(map[string]int)}
+		week[channel] = usage
+	}
+	add(usage.Emoji, emoji, delta)
+	if user != "" {
+		add(usage.Reactors, user, delta)
+	}
+	data, err := json.Marshal(week)
+	if err == nil {
+		err = s.store.Set(key, data, s.cfg.Retention)
+	}
+	if err != nil {
+		fmt.Printf("Error saving emoji statistics: %s\n", err)
+	}
+}
+
+func add(counts map[string]int, key string, delta int) {
+	counts[key] += delta
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
+
+func (s *stats) load(key string) (Week, error) {
+	week := make(Week)
+	data, found, err := s.store.Get(key)
+	if err != nil || !found {
+		return week, err
+	}
+	if err := json.Unmarshal(data, &week); err != nil {
+		return nil, err
+	}
+	for _, usage := range week {
+		if usage.Emoji == nil {
+			usage.Emoji = make(map[string]int)
+		}
+		if usage.Reactors == nil {
+			usage.Reactors = make(map[string]int)
+		}
+	}
+	return week, nil
+}
+
+// report posts the leaderboards of the week of t.
+func (s *stats) report(t time.Time) {
+	week, err := s.load(weekKey(t))
+	if err != nil {
+		fmt.Printf("Error loading emoji statistics: %s\n", err)
+		return
+	}
+	channels := make([]string, 0, len(week))
+	for channel, usage := range week {
+		if len(usage.Emoji) > 0 {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Strings(channels)
+	label := fmt.Sprintf("week of %s", monday(t).Format("January 2"))
+	for _, channel := range channels {
+		to := s.cfg.ReportChannel
+		if to == "" {
+			to = channel
+		}
+		if _, err := s.Send(&slackbot.OutgoingMessage{Channel: to, Text: s.leaderboard(channel, week[channel], label),
+			Params: slack.PostMessageParameters{AsUser: true}}); err != nil {
+			fmt.Printf("Error posting emoji leaderboard: %s\n", err)
+		}
+	}
+}
+
+// leaderboard describes the most used emoji and the most active reactors of the channel.
+func (s *stats) leaderboard(channel string, usage *Usage, label string) string {
+	if usage == nil || len(usage.Emoji) == 0 {
+		return fmt.Sprintf("No emoji used in <#%s> %s.", channel, label)
+	}
+	lines := []string{fmt.Sprintf("*Top emoji in <#%s>, %s*", channel, label)}
+	for i, e := range top(usage.Emoji, s.cfg.Top) {
+		lines = append(lines, fmt.Sprintf("%d. :%s: %d", i+1, e, usage.Emoji[e]))
+	}
+	if reactors := top(usage.Reactors, s.cfg.Top); len(reactors) > 0 {
+		mentions := make([]string, len(reactors))
+		for i, u := range reactors {
+			mentions[i] = fmt.Sprintf("<@%s> (%d)", u, usage.Reactors[u])
+		}
+		lines = append(lines, "*Most reactions:* "+strings.Join(mentions, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// top returns the n keys with the highest counts, ties by name.
+func top(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// monday returns the start of the ISO week of t.
+func monday(t time.Time) time.Time {
+	days := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, t.Location())
+}
+
+func weekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("emojistats:%d-W%02d", year, week)
+}
@@ -0,0 +1,63 @@
+package emojistats
+
+import (
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	assert := assert.New(t)
+	// a Wednesday
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	var sent []*slackbot.OutgoingMessage
+	s := &stats{
+		cfg:   Config{Top: 2, Retention: time.Hour},
+		store: slackbot.NewMemoryStore(),
+		Module: &integration.Module{
+			Send: func(msg *slackbot.OutgoingMessage) (string, error) {
+				sent = append(sent, msg)
+				return "1.0", nil
+			},
+			Now: func() time.Time { return now },
+		},
+	}
+
+	s.record("C1", "U1", "tada", 1)
+	s.record("C1", "U1", "+1::skin-tone-3", 1)
+	s.record("C1", "U2", "tada", 1)
+	s.record("C1", "U2", "eyes", 1)
+	s.record("C1", "U2", "eyes", -1)
+	for _, e := range parseEmoji("ship it :rocket: :tada: :wave::skin-tone-2:") {
+		s.record("C1", "", e, 1)
+	}
+	s.record("C2", "U3", "coffee", 1)
+
+	week, err := s.load(weekKey(now))
+	assert.NoError(err)
+	assert.Equal(map[string]int{"tada": 3, "+1": 1, "rocket": 1, "wave": 1}, week["C1"].Emoji)
+	assert.Equal(map[string]int{"U1": 2, "U2": 1}, week["C1"].Reactors)
+
+	board := s.leaderboard("C1", week["C1"], "this week")
+	assert.Equal("*Top emoji in <#C1>, this week*\n1. :tada: 3\n2. :+1: 1\n*Most reactions:* <@U1> (2), <@U2> (1)", board)
+	assert.Equal("No emoji used in <#C3> this week.", s.leaderboard("C3", week["C3"], "this week"))
+
+	// the following Monday, for the past week
+	now = time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	s.report(now.AddDate(0, 0, -7))
+	if assert.Len(sent, 2) {
+		assert.Equal("C1", sent[0].Channel)
+		assert.Contains(sent[0].Text, "week of March 2")
+		assert.Equal("C2", sent[1].Channel)
+	}
+	s.cfg.ReportChannel = "CREPORT"
+	sent = nil
+	s.report(now.AddDate(0, 0, -7))
+	if assert.Len(sent, 2) {
+		assert.Equal("CREPORT", sent[1].Channel)
+		assert.Contains(sent[1].Text, "<#C2>")
+	}
+}
@@ -0,0 +1,384 @@
+// Package faq answers frequently asked questions in a slackbot.Bot. Questions and answers
+// come from a YAML file and from bot admins:
+//
+//	@bot faq add How do I get VPN access? => Ask in #it-help with your manager in cc.
+//	@bot faq remove 3
+//	@bot faq list
+//	@bot faq stats
+//
+// Questions sent to the bot are scored against the known ones by keywords, tolerating
+// typos, or by an Embedder when set. Answered and unanswered questions are counted so the
+// entries can be curated. Register the FAQ after the other routes of the bot, as it answers
+// any message close enough to a known question, and any other question, ending with "?",
+// with the NoAnswer text.
+//
+// The YAML file lists the entries:
+//
+//	# faq.yaml
+//	- id: vpn
+//	  question: How do I get VPN access?
+//	  answer: Ask in #it-help with your manager in cc.
+//	  keywords: [vpn, remote]
+package faq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a question and its answer.
+type Entry struct {
+	ID       string   `yaml:"id" json:"id"`
+	Question string   `yaml:"question" json:"question"`
+	Answer   string   `yaml:"answer" json:"answer"`
+	Keywords []string `yaml:"keywords" json:"keywords,omitempty"`
+}
+
+// Config configures the FAQ.
+type Config struct {
+	// YAML file of entries, optional
+	File string
+	// Entries known besides those of the file and those added by admins
+	Entries []Entry
+	// Minimum score, between 0 and 1, of the questions answered; 0.6 when zero
+	Threshold float64
+	// Scores questions by embeddings when set, by keywords otherwise
+	Embedder Embedder
+	// Number of unanswered questions kept for curation, 20 when zero
+	MaxMisses int
+	// Reply to the questions matching no entry, NoAnswerText when empty
+	NoAnswer string
+}
+
+// NoAnswerText is the reply to the questions matching no entry.
+var NoAnswerText = "I don't know the answer to that yet, I noted the question."
+
+const (
+	entriesKey = "faq:entries"
+	missesKey  = "faq:misses"
+)
+
+// misses are the questions left unanswered.
+type misses struct {
+	Count  int      `json:"count"`
+	Recent []string `json:"recent"`
+}
+
+type faq struct {
+	cfg   Config
+	store slackbot.Store
+	mu    sync.Mutex
+	// entries of the config and file, and those added by admins
+	fixed []Entry
+	added []Entry
+	// embeddings of the entry questions
+	embeddings map[string][]float64
+}
+
+// LoadFile reads entries from a YAML file.
+func LoadFile(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("faq: %s: %w", path, err)
+	}
+	for i := range entries {
+		if entries[i].ID == "" {
+			entries[i].ID = fmt.Sprintf("file-%d", i+1)
+		}
+	}
+	return entries, nil
+}
+
+// Register adds the FAQ commands and answers to the bot.
+func Register(bot *slackbot.Bot, cfg Config) error {
+	if cfg.Threshold == 0 {
+		cfg.Threshold = 0.6
+	}
+	if cfg.MaxMisses == 0 {
+		cfg.MaxMisses = 20
+	}
+	if cfg.NoAnswer == "" {
+		cfg.NoAnswer = NoAnswerText
+	}
+	f := &faq{cfg: cfg, store: bot.Store(), fixed: cfg.Entries, embeddings: make(map[string][]float64)}
+	if cfg.File != "" {
+		entries, err := LoadFile(cfg.File)
+		if err != nil {
+			return err
+		}
+		f.fixed = append(f.fixed, entries...)
+	}
+	if data, found, err := f.store.Get(entriesKey); err == nil && found {
+		if err := json.Unmarshal(data, &f.added); err != nil {
+			return fmt.Errorf("faq: stored entries: %w", err)
+		}
+	}
+
+	addRe := regexp.MustCompile(`(?is)^faq add (.+?)\s*=>\s*(.+)$`)
+	bot.Hear(`(?is)^faq add .+=>.+$`).Usage("faq add <question> => <answer>").AdminOnly().MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		args := addRe.FindStringSubmatch(slackbot.TextFromContext(ctx))
+		e, err := f.add(args[1], args[2])
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not add the entry: %s", err))
+			return
+		}
+		bot.Reply(evt, fmt.Sprintf("Added entry `%s`.", e.ID))
+	})
+
+	bot.Hear(`(?i)^faq remove (\S+)$`).Usage("faq remove <id>").AdminOnly().MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		id := strings.Fields(slackbot.TextFromContext(ctx))[2]
+		removed, err := f.remove(id)
+		switch {
+		case err != nil:
+			bot.Reply(evt, fmt.Sprintf("Could not remove the entry: %s", err))
+		case !removed:
+			bot.Reply(evt, fmt.Sprintf("No entry `%s` added by admins.", id))
+		default:
+			bot.Reply(evt, fmt.Sprintf("Removed entry `%s`.", id))
+		}
+	})
+
+	bot.Hear(`(?i)^faq list$`).AdminOnly().MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		var lines []string
+		for _, e := range f.entries() {
+			lines = append(lines, fmt.Sprintf("`%s` %s", e.ID, e.Question))
+		}
+		if len(lines) == 0 {
+			bot.Reply(evt, "No FAQ entries.")
+			return
+		}
+		bot.Reply(evt, strings.Join(lines, "\n"))
+	})
+
+	bot.Hear(`(?i)^faq stats$`).AdminOnly().MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		bot.Reply(evt, f.stats())
+	})
+
+	bot.Messages(slackbot.DirectMessage, slackbot.DirectMention).AddMatcher(&QuestionMatcher{faq: f}).MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		e, ok := ctx.Value(ENTRY_CONTEXT).(Entry)
+		if !ok {
+			f.miss(strings.TrimSpace(slackbot.TextFromContext(ctx)))
+			bot.Reply(evt, cfg.NoAnswer)
+			return
+		}
+		f.incr("faq:hits:" + e.ID)
+		bot.Reply(evt, e.Answer)
+	})
+	return nil
+}
+
+func (f *faq) entries() []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append(append([]Entry(nil), f.fixed...), f.added...)
+}
+
+func (f *faq) add(question, answer string) (Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	next := 1
+	for _, e := range f.added {
+		if n, err := strconv.Atoi(e.ID); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	e := Entry{ID: strconv.Itoa(next), Question: question, Answer: answer}
+	f.added = append(f.added, e)
+	return e, f.saveLocked()
+}
+
+func (f *faq) remove(id string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, e := range f.added {
+		if e.ID == id {
+			f.added = append(f.added[:i], f.added[i+1:]...)
+			return true, f.saveLocked()
+		}
+	}
+	return false, nil
+}
+
+func (f *faq) saveLocked() error {
+	data, err := json.Marshal(f.added)
+	if err != nil {
+		return err
+	}
+	return f.store.Set(entriesKey, data, 0)
+}
+
+// best returns the entry closest to the question, and its score.
+func (f *faq) best(ctx context.Context, question string) (Entry, float64) {
+	entries := f.entries()
+	scores := make([]float64, len(entries))
+	if f.cfg.Embedder != nil {
+		var err error
+		if scores, err = f.embeddingScores(ctx, question, entries); err != nil {
+			fmt.Printf("Error embedding question: %s\n", err)
+			return Entry{}, 0
+		}
+	} else {
+		for i, e := range entries {
+			scores[i] = keywordScore(question, e)
+		}
+	}
+	var best Entry
+	var score float64
+	for i, e := range entries {
+		if scores[i] > score {
+			best, score = e, scores[i]
+		}
+	}
+	return best, score
+}
+
+// embeddingScores embeds the question, and the entries not embedded yet, and returns the
+// similarity of each entry.
+func (f *faq) embeddingScores(ctx context.Context, question string, entries []Entry) ([]float64, error) {
+	texts := []string{question}
+	f.mu.Lock()
+	for _, e := range entries {
+		if _, ok := f.embeddings[e.Question]; !ok {
+			texts = append(texts, e.Question)
+		}
+	}
+	f.mu.Unlock()
+
+	vectors, err := f.cfg.Embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("faq: %d embeddings for %d texts", len(vectors), len(texts))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, text := range texts[1:] {
+		f.embeddings[text] = vectors[i+1]
+	}
+	scores := make([]float64, len(entries))
+	for i, e := range entries {
+		scores[i] = Cosine(vectors[0], f.embeddings[e.Question])
+	}
+	return scores, nil
+}
+
+func (f *faq) incr(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	if data, found, err := f.store.Get(key); err == nil && found {
+		n, _ = strconv.Atoi(string(data))
+	}
+	_ = f.store.Set(key, []byte(strconv.Itoa(n+1)), 0)
+}
+
+func (f *faq) count(key string) int {
+	n := 0
+	if data, found, err := f.store.Get(key); err == nil && found {
+		n, _ = strconv.Atoi(string(data))
+	}
+	return n
+}
+
+func (f *faq) miss(question string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m := f.missesLocked()
+	m.Count++
+	m.Recent = append(m.Recent, question)
+	if len(m.Recent) > f.cfg.MaxMisses {
+		m.Recent = m.Recent[len(m.Recent)-f.cfg.MaxMisses:]
+	}
+	if data, err := json.Marshal(m); err == nil {
+		_ = f.store.Set(missesKey, data, 0)
+	}
+}
+
+func (f *faq) missesLocked() misses {
+	var m misses
+	if data, found, err := f.store.Get(missesKey); err == nil && found {
+		_ = json.Unmarshal(data, &m)
+	}
+	return m
+}
+
+// stats describes the hits of each entry, most asked first, and the questions missed.
+func (f *faq) stats() string {
+	type hit struct {
+		entry Entry
+		count int
+	}
+	var hits []hit
+	total := 0
+	for _, e := range f.entries() {
+		n := f.count("faq:hits:" + e.ID)
+		hits = append(hits, hit{e, n})
+		total += n
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].count > hits[j].count })
+
+	f.mu.Lock()
+	m := f.missesLocked()
+	f.mu.Unlock()
+
+	lines := []string{fmt.Sprintf("*%d* answered, *%d* unanswered", total, m.Count)}
+	if total+m.Count > 0 {
+		lines[0] += fmt.Sprintf(" (%.0f%% hit rate)", 100*float64(total)/float64(total+m.Count))
+	}
+	for _, h := range hits {
+		lines = append(lines, fmt.Sprintf("• `%s` %s: %d", h.entry.ID, h.entry.Question, h.count))
+	}
+	if len(m.Recent) > 0 {
+		lines = append(lines, "Recently unanswered:")
+		for _, q := range m.Recent {
+			lines = append(lines, "• "+q)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ============================================================================
+// Question Matcher
+// ============================================================================
+
+const ENTRY_CONTEXT = "__FAQ_ENTRY_CONTEXT__"
+
+// QuestionMatcher matches messages close enough to a known question, whose entry it adds
+// to the context, and the other questions, without entry.
+type QuestionMatcher struct {
+	faq       *faq
+	botUserID string
+}
+
+func (qm *QuestionMatcher) Match(ctx context.Context) (bool, context.Context) {
+	text := strings.TrimSpace(slackbot.TextFromContext(ctx))
+	if text == "" {
+		return false, ctx
+	}
+	entry, score := qm.faq.best(ctx, text)
+	if score < qm.faq.cfg.Threshold {
+		return strings.HasSuffix(text, "?"), ctx
+	}
+	return true, context.WithValue(ctx, ENTRY_CONTEXT, entry)
+}
+
+func (qm *QuestionMatcher) SetBotID(botID string) {
+	qm.botUserID = botID
+}
@@ -0,0 +1,107 @@
+package faq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordScore(t *testing.T) {
+	assert := assert.New(t)
+	vpn := Entry{Question: "How do I get VPN access?", Keywords: []string{"remote"}}
+	assert.Equal(1.0, keywordScore("how to get vpn access", vpn))
+	assert.True(keywordScore("getting VPN acess?", vpn) >= 0.6)
+	assert.True(keywordScore("remote access?", vpn) >= 0.6)
+	assert.True(keywordScore("where is the coffee machine?", vpn) < 0.6)
+}
+
+func TestBestAndStats(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "faq.yaml")
+	assert.NoError(os.WriteFile(file, []byte("- question: Where is the office?\n  answer: Downtown.\n"), 0o600))
+	entries, err := LoadFile(file)
+	assert.NoError(err)
+	assert.Equal("file-1", entries[0].ID)
+
+	f := &faq{cfg: Config{Threshold: 0.6, MaxMisses: 1}, store: slackbot.NewMemoryStore(), fixed: entries}
+	e, err := f.add("How do I get VPN access?", "Ask IT.")
+	assert.NoError(err)
+	assert.Equal("1", e.ID)
+
+	best, score := f.best(context.Background(), "where's the office")
+	assert.Equal("file-1", best.ID)
+	assert.True(score >= 0.6)
+
+	f.incr("faq:hits:1")
+	f.miss("first?")
+	f.miss("second?")
+	assert.Contains(f.stats(), "*1* answered, *2* unanswered (33% hit rate)")
+	assert.Contains(f.stats(), "• second?")
+	assert.NotContains(f.stats(), "• first?")
+
+	removed, err := f.remove("1")
+	assert.NoError(err)
+	assert.True(removed)
+	assert.Len(f.entries(), 1)
+}
+
+func TestEmbeddingScores(t *testing.T) {
+	embedder := EmbedderFunc(func(ctx context.Context, texts []string) ([][]float64, error) {
+		vectors := make([][]float64, len(texts))
+		for i, text := range texts {
+			if text == "vpn" || text == "How do I get VPN access?" {
+				vectors[i] = []float64{1, 0}
+			} else {
+				vectors[i] = []float64{0, 1}
+			}
+		}
+		return vectors, nil
+	})
+	f := &faq{cfg: Config{Embedder: embedder}, store: slackbot.NewMemoryStore(), embeddings: map[string][]float64{},
+		fixed: []Entry{{ID: "a", Question: "Where is the office?"}, {ID: "b", Question: "How do I get VPN access?"}}}
+	best, score := f.best(context.Background(), "vpn")
+	assert.Equal(t, "b", best.ID)
+	assert.Equal(t, 1.0, score)
+}
+
+func TestQuestions(t *testing.T) {
+	assert := assert.New(t)
+	var replies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		replies = append(replies, r.FormValue("text"))
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	assert.NoError(Register(bot, Config{Entries: []Entry{{ID: "vpn", Question: "How do I get VPN access?", Answer: "Ask IT."}}}))
+
+	match := func(text string) (bool, context.Context, *slackbot.RouteMatch) {
+		msg := &slack.MessageEvent{}
+		msg.Channel, msg.User, msg.Text = "D1", "U1", text
+		ctx := slackbot.AddTextToContext(slackbot.AddMessageToContext(slackbot.AddBotToContext(context.Background(), bot), msg), text)
+		var m slackbot.RouteMatch
+		matched, ctx := bot.Match(ctx, &m)
+		return matched, ctx, &m
+	}
+	for _, text := range []string{"how do I get vpn access?", "where is the coffee?", "thanks"} {
+		// matching has no side effect, only handling does
+		match(text)
+		if matched, ctx, m := match(text); matched {
+			m.Handler(ctx)
+		}
+	}
+	assert.Equal([]string{"Ask IT.", NoAnswerText}, replies)
+	f := &faq{cfg: Config{MaxMisses: 20}, store: bot.Store(), fixed: []Entry{{ID: "vpn", Question: "How do I get VPN access?"}}}
+	assert.Contains(f.stats(), "*1* answered, *1* unanswered")
+	assert.Contains(f.stats(), "• where is the coffee?")
+}
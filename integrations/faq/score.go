@@ -0,0 +1,133 @@
+package faq
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Embedder turns texts into embedding vectors, to score questions by semantic similarity
+// instead of keywords, e.g. with a hosted embeddings API.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// EmbedderFunc adapts a function to an Embedder.
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float64, error)
+
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	return f(ctx, texts)
+}
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "is": true, "are": true, "do": true, "does": true,
+	"i": true, "we": true, "you": true, "to": true, "of": true, "in": true, "on": true,
+	"for": true, "my": true, "our": true, "it": true, "can": true, "how": true, "what": true,
+	"where": true, "when": true, "who": true, "which": true, "and": true, "or": true, "be": true,
+}
+
+// tokens returns the lowercase words of the text, without stopwords.
+func tokens(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	result := words[:0]
+	for _, w := range words {
+		if !stopwords[w] {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// similar scores how close two words are: 1 when equal, less for typos and inflections.
+func similar(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if len(a) < 4 || len(b) < 4 {
+		return 0
+	}
+	if strings.HasPrefix(a, b) || strings.HasPrefix(b, a) {
+		return 0.8
+	}
+	if levenshtein(a, b) == 1 {
+		return 0.8
+	}
+	return 0
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// keywordScore scores a question against an entry between 0 and 1 by the words they share.
+// Keywords of the entry count as matches without lowering the score when absent.
+func keywordScore(question string, e Entry) float64 {
+	asked := tokens(question)
+	known := tokens(e.Question)
+	if len(asked) == 0 || len(known) == 0 {
+		return 0
+	}
+	candidates := append(tokens(strings.Join(e.Keywords, " ")), known...)
+	matched := 0.0
+	for _, a := range asked {
+		best := 0.0
+		for _, k := range candidates {
+			best = math.Max(best, similar(a, k))
+		}
+		matched += best
+	}
+	return math.Min(1, 2*matched/float64(len(asked)+len(known)))
+}
+
+// Similarity scores how close question a is to question b, between 0 and 1, by the words
+// they share, tolerating typos.
+func Similarity(a, b string) float64 {
+	return keywordScore(a, Entry{Question: b})
+}
+
+// Cosine returns the cosine similarity of two embedding vectors.
+func Cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		if i >= len(b) {
+			break
+		}
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
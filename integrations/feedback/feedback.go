@@ -0,0 +1,222 @@
+// Package feedback relays feedback sent to a slackbot.Bot in direct messages to a channel,
+// without revealing who sent it:
+//
+//	feedback The release notes are hard to find.
+//
+// Senders are rate limited and feedback containing blocked words is refused. With
+// follow-ups enabled, replies in the thread of relayed feedback are forwarded to its
+// sender, who may answer anonymously:
+//
+//	feedback reply 3f2a9c1e Thanks, they should be linked from the wiki.
+package feedback
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// Config configures the feedback relay.
+type Config struct {
+	// ID of the channel feedback is relayed to
+	Channel string
+	// Feedback accepted per user within the window, 3 a day when zero
+	Limit  int
+	Window time.Duration
+	// Feedback containing any of the words, ignoring case, is refused
+	BlockedWords []string
+	// Forward the replies in the thread of relayed feedback to its sender
+	FollowUps bool
+	// How long follow-ups are possible, 30 days when zero
+	FollowUpTTL time.Duration
+}
+
+// thread links relayed feedback to its sender.
+type thread struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// usage counts the feedback sent by a user within the window.
+type usage struct {
+	Count int       `json:"count"`
+	Since time.Time `json:"since"`
+}
+
+type relay struct {
+	cfg     Config
+	store   slackbot.Store
+	blocked slackbot.Classifier
+	*integration.Module
+	// how the users are answered, replaced in tests
+	dm func(user, text string) error
+}
+
+// Register adds the feedback commands to the bot.
+func Register(bot *slackbot.Bot, cfg Config) error {
+	if cfg.Channel == "" {
+		return fmt.Errorf("feedback: no channel configured")
+	}
+	if cfg.Limit == 0 {
+		cfg.Limit = 3
+	}
+	if cfg.Window == 0 {
+		cfg.Window = 24 * time.Hour
+	}
+	if cfg.FollowUpTTL == 0 {
+		cfg.FollowUpTTL = 30 * 24 * time.Hour
+	}
+	r := &relay{cfg: cfg, store: bot.Store(), Module: integration.NewModule(bot), dm: func(user, text string) error {
+		return bot.DM(user, text).Err
+	}}
+	if len(cfg.BlockedWords) > 0 {
+		r.blocked = slackbot.WordlistClassifier(cfg.BlockedWords...)
+	}
+
+	replyRe := regexp.MustCompile(`(?is)^feedback reply (\S+) (.+)$`)
+	bot.Hear(replyRe.String()).Messages(slackbot.DirectMessage).Usage("feedback reply <id> <text>").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		args := replyRe.FindStringSubmatch(slackbot.TextFromContext(ctx))
+		bot.Reply(evt, r.answer(ctx, evt.User, args[1], args[2]))
+	})
+	sendRe := regexp.MustCompile(`(?is)^feedback (.+)$`)
+	bot.Hear(sendRe.String()).Messages(slackbot.DirectMessage).Usage("feedback <text>").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		text := sendRe.FindStringSubmatch(slackbot.TextFromContext(ctx))[1]
+		bot.Reply(evt, r.submit(ctx, evt.User, text))
+	})
+	if cfg.FollowUps {
+		bot.OnEvent("message", func(ctx context.Context, bot *slackbot.Bot, evt interface{}) {
+			msg, ok := evt.(*slack.MessageEvent)
+			if !ok || msg.Channel != cfg.Channel || msg.ThreadTimestamp == "" || msg.BotID != "" {
+				return
+			}
+			r.followUp(msg.ThreadTimestamp, msg.Text)
+		})
+	}
+	return nil
+}
+
+// submit relays the feedback of the user and returns the reply to the user.
+func (r *relay) submit(ctx context.Context, user, text string) string {
+	text = strings.TrimSpace(text)
+	if r.blocked != nil {
+		if verdict, err := r.blocked.Classify(ctx, text); err == nil && verdict.Flagged {
+			return "Your feedback was not shared, please rephrase it respectfully."
+		}
+	}
+	if !r.allow(user) {
+		return fmt.Sprintf("You shared feedback %d times recently, please try again later.", r.cfg.Limit)
+	}
+
+	id := newID()
+	msg := fmt.Sprintf("*Anonymous feedback* `%s`\n>%s", id, strings.ReplaceAll(text, "\n", "\n>"))
+	ts, err := r.Send(&slackbot.OutgoingMessage{Channel: r.cfg.Channel, Text: msg, Params: slack.PostMessageParameters{AsUser: true}})
+	if err != nil {
+		fmt.Printf("Error relaying feedback: %s\n", err)
+		return "Your feedback could not be shared, please try again later."
+	}
+	if !r.cfg.FollowUps {
+		return "Thanks, your feedback was shared anonymously."
+	}
+	if err := r.link(id, thread{User: user, TS: ts}); err != nil {
+		fmt.Printf("Error saving feedback thread: %s\n", err)
+	}
+	return fmt.Sprintf("Thanks, your feedback `%s` was shared anonymously. Replies will be forwarded to you, "+
+		"answer them with `feedback reply %s <text>`.", id, id)
+}
+
+// allow counts the feedback of the user, returning false when over the limit.
+func (r *relay) allow(user string) bool {
+	key := "feedback:usage:" + user
+	u := usage{Since: time.Now()}
+	if data, found, err := r.store.Get(key); err == nil && found {
+		var stored usage
+		if json.Unmarshal(data, &stored) == nil && time.Since(stored.Since) < r.cfg.Window {
+			u = stored
+		}
+	}
+	if u.Count >= r.cfg.Limit {
+		return false
+	}
+	u.Count++
+	data, _ := json.Marshal(u)
+	if err := r.store.Set(key, data, r.cfg.Window); err != nil {
+		fmt.Printf("Error saving feedback usage: %s\n", err)
+	}
+	return true
+}
+
+func (r *relay) link(id string, t thread) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := r.store.Set("feedback:id:"+id, data, r.cfg.FollowUpTTL); err != nil {
+		return err
+	}
+	return r.store.Set("feedback:ts:"+t.TS, []byte(id), r.cfg.FollowUpTTL)
+}
+
+func (r *relay) thread(id string) (thread, bool) {
+	var t thread
+	data, found, err := r.store.Get("feedback:id:" + id)
+	if err != nil || !found || json.Unmarshal(data, &t) != nil {
+		return t, false
+	}
+	return t, true
+}
+
+// followUp forwards a reply in the thread of relayed feedback to its sender.
+func (r *relay) followUp(threadTS, text string) {
+	id, found, err := r.store.Get("feedback:ts:" + threadTS)
+	if err != nil || !found {
+		return
+	}
+	t, ok := r.thread(string(id))
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf("Reply to your feedback `%s`:\n>%s\nAnswer with `feedback reply %s <text>`.",
+		id, strings.ReplaceAll(text, "\n", "\n>"), id)
+	if err := r.dm(t.User, msg); err != nil {
+		fmt.Printf("Error forwarding feedback reply: %s\n", err)
+	}
+}
+
+// answer posts the anonymous answer of the user in the thread of their feedback.
+func (r *relay) answer(ctx context.Context, user, id, text string) string {
+	t, ok := r.thread(id)
+	if !ok || t.User != user {
+		return fmt.Sprintf("No feedback `%s` to answer.", id)
+	}
+	if r.blocked != nil {
+		if verdict, err := r.blocked.Classify(ctx, text); err == nil && verdict.Flagged {
+			return "Your answer was not shared, please rephrase it respectfully."
+		}
+	}
+	msg := fmt.Sprintf("*Anonymous answer*\n>%s", strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n>"))
+	_, err := r.Send(&slackbot.OutgoingMessage{
+		Channel: r.cfg.Channel,
+		Text:    msg,
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: t.TS},
+	})
+	if err != nil {
+		fmt.Printf("Error relaying feedback answer: %s\n", err)
+		return "Your answer could not be shared, please try again later."
+	}
+	return "Your answer was shared anonymously."
+}
+
+func newID() string {
+	buf := make([]byte, 4)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
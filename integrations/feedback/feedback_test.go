@@ -0,0 +1,64 @@
+package feedback
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelay(t *testing.T) {
+	assert := assert.New(t)
+	var sent []*slackbot.OutgoingMessage
+	dms := map[string][]string{}
+	r := &relay{
+		cfg:     Config{Channel: "C1", Limit: 2, Window: time.Hour, FollowUps: true, FollowUpTTL: time.Hour},
+		store:   slackbot.NewMemoryStore(),
+		blocked: slackbot.WordlistClassifier("idiot"),
+		Module: &integration.Module{
+			Send: func(msg *slackbot.OutgoingMessage) (string, error) {
+				sent = append(sent, msg)
+				return "100.1", nil
+			},
+		},
+		dm: func(user, text string) error {
+			dms[user] = append(dms[user], text)
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	assert.Contains(r.submit(ctx, "U1", "The boss is an IDIOT"), "not shared")
+	assert.Len(sent, 0)
+
+	reply := r.submit(ctx, "U1", "Stand-ups\nare too long")
+	assert.Contains(reply, "shared anonymously")
+	assert.Len(sent, 1)
+	assert.Equal("C1", sent[0].Channel)
+	assert.Contains(sent[0].Text, ">Stand-ups\n>are too long")
+	assert.NotContains(sent[0].Text, "U1")
+	id := strings.Trim(strings.Fields(sent[0].Text)[2], "`")
+
+	r.followUp("100.1", "Which ones?")
+	assert.Len(dms["U1"], 1)
+	assert.Contains(dms["U1"][0], ">Which ones?")
+	r.followUp("999.9", "Unrelated")
+	assert.Len(dms["U1"], 1)
+
+	assert.Contains(r.answer(ctx, "U2", id, "All of them"), "No feedback")
+	assert.Equal("Your answer was shared anonymously.", r.answer(ctx, "U1", id, "All of them"))
+	assert.Equal("100.1", sent[1].Params.ThreadTimestamp)
+
+	assert.Contains(r.submit(ctx, "U1", "Second"), "shared anonymously")
+	assert.Contains(r.submit(ctx, "U1", "Third"), "try again later")
+	assert.Contains(r.submit(ctx, "U2", "Third"), "shared anonymously")
+}
+
+func TestRegister(t *testing.T) {
+	assert.Error(t, Register(slackbot.New(""), Config{}))
+	assert.NoError(t, Register(slackbot.New(""), Config{Channel: "C1"}))
+}
@@ -0,0 +1,48 @@
+// Package integration holds the scaffolding shared by the integrations.
+package integration
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+)
+
+// Module is embedded by the integrations that keep state in the Store and
+// post to Slack.
+type Module struct {
+	// serializes the updates of the stored state
+	sync.Mutex
+	// how messages are posted and the time is read, replaced in tests
+	Send func(msg *slackbot.OutgoingMessage) (string, error)
+	Now  func() time.Time
+}
+
+// NewModule returns a Module posting with the bot.
+func NewModule(bot *slackbot.Bot) *Module {
+	return &Module{Send: bot.Send, Now: time.Now}
+}
+
+// Watched reports whether an integration configured with the channel IDs
+// watches the channel: all of them but the direct messages when empty.
+func Watched(channels []string, channel string) bool {
+	if len(channels) == 0 {
+		// direct messages are private
+		return !strings.HasPrefix(channel, "D")
+	}
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// OrDefault returns value, or def when it is empty.
+func OrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,19 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatched(t *testing.T) {
+	assert.True(t, Watched(nil, "C1"))
+	assert.False(t, Watched(nil, "D1"))
+	assert.False(t, Watched([]string{"C2"}, "C1"))
+	assert.True(t, Watched([]string{"C2"}, "C2"))
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.Equal(t, "default", OrDefault("", "default"))
+	assert.Equal(t, "prod", OrDefault("prod", "default"))
+}
@@ -0,0 +1,262 @@
+// Package kanban keeps a task board per channel in a slackbot.Bot. Items are added with
+// commands and the board is a pinned message, updated in place, whose buttons claim items
+// and mark them done:
+//
+//	@bot todo add Renew the TLS certificates
+//	@bot todo claim 3
+//	@bot todo done 3
+//	@bot todo remove 3
+//	@bot todo board
+//
+// Button clicks are received through the InteractionHandler of the bot.
+package kanban
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// Status is the column of an item.
+type Status string
+
+const (
+	Todo  Status = "todo"
+	Doing Status = "doing"
+	Done  Status = "done"
+)
+
+// Item is a task of a board.
+type Item struct {
+	ID      int    `json:"id"`
+	Text    string `json:"text"`
+	Creator string `json:"creator"`
+	Owner   string `json:"owner,omitempty"`
+	Status  Status `json:"status"`
+}
+
+// Board is the task board of a channel.
+type Board struct {
+	// Timestamp of the pinned message showing the board
+	TS     string `json:"ts,omitempty"`
+	NextID int    `json:"next_id"`
+	Items  []Item `json:"items"`
+}
+
+// Config configures the boards.
+type Config struct {
+	// Done items shown on the board, the most recent first, 5 when zero
+	ShowDone int
+}
+
+const (
+	claimAction = "kanban_claim"
+	doneAction  = "kanban_done"
+)
+
+type kanban struct {
+	cfg   Config
+	bot   *slackbot.Bot
+	store slackbot.Store
+	*integration.Module
+	// how the board is pinned, replaced in tests
+	pin func(channel, ts string) error
+}
+
+// Register adds the todo commands and the board buttons to the bot.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.ShowDone == 0 {
+		cfg.ShowDone = 5
+	}
+	k := &kanban{cfg: cfg, bot: bot, store: bot.Store(), Module: integration.NewModule(bot), pin: func(channel, ts string) error {
+		return bot.Client.AddPin(channel, slack.NewRefToMessage(channel, ts))
+	}}
+	bot.RequireScopes("pins:write")
+
+	addRe := regexp.MustCompile(`(?is)^todo add (.+)$`)
+	bot.Hear(addRe.String()).Usage("todo add <text>").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		text := strings.TrimSpace(addRe.FindStringSubmatch(slackbot.TextFromContext(ctx))[1])
+		item, err := k.update(evt.Channel, func(b *Board) (string, error) {
+			b.NextID++
+			b.Items = append(b.Items, Item{ID: b.NextID, Text: text, Creator: evt.User, Status: Todo})
+			return fmt.Sprintf("Added #%d.", b.NextID), nil
+		})
+		k.reply(evt, item, err)
+	})
+	itemRe := regexp.MustCompile(`(?i)^todo (claim|done|remove) #?(\d+)$`)
+	bot.Hear(itemRe.String()).Usage("todo claim|done|remove <id>").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		args := itemRe.FindStringSubmatch(slackbot.TextFromContext(ctx))
+		id, _ := strconv.Atoi(args[2])
+		reply, err := k.update(evt.Channel, func(b *Board) (string, error) {
+			return apply(b, strings.ToLower(args[1]), id, evt.User)
+		})
+		k.reply(evt, reply, err)
+	})
+	bot.Hear(`(?i)^todo board$`).Usage("todo board").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		// post the board again, e.g. after the pinned message was deleted
+		_, err := k.update(evt.Channel, func(b *Board) (string, error) {
+			b.TS = ""
+			return "", nil
+		})
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not post the board: %s", err))
+		}
+	})
+
+	onClick := func(action string) slackbot.ActionHandler {
+		return func(ctx context.Context, bot *slackbot.Bot, callback *slack.InteractionCallback, a *slack.BlockAction) {
+			id, _ := strconv.Atoi(a.Value)
+			_, err := k.update(callback.Channel.ID, func(b *Board) (string, error) {
+				return apply(b, action, id, callback.User.ID)
+			})
+			if err != nil {
+				fmt.Printf("Error updating board: %s\n", err)
+			}
+		}
+	}
+	bot.OnAction(claimAction, onClick("claim"))
+	bot.OnAction(doneAction, onClick("done"))
+}
+
+func (k *kanban) reply(evt *slack.MessageEvent, text string, err error) {
+	if err != nil {
+		k.bot.Reply(evt, err.Error())
+		return
+	}
+	k.bot.Reply(evt, text)
+}
+
+// apply claims, completes or removes the item on behalf of the user.
+func apply(b *Board, action string, id int, user string) (string, error) {
+	for i := range b.Items {
+		item := &b.Items[i]
+		if item.ID != id {
+			continue
+		}
+		switch action {
+		case "claim":
+			if item.Status == Done {
+				return "", fmt.Errorf("#%d is done", id)
+			}
+			item.Owner, item.Status = user, Doing
+			return fmt.Sprintf("<@%s> is on #%d.", user, id), nil
+		case "done":
+			item.Status = Done
+			if item.Owner == "" {
+				item.Owner = user
+			}
+			return fmt.Sprintf("#%d is done.", id), nil
+		case "remove":
+			b.Items = append(b.Items[:i], b.Items[i+1:]...)
+			return fmt.Sprintf("Removed #%d.", id), nil
+		}
+	}
+	return "", fmt.Errorf("No item #%d on this board.", id)
+}
+
+// update changes the board of the channel, saves it and renders it.
+func (k *kanban) update(channel string, change func(b *Board) (string, error)) (string, error) {
+	k.Lock()
+	defer k.Unlock()
+	b, err := k.load(channel)
+	if err != nil {
+		return "", err
+	}
+	reply, err := change(b)
+	if err != nil {
+		return "", err
+	}
+	if err := k.render(channel, b); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	return reply, k.store.Set(boardKey(channel), data, 0)
+}
+
+func (k *kanban) load(channel string) (*Board, error) {
+	b := &Board{}
+	data, found, err := k.store.Get(boardKey(channel))
+	if err != nil || !found {
+		return b, err
+	}
+	return b, json.Unmarshal(data, b)
+}
+
+// render edits the board message, or posts and pins it if there is none yet.
+func (k *kanban) render(channel string, b *Board) error {
+	msg := &slackbot.OutgoingMessage{Channel: channel, Timestamp: b.TS, Text: "Task board", Blocks: k.blocks(b)}
+	if b.TS != "" {
+		if _, err := k.Send(msg); err == nil {
+			return nil
+		}
+		// the board message is gone, post it again
+		msg.Timestamp = ""
+	}
+	msg.Params = slack.PostMessageParameters{AsUser: true}
+	ts, err := k.Send(msg)
+	if err != nil {
+		return err
+	}
+	b.TS = ts
+	if err := k.pin(channel, ts); err != nil {
+		fmt.Printf("Error pinning board: %s\n", err)
+	}
+	return nil
+}
+
+func (k *kanban) blocks(b *Board) []slack.Block {
+	section := func(text string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+	}
+	button := func(action, label string, id int) *slack.ButtonBlockElement {
+		return slack.NewButtonBlockElement(action, strconv.Itoa(id), slack.NewTextBlockObject(slack.PlainTextType, label, false, false))
+	}
+	blocks := []slack.Block{section("*Task board*")}
+	for _, column := range []struct {
+		status Status
+		title  string
+	}{{Todo, "To do"}, {Doing, "In progress"}} {
+		blocks = append(blocks, slack.NewDividerBlock(), section("*"+column.title+"*"))
+		empty := true
+		for _, item := range b.Items {
+			if item.Status != column.status {
+				continue
+			}
+			empty = false
+			text := fmt.Sprintf("`#%d` %s", item.ID, item.Text)
+			if item.Owner != "" {
+				text += fmt.Sprintf(" — <@%s>", item.Owner)
+			}
+			blocks = append(blocks, section(text), slack.NewActionBlock("",
+				button(claimAction, "Claim", item.ID), button(doneAction, "Done", item.ID)))
+		}
+		if empty {
+			blocks = append(blocks, section("_Nothing_"))
+		}
+	}
+
+	var done []string
+	for i := len(b.Items) - 1; i >= 0 && len(done) < k.cfg.ShowDone; i-- {
+		if item := b.Items[i]; item.Status == Done {
+			done = append(done, fmt.Sprintf("~#%d %s~", item.ID, item.Text))
+		}
+	}
+	if len(done) > 0 {
+		blocks = append(blocks, slack.NewDividerBlock(), section("*Done*\n"+strings.Join(done, "\n")))
+	}
+	return blocks
+}
+
+func boardKey(channel string) string {
+	return "kanban:" + channel
+}
@@ -0,0 +1,63 @@
+package kanban
+
+import (
+	"errors"
+	"testing"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoard(t *testing.T) {
+	assert := assert.New(t)
+	var sent []*slackbot.OutgoingMessage
+	var pinned []string
+	failEdits := false
+	k := &kanban{
+		cfg:   Config{ShowDone: 5},
+		store: slackbot.NewMemoryStore(),
+		Module: &integration.Module{
+			Send: func(msg *slackbot.OutgoingMessage) (string, error) {
+				sent = append(sent, msg)
+				if msg.Timestamp != "" && failEdits {
+					return "", errors.New("message_not_found")
+				}
+				return "ts" + string(rune('0'+len(sent))), nil
+			},
+		},
+		pin: func(channel, ts string) error {
+			pinned = append(pinned, ts)
+			return nil
+		},
+	}
+
+	reply, err := k.update("C1", func(b *Board) (string, error) {
+		b.NextID++
+		b.Items = append(b.Items, Item{ID: b.NextID, Text: "Renew certificates", Creator: "U1", Status: Todo})
+		return "Added.", nil
+	})
+	assert.NoError(err)
+	assert.Equal("Added.", reply)
+	assert.Equal([]string{"ts1"}, pinned)
+
+	reply, err = k.update("C1", func(b *Board) (string, error) { return apply(b, "claim", 1, "U2") })
+	assert.NoError(err)
+	assert.Equal("<@U2> is on #1.", reply)
+	assert.Equal("ts1", sent[1].Timestamp)
+
+	_, err = k.update("C1", func(b *Board) (string, error) { return apply(b, "done", 7, "U2") })
+	assert.EqualError(err, "No item #7 on this board.")
+
+	failEdits = true
+	_, err = k.update("C1", func(b *Board) (string, error) { return apply(b, "done", 1, "U2") })
+	assert.NoError(err)
+	assert.Equal([]string{"ts1", "ts4"}, pinned)
+
+	b, err := k.load("C1")
+	assert.NoError(err)
+	assert.Equal("ts4", b.TS)
+	assert.Equal(Done, b.Items[0].Status)
+	assert.Equal("U2", b.Items[0].Owner)
+	assert.Len(k.blocks(b), 9)
+}
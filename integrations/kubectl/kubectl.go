@@ -0,0 +1,136 @@
+// Package kubectl adds Kubernetes ChatOps commands to a slackbot.Bot:
+//
+//	@bot pods in <namespace>
+//	@bot rollout restart <deployment> [in <namespace>]
+//
+// Commands are authorized by mapping Slack user groups to the verbs their members may use.
+// The cluster is accessed through the Cluster interface, typically implemented with
+// client-go:
+//
+//	func (c *clientGoCluster) RolloutRestart(ctx context.Context, ns, name string) error {
+//		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, time.Now().Format(time.RFC3339))
+//		_, err := c.clientset.AppsV1().Deployments(ns).Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+//		return err
+//	}
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/lazappa/go-slackbot/integrations/internal/integration"
+	"github.com/slack-go/slack"
+)
+
+// Verbs of the commands, mapped to user groups in Config.Groups.
+const (
+	VerbGet     = "get"
+	VerbRestart = "restart"
+)
+
+// Pod is the status of a pod.
+type Pod struct {
+	Name     string
+	Phase    string
+	Ready    bool
+	Restarts int
+	Created  time.Time
+}
+
+// Cluster gives access to a Kubernetes cluster.
+type Cluster interface {
+	Pods(ctx context.Context, namespace string) ([]Pod, error)
+	RolloutRestart(ctx context.Context, namespace, deployment string) error
+}
+
+// Config configures the Kubernetes commands.
+type Config struct {
+	Cluster Cluster
+	// Verbs allowed to the members of each Slack user group, keyed by user group ID
+	Groups map[string][]string
+	// Namespace of the commands not naming one, "default" if empty
+	DefaultNamespace string
+}
+
+// ForbiddenText is the reply to users lacking the verb of a command.
+var ForbiddenText = "You are not allowed to do that."
+
+const (
+	podsRegexp    = `(?i)^pods(?: in ([a-z0-9-]+))?$`
+	restartRegexp = `(?i)^rollout restart ([a-z0-9.-]+)(?: in ([a-z0-9-]+))?$`
+)
+
+// Register adds the Kubernetes commands to the bot.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.DefaultNamespace == "" {
+		cfg.DefaultNamespace = "default"
+	}
+	bot.RequireScopes("usergroups:read")
+
+	pods := regexp.MustCompile(podsRegexp)
+	bot.Hear(podsRegexp).Usage("pods in <namespace>").Allow(allowlist(cfg.Groups, VerbGet), ForbiddenText).
+		MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+			ns := integration.OrDefault(pods.FindStringSubmatch(slackbot.TextFromContext(ctx))[1], cfg.DefaultNamespace)
+			list, err := cfg.Cluster.Pods(ctx, ns)
+			if err != nil {
+				bot.Reply(evt, fmt.Sprintf("Could not list the pods of `%s`: %s", ns, err))
+				return
+			}
+			if len(list) == 0 {
+				bot.Reply(evt, fmt.Sprintf("No pods in `%s`.", ns))
+				return
+			}
+			rows := make([][]string, len(list))
+			for i, p := range list {
+				rows[i] = []string{p.Name, p.Phase, strconv.FormatBool(p.Ready), strconv.Itoa(p.Restarts), age(p.Created)}
+			}
+			_ = bot.ReplyTable(evt, []string{"NAME", "STATUS", "READY", "RESTARTS", "AGE"}, rows, slackbot.TableOptions{Title: "Pods in " + ns})
+		})
+
+	restart := regexp.MustCompile(restartRegexp)
+	bot.Hear(restartRegexp).Usage("rollout restart <deployment> in <namespace>").Allow(allowlist(cfg.Groups, VerbRestart), ForbiddenText).
+		MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+			args := restart.FindStringSubmatch(slackbot.TextFromContext(ctx))
+			deployment, ns := args[1], integration.OrDefault(args[2], cfg.DefaultNamespace)
+			if err := cfg.Cluster.RolloutRestart(ctx, ns, deployment); err != nil {
+				bot.Reply(evt, fmt.Sprintf("Could not restart `%s/%s`: %s", ns, deployment, err))
+				return
+			}
+			bot.Reply(evt, fmt.Sprintf("Restarting `%s/%s`.", ns, deployment))
+		})
+}
+
+// allowlist returns the user groups allowed the verb.
+func allowlist(groups map[string][]string, verb string) slackbot.Allowlist {
+	var list slackbot.Allowlist
+	for group, verbs := range groups {
+		for _, v := range verbs {
+			if v == verb || v == "*" {
+				list.Groups = append(list.Groups, group)
+				break
+			}
+		}
+	}
+	sort.Strings(list.Groups)
+	return list
+}
+
+// age formats the time since t like kubectl.
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
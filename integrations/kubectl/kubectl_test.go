@@ -0,0 +1,69 @@
+package kubectl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRBAC(t *testing.T) {
+	assert := assert.New(t)
+	var replies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/usergroups.users.list":
+			members := map[string]string{"SOPS": `["U1"]`, "SDEVS": `["U1", "U2"]`}[r.FormValue("usergroup")]
+			w.Write([]byte(`{"ok": true, "users": ` + members + `}`))
+		case "/chat.postMessage":
+			replies = append(replies, r.FormValue("channel")+" "+r.FormValue("text"))
+			w.Write([]byte(`{"ok": true}`))
+		}
+	}))
+	defer server.Close()
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	groups := map[string][]string{"SOPS": {"*"}, "SDEVS": {VerbGet}}
+	assert.Equal(slackbot.Allowlist{Groups: []string{"SDEVS", "SOPS"}}, allowlist(groups, VerbGet))
+	assert.Equal(slackbot.Allowlist{Groups: []string{"SOPS"}}, allowlist(groups, VerbRestart))
+
+	var ran []string
+	for _, verb := range []string{VerbGet, VerbRestart} {
+		verb := verb
+		bot.Hear(verb).Allow(allowlist(groups, verb), ForbiddenText).Handler(func(ctx context.Context) {
+			ran = append(ran, slackbot.MessageFromContext(ctx).User+" "+verb)
+		})
+	}
+	for _, c := range []struct{ user, verb string }{{"U1", VerbRestart}, {"U2", VerbGet}, {"U2", VerbRestart}, {"U3", VerbGet}} {
+		msg := &slack.MessageEvent{}
+		msg.Channel, msg.User, msg.Text = "C1", c.user, c.verb
+		ctx := slackbot.AddTextToContext(slackbot.AddMessageToContext(slackbot.AddBotToContext(context.Background(), bot), msg), c.verb)
+		var match slackbot.RouteMatch
+		if matched, ctx := bot.Match(ctx, &match); matched {
+			match.Handler(ctx)
+		}
+	}
+	assert.Equal([]string{"U1 restart", "U2 get"}, ran)
+	assert.Equal([]string{"C1 " + ForbiddenText, "C1 " + ForbiddenText}, replies)
+}
+
+func TestCommands(t *testing.T) {
+	assert := assert.New(t)
+	pods := regexp.MustCompile(podsRegexp)
+	assert.Equal([]string{"pods in kube-system", "kube-system"}, pods.FindStringSubmatch("pods in kube-system"))
+	assert.Equal([]string{"Pods", ""}, pods.FindStringSubmatch("Pods"))
+	restart := regexp.MustCompile(restartRegexp)
+	assert.Equal([]string{"rollout restart web.v2 in prod", "web.v2", "prod"}, restart.FindStringSubmatch("rollout restart web.v2 in prod"))
+	assert.Nil(restart.FindStringSubmatch("rollout restart"))
+
+	assert.Equal("30s", age(time.Now().Add(-30*time.Second)))
+	assert.Equal("5h", age(time.Now().Add(-5*time.Hour-time.Minute)))
+	assert.Equal("3d", age(time.Now().Add(-73*time.Hour)))
+}
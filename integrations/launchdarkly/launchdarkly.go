@@ -0,0 +1,132 @@
+// Package launchdarkly provides a slackbot.FlagProvider evaluating feature flags with
+// LaunchDarkly.
+//
+//	bot.SetFlagProvider(launchdarkly.New(launchdarkly.Config{ClientSideID: os.Getenv("LD_CLIENT_SIDE_ID")}))
+//
+// Flags are evaluated by the client-side evaluation endpoint of LaunchDarkly, the SDK is not
+// required, so only the flags made available to client-side SDKs are seen. The Slack user
+// is the key of the evaluated context, with the workspace and channel as attributes to
+// target.
+package launchdarkly
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+)
+
+// Config configures the provider.
+type Config struct {
+	// Client-side ID of the LaunchDarkly environment
+	ClientSideID string
+	// Base URL of the evaluation endpoint, https://clientsdk.launchdarkly.com when empty
+	BaseURL string
+	// How long the flags of a context are cached, a minute when zero
+	TTL time.Duration
+	// HTTP client, a client with a 5 seconds timeout when nil
+	Client *http.Client
+}
+
+// Provider is a slackbot.FlagProvider evaluating flags with LaunchDarkly. Flags are disabled
+// when they cannot be evaluated.
+type Provider struct {
+	cfg Config
+
+	mu    sync.Mutex
+	cache map[slackbot.FlagTarget]cachedFlags
+}
+
+type cachedFlags struct {
+	flags   map[string]bool
+	expires time.Time
+}
+
+// New returns a Provider for the environment of the config.
+func New(cfg Config) *Provider {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://clientsdk.launchdarkly.com"
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = time.Minute
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &Provider{cfg: cfg, cache: make(map[slackbot.FlagTarget]cachedFlags)}
+}
+
+// Enabled returns true if the boolean flag evaluates to true for the target.
+func (p *Provider) Enabled(ctx context.Context, flag string, target slackbot.FlagTarget) bool {
+	p.mu.Lock()
+	cached, ok := p.cache[target]
+	p.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.flags[flag]
+	}
+
+	flags, err := p.evaluate(ctx, target)
+	if err != nil {
+		fmt.Printf("Error evaluating feature flags: %s\n", err)
+		return false
+	}
+	p.mu.Lock()
+	p.cache[target] = cachedFlags{flags: flags, expires: time.Now().Add(p.cfg.TTL)}
+	p.mu.Unlock()
+	return flags[flag]
+}
+
+// evalContext is the LaunchDarkly context of a target.
+type evalContext struct {
+	Kind      string `json:"kind"`
+	Key       string `json:"key"`
+	Anonymous bool   `json:"anonymous,omitempty"`
+	Team      string `json:"team,omitempty"`
+	Channel   string `json:"channel,omitempty"`
+}
+
+// evaluate returns the values of the boolean flags for the target.
+func (p *Provider) evaluate(ctx context.Context, target slackbot.FlagTarget) (map[string]bool, error) {
+	c := evalContext{Kind: "user", Key: target.User, Team: target.Team, Channel: target.Channel}
+	if c.Key == "" {
+		c.Key, c.Anonymous = "slackbot", true
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/sdk/evalx/%s/contexts/%s", strings.TrimSuffix(p.cfg.BaseURL, "/"), p.cfg.ClientSideID,
+		base64.RawURLEncoding.EncodeToString(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("launchdarkly: %s", resp.Status)
+	}
+
+	var evaluations map[string]struct {
+		Value interface{} `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&evaluations); err != nil {
+		return nil, err
+	}
+	flags := make(map[string]bool, len(evaluations))
+	for key, e := range evaluations {
+		if on, ok := e.Value.(bool); ok {
+			flags[key] = on
+		}
+	}
+	return flags, nil
+}
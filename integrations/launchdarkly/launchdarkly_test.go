@@ -0,0 +1,53 @@
+package launchdarkly
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider(t *testing.T) {
+	assert := assert.New(t)
+	var contexts []evalContext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded := strings.TrimPrefix(r.URL.Path, "/sdk/evalx/env-id/contexts/")
+		if encoded == r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := base64.RawURLEncoding.DecodeString(encoded)
+		assert.NoError(err)
+		var c evalContext
+		assert.NoError(json.Unmarshal(data, &c))
+		contexts = append(contexts, c)
+		w.Header().Set("Content-Type", "application/json")
+		if c.Channel == "C1" {
+			w.Write([]byte(`{"new-deploy-flow": {"value": true, "version": 3}, "theme": {"value": "dark"}}`))
+			return
+		}
+		w.Write([]byte(`{"new-deploy-flow": {"value": false}}`))
+	}))
+	defer server.Close()
+	p := New(Config{ClientSideID: "env-id", BaseURL: server.URL + "/"})
+
+	target := slackbot.FlagTarget{Team: "T1", Channel: "C1", User: "U1"}
+	assert.True(p.Enabled(context.Background(), "new-deploy-flow", target))
+	// flags are cached per target
+	assert.False(p.Enabled(context.Background(), "theme", target))
+	assert.False(p.Enabled(context.Background(), "new-deploy-flow", slackbot.FlagTarget{Team: "T1", Channel: "C2"}))
+	assert.Equal([]evalContext{
+		{Kind: "user", Key: "U1", Team: "T1", Channel: "C1"},
+		{Kind: "user", Key: "slackbot", Anonymous: true, Team: "T1", Channel: "C2"},
+	}, contexts)
+
+	// flags that cannot be evaluated are disabled
+	p = New(Config{ClientSideID: "unknown", BaseURL: server.URL + "/missing"})
+	assert.False(p.Enabled(context.Background(), "new-deploy-flow", target))
+}
@@ -0,0 +1,146 @@
+// Package sentry reports the failures of slackbot handlers to Sentry.
+//
+//	reporter, err := sentry.New(sentry.Config{DSN: os.Getenv("SENTRY_DSN"), Environment: "prod"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	bot.SetErrorReporter(reporter).RecoverPanics()
+//
+// Events are sent to the store endpoint of the Sentry HTTP API, the Sentry SDK is not required.
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+)
+
+// Config configures the reporter.
+type Config struct {
+	// DSN of the Sentry project, e.g. https://key@o0.ingest.sentry.io/42
+	DSN string
+	// Environment and release tagged on events
+	Environment string
+	Release     string
+	// HTTP client, a client with a 5 seconds timeout when nil
+	Client *http.Client
+}
+
+// Reporter is a slackbot.ErrorReporter sending events to Sentry.
+type Reporter struct {
+	cfg      Config
+	endpoint string
+	auth     string
+}
+
+// New returns a Reporter for the DSN of the config.
+func New(cfg Config) (*Reporter, error) {
+	u, err := url.Parse(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	project := strings.TrimPrefix(u.Path, "/")
+	if u.User == nil || project == "" {
+		return nil, errors.New("sentry: invalid DSN")
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	key := u.User.Username()
+	auth := "Sentry sentry_version=7, sentry_client=go-slackbot/1.0, sentry_key=" + key
+	if secret, ok := u.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+	return &Reporter{
+		cfg:      cfg,
+		endpoint: fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, project),
+		auth:     auth,
+	}, nil
+}
+
+// event is the subset of the Sentry event payload filled by the reporter.
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Message     string            `json:"message"`
+	Exception   []exception       `json:"exception"`
+	Tags        map[string]string `json:"tags"`
+	User        map[string]string `json:"user,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+type exception struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newEvent(cfg Config, report *slackbot.ErrorReport) *event {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	e := &event{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Level:       "error",
+		Platform:    "go",
+		Logger:      "slackbot",
+		Environment: cfg.Environment,
+		Release:     cfg.Release,
+		Transaction: report.Route,
+		Message:     report.Err.Error(),
+		Exception:   []exception{{Type: reflect.TypeOf(report.Err).String(), Value: report.Err.Error()}},
+		Tags:        map[string]string{"channel": report.Channel, "team": report.Team},
+		Extra:       map[string]string{"text": report.Text},
+	}
+	if report.Route != "" {
+		e.Tags["route"] = report.Route
+	}
+	if report.User != "" {
+		e.User = map[string]string{"id": report.User}
+	}
+	if report.Panic {
+		e.Level = "fatal"
+		e.Exception[0].Type = "panic"
+		e.Extra["stack"] = string(report.Stack)
+	}
+	return e
+}
+
+func (r *Reporter) ReportError(ctx context.Context, report *slackbot.ErrorReport) {
+	body, err := json.Marshal(newEvent(r.cfg, report))
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+	resp, err := r.cfg.Client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			err = errors.New(resp.Status)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error reporting to Sentry: %s\n", err)
+	}
+}
@@ -0,0 +1,41 @@
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportError(t *testing.T) {
+	assert := assert.New(t)
+	var got event
+	var auth, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("X-Sentry-Auth"), r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	reporter, err := New(Config{DSN: strings.Replace(server.URL, "://", "://key@", 1) + "/42", Environment: "test"})
+	assert.NoError(err)
+	reporter.ReportError(context.Background(), &slackbot.ErrorReport{
+		Err: errors.New("boom"), Route: "deploy", Channel: "C1", User: "U1", Text: "deploy prod",
+	})
+	assert.Equal("/api/42/store/", path)
+	assert.Contains(auth, "sentry_key=key")
+	assert.Equal("boom", got.Message)
+	assert.Equal("deploy", got.Tags["route"])
+	assert.Equal("C1", got.Tags["channel"])
+	assert.Equal("U1", got.User["id"])
+	assert.Equal("test", got.Environment)
+
+	_, err = New(Config{DSN: "https://o0.ingest.sentry.io/42"})
+	assert.Error(err)
+}
@@ -0,0 +1,174 @@
+// Package sqlquery lets allowed users run saved SQL queries from a slackbot.Bot:
+//
+//	@bot query <name> [args...]
+//	@bot queries
+//
+// Queries run against any database/sql driver, e.g. PostgreSQL or a warehouse, with a
+// timeout, and results are replied as tables, or CSV files when large.
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+)
+
+// Query is a saved query. Its arguments are given in order after its name, and passed to
+// the driver as query parameters, never interpolated in the SQL.
+type Query struct {
+	Name string
+	SQL  string
+	// Names of the arguments, shown in the usage
+	Params []string
+	// Users, and members of user groups, allowed to run the query; nobody when both empty
+	AllowedUsers  []string
+	AllowedGroups []string
+	// Timeout of the query, Config.Timeout when zero
+	Timeout time.Duration
+}
+
+// Config configures the query commands.
+type Config struct {
+	DB      *sql.DB
+	Queries []Query
+	// Default timeout of queries, 30 seconds when zero
+	Timeout time.Duration
+	// Results with more rows are uploaded as CSV, slackbot.DefaultMaxTableRows when zero
+	MaxInlineRows int
+}
+
+// ForbiddenText is the reply to users not allowed to run a query.
+var ForbiddenText = "You are not allowed to run this query."
+
+const queryRegexp = `(?i)^query (\S+)(.*)$`
+
+// Register adds the query commands to the bot.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	queries := make(map[string]Query, len(cfg.Queries))
+	for _, q := range cfg.Queries {
+		queries[strings.ToLower(q.Name)] = q
+	}
+
+	re := regexp.MustCompile(queryRegexp)
+	bot.Hear(queryRegexp).Usage("query <name> [args...]").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		args := re.FindStringSubmatch(slackbot.TextFromContext(ctx))
+		q, ok := queries[strings.ToLower(args[1])]
+		if !ok {
+			bot.Reply(evt, fmt.Sprintf("Unknown query `%s`, see `queries`.", args[1]))
+			return
+		}
+		if !allowed(bot, q, evt.User) {
+			bot.Reply(evt, ForbiddenText)
+			return
+		}
+		params := strings.Fields(args[2])
+		if len(params) != len(q.Params) {
+			bot.Reply(evt, fmt.Sprintf("Usage: `%s`", usage(q)))
+			return
+		}
+		timeout := q.Timeout
+		if timeout == 0 {
+			timeout = cfg.Timeout
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		headers, rows, err := run(ctx, cfg.DB, q.SQL, params)
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Query `%s` failed: %s", q.Name, err))
+			return
+		}
+		if len(rows) == 0 {
+			bot.Reply(evt, fmt.Sprintf("Query `%s` returned no rows.", q.Name))
+			return
+		}
+		if err := bot.ReplyTable(evt, headers, rows, slackbot.TableOptions{Title: q.Name, MaxRows: cfg.MaxInlineRows}); err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not send the results: %s", err))
+		}
+	})
+
+	bot.Hear(`(?i)^queries$`).MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+		var lines []string
+		for _, q := range queries {
+			if allowed(bot, q, evt.User) {
+				lines = append(lines, "`"+usage(q)+"`")
+			}
+		}
+		if len(lines) == 0 {
+			bot.Reply(evt, "No queries available.")
+			return
+		}
+		sort.Strings(lines)
+		bot.Reply(evt, strings.Join(lines, "\n"))
+	})
+}
+
+func usage(q Query) string {
+	u := "query " + q.Name
+	for _, p := range q.Params {
+		u += " <" + p + ">"
+	}
+	return u
+}
+
+func allowed(bot *slackbot.Bot, q Query, userID string) bool {
+	return bot.Allows(slackbot.Allowlist{Users: q.AllowedUsers, Groups: q.AllowedGroups}, userID)
+}
+
+// run executes the query and returns its columns and rows formatted as text.
+func run(ctx context.Context, db *sql.DB, query string, params []string) ([]string, [][]string, error) {
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	var result [][]string
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = formatValue(v)
+		}
+		result = append(result, row)
+	}
+	return columns, result, rows.Err()
+}
+
+// formatValue formats a value scanned by database/sql.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
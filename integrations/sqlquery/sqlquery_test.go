@@ -0,0 +1,73 @@
+package sqlquery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("NULL", formatValue(nil))
+	assert.Equal("abc", formatValue([]byte("abc")))
+	assert.Equal("42", formatValue(int64(42)))
+	assert.Equal("2021-03-01T10:00:00Z", formatValue(time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestUsage(t *testing.T) {
+	assert.Equal(t, "query signups <from> <to>", usage(Query{Name: "signups", Params: []string{"from", "to"}}))
+}
+
+func TestAllowed(t *testing.T) {
+	assert := assert.New(t)
+	var replies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/usergroups.users.list":
+			w.Write([]byte(`{"ok": true, "users": ["U2"]}`))
+		case "/chat.postMessage":
+			replies = append(replies, r.FormValue("text"))
+			w.Write([]byte(`{"ok": true}`))
+		}
+	}))
+	defer server.Close()
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	Register(bot, Config{Queries: []Query{
+		{Name: "signups", SQL: "SELECT 1", Params: []string{"from"}, AllowedUsers: []string{"U1"}},
+		{Name: "revenue", SQL: "SELECT 2", AllowedGroups: []string{"SFINANCE"}},
+		{Name: "users", SQL: "SELECT 3"},
+	}})
+	hear := func(user, text string) {
+		msg := &slack.MessageEvent{}
+		msg.Channel, msg.User, msg.Text = "C1", user, text
+		ctx := slackbot.AddTextToContext(slackbot.AddMessageToContext(slackbot.AddBotToContext(context.Background(), bot), msg), text)
+		var match slackbot.RouteMatch
+		if matched, ctx := bot.Match(ctx, &match); matched {
+			match.Handler(ctx)
+		}
+	}
+
+	// the argument count is checked once allowed, before running the query
+	hear("U1", "query signups")
+	hear("U2", "query signups")
+	hear("U2", "query users")
+	hear("U1", "queries")
+	hear("U2", "queries")
+	hear("U3", "queries")
+	assert.Equal([]string{
+		"Usage: `query signups <from>`",
+		ForbiddenText,
+		ForbiddenText,
+		"`query signups <from>`",
+		"`query revenue`",
+		"No queries available.",
+	}, replies)
+}
@@ -0,0 +1,266 @@
+// Package terraform adds an approval workflow for Terraform plans to a slackbot.Bot. CI
+// pipelines post plans to the webhook mounted on the bot HTTP handler; the bot posts a summary
+// of the changes with Approve and Reject buttons, restricted to approver user groups, and calls
+// back the apply, or reject, URL of the plan once decided.
+//
+//	curl -H "Authorization: Bearer $TOKEN" https://bot.example.com/terraform -d @- <<EOF
+//	{"id": "run-42", "workspace": "prod-network", "plan": "...", "apply_url": "https://ci.example.com/runs/42/apply"}
+//	EOF
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+)
+
+// Plan is the payload posted to the webhook.
+type Plan struct {
+	ID        string `json:"id"`
+	Workspace string `json:"workspace"`
+	// Output of terraform plan
+	Plan string `json:"plan"`
+	// Called with a Decision when the plan is approved
+	ApplyURL string `json:"apply_url"`
+	// Called with a Decision when the plan is rejected, optional
+	RejectURL string `json:"reject_url,omitempty"`
+}
+
+// Decision is the payload sent to the apply or reject URL of a plan.
+type Decision struct {
+	ID       string `json:"id"`
+	Approved bool   `json:"approved"`
+	// Slack ID of the approver
+	User string `json:"user"`
+}
+
+// Config configures the approval workflow.
+type Config struct {
+	// Channel where plans are posted
+	Channel string
+	// User groups whose members may approve or reject plans
+	ApproverGroups []string
+	// Bearer token expected by the webhook, which rejects every plan when empty
+	Token string
+	// Endpoint of the webhook, "/terraform" if empty
+	Endpoint string
+	// How long plans wait for a decision, a week when zero
+	Expiry time.Duration
+	// HTTP client calling back the apply and reject URLs, http.DefaultClient when nil
+	Client *http.Client
+}
+
+const (
+	approveAction = "terraform_approve"
+	rejectAction  = "terraform_reject"
+)
+
+// NotApproverText is shown to users clicking the buttons without being approvers.
+var NotApproverText = "Only approvers can decide on Terraform plans."
+
+// Register mounts the webhook and handles the approval buttons.
+func Register(bot *slackbot.Bot, cfg Config) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "/terraform"
+	}
+	if cfg.Expiry == 0 {
+		cfg.Expiry = 7 * 24 * time.Hour
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	w := &workflow{cfg: cfg}
+	bot.RequireScopes("chat:write", "usergroups:read")
+	bot.Mount(cfg.Endpoint, w.webhook(bot))
+	bot.OnAction(approveAction, func(ctx context.Context, bot *slackbot.Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		w.decide(bot, callback, action.Value, true)
+	})
+	bot.OnAction(rejectAction, func(ctx context.Context, bot *slackbot.Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		w.decide(bot, callback, action.Value, false)
+	})
+}
+
+// workflow posts the plans and serializes the decisions, so that a plan is only decided once.
+type workflow struct {
+	cfg Config
+	mu  sync.Mutex
+}
+
+func storeKey(id string) string {
+	return "terraform:" + id
+}
+
+func (wf *workflow) webhook(bot *slackbot.Bot) http.Handler {
+	cfg := wf.cfg
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if r.Method != http.MethodPost || cfg.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var plan Plan
+		if err := json.NewDecoder(r.Body).Decode(&plan); err != nil || plan.ID == "" || plan.ApplyURL == "" {
+			http.Error(w, "invalid plan", http.StatusBadRequest)
+			return
+		}
+		data, err := json.Marshal(plan)
+		if err == nil {
+			err = bot.Store().Set(storeKey(plan.ID), data, cfg.Expiry)
+		}
+		if err == nil {
+			_, err = bot.Send(&slackbot.OutgoingMessage{
+				Channel: cfg.Channel,
+				Text:    title(plan),
+				Blocks:  planBlocks(plan, true),
+			})
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func title(plan Plan) string {
+	return fmt.Sprintf("Terraform plan `%s` for *%s*", plan.ID, plan.Workspace)
+}
+
+// planBlocks renders the summary of a plan, with the decision buttons when pending.
+func planBlocks(plan Plan, pending bool) []slack.Block {
+	summary := Summarize(plan.Plan)
+	text := title(plan) + "\n" + summary.Totals
+	if len(summary.Changes) > 0 {
+		changes := summary.Changes
+		if len(changes) > 30 {
+			changes = append(changes[:30:30], fmt.Sprintf("… and %d more", len(summary.Changes)-30))
+		}
+		text += "\n```\n" + strings.Join(changes, "\n") + "\n```"
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+	}
+	if pending {
+		approve := slack.NewButtonBlockElement(approveAction, plan.ID, slack.NewTextBlockObject(slack.PlainTextType, "Approve", false, false))
+		approve.Style = slack.StylePrimary
+		reject := slack.NewButtonBlockElement(rejectAction, plan.ID, slack.NewTextBlockObject(slack.PlainTextType, "Reject", false, false))
+		reject.Style = slack.StyleDanger
+		blocks = append(blocks, slack.NewActionBlock("", approve, reject))
+	}
+	return blocks
+}
+
+// claim removes the pending plan from the store and returns it, or false when it expired or
+// was already claimed by another decision.
+func (wf *workflow) claim(bot *slackbot.Bot, id string) (Plan, bool) {
+	wf.mu.Lock()
+	defer wf.mu.Unlock()
+	var plan Plan
+	data, found, err := bot.Store().Get(storeKey(id))
+	if err != nil || !found {
+		return plan, false
+	}
+	if err := bot.Store().Delete(storeKey(id)); err != nil {
+		fmt.Printf("Error claiming plan %s: %s\n", id, err)
+		return plan, false
+	}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		fmt.Printf("Error decoding plan %s: %s\n", id, err)
+		return plan, false
+	}
+	return plan, true
+}
+
+// decide records the decision of an approver and calls back the plan.
+func (wf *workflow) decide(bot *slackbot.Bot, callback *slack.InteractionCallback, id string, approved bool) {
+	cfg := wf.cfg
+	if !bot.Allows(slackbot.Allowlist{Groups: cfg.ApproverGroups}, callback.User.ID) {
+		_, _ = bot.Send(&slackbot.OutgoingMessage{Channel: callback.Channel.ID, Text: NotApproverText, EphemeralUser: callback.User.ID})
+		return
+	}
+	plan, ok := wf.claim(bot, id)
+	if !ok {
+		_, _ = bot.Send(&slackbot.OutgoingMessage{Channel: callback.Channel.ID, Text: fmt.Sprintf("Plan `%s` expired or was already decided.", id), EphemeralUser: callback.User.ID})
+		return
+	}
+
+	outcome := fmt.Sprintf(":white_check_mark: Approved by <@%s>", callback.User.ID)
+	target := plan.ApplyURL
+	if !approved {
+		outcome = fmt.Sprintf(":no_entry: Rejected by <@%s>", callback.User.ID)
+		target = plan.RejectURL
+	}
+	if target != "" {
+		if err := callBack(cfg.Client, target, Decision{ID: plan.ID, Approved: approved, User: callback.User.ID}); err != nil {
+			outcome += fmt.Sprintf(", but notifying the pipeline failed: %s", err)
+		}
+	}
+	blocks := append(planBlocks(plan, false), slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, outcome, false, false)))
+	_, _ = bot.Send(&slackbot.OutgoingMessage{
+		Channel:   callback.Channel.ID,
+		Timestamp: callback.Message.Timestamp,
+		Text:      title(plan) + ": " + outcome,
+		Blocks:    blocks,
+	})
+}
+
+func callBack(client *http.Client, url string, decision Decision) error {
+	body, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// Summary is the gist of a Terraform plan.
+type Summary struct {
+	// Resources changed, e.g. "+ aws_instance.web" or "-/+ aws_db.main"
+	Changes []string
+	// e.g. "Plan: 1 to add, 0 to change, 2 to destroy."
+	Totals string
+}
+
+var (
+	changeRegexp = regexp.MustCompile(`^\s*# (\S+) (?:will be|must be) (created|destroyed|updated in-place|replaced|read during apply)`)
+	totalsRegexp = regexp.MustCompile(`^(Plan: .*|No changes\..*)$`)
+	ansiRegexp   = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+var changeSymbols = map[string]string{
+	"created":           "+",
+	"destroyed":         "-",
+	"updated in-place":  "~",
+	"replaced":          "-/+",
+	"read during apply": "<=",
+}
+
+// Summarize extracts the changed resources and the totals of the output of terraform plan.
+func Summarize(plan string) Summary {
+	var s Summary
+	for _, line := range strings.Split(ansiRegexp.ReplaceAllString(plan, ""), "\n") {
+		if m := changeRegexp.FindStringSubmatch(line); m != nil {
+			s.Changes = append(s.Changes, changeSymbols[m[2]]+" "+m[1])
+		} else if m := totalsRegexp.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			s.Totals = m[1]
+		}
+	}
+	return s
+}
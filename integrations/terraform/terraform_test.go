@@ -0,0 +1,142 @@
+package terraform
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	slackbot "github.com/lazappa/go-slackbot"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSlack records the messages sent, by API method, and makes U1 the only approver.
+type fakeSlack struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/usergroups.users.list" {
+		w.Write([]byte(`{"ok": true, "users": ["U1"]}`))
+		return
+	}
+	f.mu.Lock()
+	f.sent = append(f.sent, strings.TrimPrefix(r.URL.Path, "/")+" "+r.FormValue("text"))
+	f.mu.Unlock()
+	w.Write([]byte(`{"ok": true, "channel": "C1", "ts": "1.0"}`))
+}
+
+func newWorkflow(t *testing.T, cfg Config) (*workflow, *slackbot.Bot, *fakeSlack) {
+	slackAPI := &fakeSlack{}
+	server := httptest.NewServer(slackAPI)
+	t.Cleanup(server.Close)
+	bot := slackbot.New("")
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	cfg.Channel, cfg.ApproverGroups = "C1", []string{"SOPS"}
+	return &workflow{cfg: cfg}, bot, slackAPI
+}
+
+func TestWebhook(t *testing.T) {
+	assert := assert.New(t)
+	post := func(handler http.Handler, token, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/terraform", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	plan := `{"id": "run-42", "workspace": "prod", "plan": "Plan: 1 to add, 0 to change, 0 to destroy.", "apply_url": "https://ci/apply"}`
+
+	// without a token every plan is rejected
+	wf, bot, slackAPI := newWorkflow(t, Config{})
+	assert.Equal(http.StatusForbidden, post(wf.webhook(bot), "", plan))
+
+	wf, bot, slackAPI = newWorkflow(t, Config{Token: "secret"})
+	handler := wf.webhook(bot)
+	assert.Equal(http.StatusForbidden, post(handler, "", plan))
+	assert.Equal(http.StatusForbidden, post(handler, "wrong", plan))
+	assert.Equal(http.StatusBadRequest, post(handler, "secret", `{"id": "run-42"}`))
+	assert.Equal(http.StatusAccepted, post(handler, "secret", plan))
+	assert.Equal([]string{"chat.postMessage Terraform plan `run-42` for *prod*"}, slackAPI.sent)
+	_, found, _ := bot.Store().Get(storeKey("run-42"))
+	assert.True(found)
+}
+
+func TestDecide(t *testing.T) {
+	assert := assert.New(t)
+	var mu sync.Mutex
+	var decisions []Decision
+	ci := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var d Decision
+		_ = json.NewDecoder(r.Body).Decode(&d)
+		mu.Lock()
+		decisions = append(decisions, d)
+		mu.Unlock()
+	}))
+	defer ci.Close()
+	wf, bot, slackAPI := newWorkflow(t, Config{Token: "secret"})
+	data, _ := json.Marshal(Plan{ID: "run-42", Workspace: "prod", ApplyURL: ci.URL + "/apply"})
+	_ = bot.Store().Set(storeKey("run-42"), data, 0)
+	click := func(user string) *slack.InteractionCallback {
+		callback := &slack.InteractionCallback{}
+		callback.User.ID, callback.Channel.ID, callback.Message.Timestamp = user, "C1", "1.0"
+		return callback
+	}
+
+	wf.decide(bot, click("U2"), "run-42", true)
+	assert.Equal([]string{"chat.postEphemeral " + NotApproverText}, slackAPI.sent)
+	assert.Empty(decisions)
+
+	// concurrent clicks decide the plan once
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wf.decide(bot, click("U1"), "run-42", true)
+		}()
+	}
+	wg.Wait()
+	assert.Equal([]Decision{{ID: "run-42", Approved: true, User: "U1"}}, decisions)
+	updates, expired := 0, 0
+	for _, sent := range slackAPI.sent[1:] {
+		switch sent {
+		case "chat.update Terraform plan `run-42` for *prod*: :white_check_mark: Approved by <@U1>":
+			updates++
+		case "chat.postEphemeral Plan `run-42` expired or was already decided.":
+			expired++
+		}
+	}
+	assert.Equal(1, updates)
+	assert.Equal(4, expired)
+}
+
+func TestSummarize(t *testing.T) {
+	plan := "Terraform will perform the following actions:\n\n" +
+		"  # aws_instance.web will be created\n" +
+		"  + resource \"aws_instance\" \"web\" {\n" +
+		"  }\n\n" +
+		"  # \x1b[1maws_db_instance.main\x1b[0m must be replaced\n" +
+		"-/+ resource \"aws_db_instance\" \"main\" {\n" +
+		"  # aws_s3_bucket.logs will be updated in-place\n" +
+		"  # aws_iam_role.old will be destroyed\n\n" +
+		"Plan: 2 to add, 1 to change, 2 to destroy.\n"
+
+	assert.Equal(t, Summary{
+		Changes: []string{"+ aws_instance.web", "-/+ aws_db_instance.main", "~ aws_s3_bucket.logs", "- aws_iam_role.old"},
+		Totals:  "Plan: 2 to add, 1 to change, 2 to destroy.",
+	}, Summarize(plan))
+
+	assert.Equal(t, Summary{Totals: "No changes. Your infrastructure matches the configuration."},
+		Summarize("No changes. Your infrastructure matches the configuration.\n"))
+}
@@ -0,0 +1,110 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/slack-go/slack"
+)
+
+const INTERACTION_CONTEXT = "__INTERACTION_CONTEXT__"
+
+// ActionHandler handles a block action triggered by a user, e.g. a button click.
+type ActionHandler func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction)
+
+// InteractionFromContext returns the interaction callback being handled.
+func InteractionFromContext(ctx context.Context) *slack.InteractionCallback {
+	if result, ok := ctx.Value(INTERACTION_CONTEXT).(*slack.InteractionCallback); ok {
+		return result
+	}
+	return nil
+}
+
+// AddInteractionToContext sets the interaction callback in context and returns the newly derived context
+func AddInteractionToContext(ctx context.Context, callback *slack.InteractionCallback) context.Context {
+	return context.WithValue(ctx, INTERACTION_CONTEXT, callback)
+}
+
+// OnAction registers the handler of block actions with the given action_id.
+func (b *Bot) OnAction(actionID string, handler ActionHandler) *Bot {
+	b.interactionsMu.Lock()
+	defer b.interactionsMu.Unlock()
+	if b.actions == nil {
+		b.actions = make(map[string]ActionHandler)
+	}
+	b.actions[actionID] = handler
+	return b
+}
+
+// RemoveAction unregisters the handler of block actions with the given action_id.
+func (b *Bot) RemoveAction(actionID string) {
+	b.interactionsMu.Lock()
+	delete(b.actions, actionID)
+	b.interactionsMu.Unlock()
+}
+
+// InteractionHandler returns the HTTP handler to configure as the Interactivity Request URL
+// of the Slack app. Requests are authenticated with the app signing secret.
+func (b *Bot) InteractionHandler(signingSecret string) http.Handler {
+	return VerifySignature(signingSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var callback slack.InteractionCallback
+		if err := json.Unmarshal([]byte(r.FormValue("payload")), &callback); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// view submissions are answered synchronously, as the response may carry errors
+		if callback.Type == slack.InteractionTypeViewSubmission || callback.Type == slack.InteractionTypeViewClosed {
+			b.interactionsMu.Lock()
+			handler, ok := b.views[callback.View.CallbackID]
+			b.interactionsMu.Unlock()
+			var response *slack.ViewSubmissionResponse
+			if ok {
+				response = handler(&callback)
+			}
+			if response != nil {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(response)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		b.goRecover(func() { b.handleInteraction(&callback) })
+	}))
+}
+
+// handleInteraction dispatches an interaction callback to the registered handlers, run like
+// the handlers of messages.
+func (b *Bot) handleInteraction(callback *slack.InteractionCallback) {
+	ctx := AddInteractionToContext(AddBotToContext(context.Background(), b), callback)
+	if callback.Team.ID != "" {
+		ctx = AddTeamToContext(ctx, callback.Team.ID)
+	}
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		for _, action := range callback.ActionCallback.BlockActions {
+			b.interactionsMu.Lock()
+			handler, ok := b.actions[action.ActionID]
+			b.interactionsMu.Unlock()
+			if ok {
+				action := action
+				b.dispatch(ctx, func(ctx context.Context) { handler(ctx, b, callback, action) })
+			}
+		}
+	default:
+		fmt.Printf("Unhandled interaction: %s\n", callback.Type)
+	}
+}
+
+var actionSequence uint64
+
+// newActionID returns a unique action_id for prompts created by the bot.
+func newActionID(kind string) string {
+	return fmt.Sprintf("slackbot_%s_%d", kind, atomic.AddUint64(&actionSequence, 1))
+}
@@ -0,0 +1,87 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func blockActionsRequest(actionIDs ...string) *http.Request {
+	var actions []map[string]string
+	for _, id := range actionIDs {
+		actions = append(actions, map[string]string{"block_id": "b1", "action_id": id, "value": "v"})
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"type":    "block_actions",
+		"user":    map[string]string{"id": "U1"},
+		"channel": map[string]string{"id": "C1"},
+		"actions": actions,
+	})
+	return signedRequest("secret", "/interactivity", url.Values{"payload": {string(payload)}}.Encode())
+}
+
+func TestInteractionHandler(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	clicked := make(chan string, 2)
+	bot.OnAction("approve", func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		assert.Equal(callback, InteractionFromContext(ctx))
+		clicked <- callback.User.ID + " " + action.ActionID + " " + action.Value
+	})
+	bot.OnAction("removed", func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		clicked <- "removed"
+	})
+	bot.RemoveAction("removed")
+
+	rec := httptest.NewRecorder()
+	bot.InteractionHandler("secret").ServeHTTP(rec, blockActionsRequest("removed", "approve", "unknown"))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("U1 approve v", <-clicked)
+	select {
+	case c := <-clicked:
+		t.Errorf("unexpected action %s", c)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// unsigned requests are rejected
+	rec = httptest.NewRecorder()
+	req := blockActionsRequest("approve")
+	req.Header.Set("X-Slack-Signature", "v0=00")
+	bot.InteractionHandler("secret").ServeHTTP(rec, req)
+	assert.NotEqual(http.StatusOK, rec.Code)
+}
+
+func TestInteractionPanic(t *testing.T) {
+	assert := assert.New(t)
+	reports := make(chan *ErrorReport, 1)
+	bot := New("").RecoverPanics().SetErrorReporter(ErrorReporterFunc(func(ctx context.Context, report *ErrorReport) {
+		reports <- report
+	}))
+	bot.OnAction("crash", func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	bot.InteractionHandler("secret").ServeHTTP(rec, blockActionsRequest("crash"))
+	assert.Equal(http.StatusOK, rec.Code)
+	report := <-reports
+	assert.True(report.Panic)
+	assert.EqualError(report.Err, "panic: boom")
+
+	// without RecoverPanics, the panic is still recovered out of the request
+	bot = New("").SetErrorReporter(ErrorReporterFunc(func(ctx context.Context, report *ErrorReport) {
+		reports <- report
+	}))
+	bot.OnAction("crash", func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		panic("boom")
+	})
+	bot.InteractionHandler("secret").ServeHTTP(httptest.NewRecorder(), blockActionsRequest("crash"))
+	assert.True((<-reports).Panic)
+}
@@ -0,0 +1,579 @@
+package slackbot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const JOB_CONTEXT = "__JOB_CONTEXT__"
+
+// JobStatus is the state of a job.
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobDone      JobStatus = "done"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Job is a long task run in the background by the workers of the bot. Jobs are persisted
+// in the bot Store, and those not finished when the bot stops are resumed when it serves again.
+type Job struct {
+	ID string `json:"id"`
+	// Kind selects the JobFunc registered with Jobs.Handle running the job
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// User who requested the job, and the channel notified when it fails
+	User    string `json:"user,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	// Message requesting the job, and its thread, where the job may be cancelled
+	Message string `json:"message,omitempty"`
+	Thread  string `json:"thread,omitempty"`
+
+	Status   JobStatus `json:"status"`
+	Progress string    `json:"progress,omitempty"`
+	// Runs so far, and the most allowed, DefaultJobAttempts when 0
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"max_attempts,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// NewJob returns a job of the kind with the payload encoded as JSON, requested by the
+// sender of the message in context.
+func NewJob(ctx context.Context, kind string, payload interface{}) (*Job, error) {
+	job := &Job{Kind: kind}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		job.Payload = data
+	}
+	if evt := MessageFromContext(ctx); evt != nil {
+		job.User, job.Channel = evt.User, evt.Channel
+		job.Message, job.Thread = evt.Timestamp, evt.ThreadTimestamp
+	}
+	return job, nil
+}
+
+// Decode unmarshals the payload of the job into v.
+func (j *Job) Decode(v interface{}) error {
+	if len(j.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(j.Payload, v)
+}
+
+// JobFunc runs a job. An error, or a panic, retries the job until it ran MaxAttempts times.
+// The context is cancelled when the job is, see Jobs.Cancel and CancelReaction.
+type JobFunc func(ctx context.Context, job *Job) error
+
+const (
+	DefaultJobAttempts   = 3
+	DefaultJobWorkers    = 2
+	DefaultJobRetryDelay = 10 * time.Second
+	// How long finished jobs are kept for the status commands
+	DefaultJobRetention = 24 * time.Hour
+)
+
+// Jobs queues and runs the jobs of the bot. Its workers start when the bot serves.
+type Jobs struct {
+	bot *Bot
+
+	// Number of jobs run concurrently, delay before retrying a failed job, multiplied by the
+	// attempts made, and how long finished jobs are kept
+	Workers    int
+	RetryDelay time.Duration
+	Retention  time.Duration
+
+	mu      sync.Mutex
+	kinds   map[string]JobFunc
+	queue   []string
+	wake    chan struct{}
+	started bool
+	// Cancellation of the running jobs by ID
+	running map[string]*cancelHandle
+}
+
+func newJobs(b *Bot) *Jobs {
+	return &Jobs{
+		bot:        b,
+		Workers:    DefaultJobWorkers,
+		RetryDelay: DefaultJobRetryDelay,
+		Retention:  DefaultJobRetention,
+		kinds:      make(map[string]JobFunc),
+		wake:       make(chan struct{}, 1),
+		running:    make(map[string]*cancelHandle),
+	}
+}
+
+// Handle registers the function running the jobs of the kind.
+func (j *Jobs) Handle(kind string, fn JobFunc) *Jobs {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.kinds[kind] = fn
+	return j
+}
+
+// Enqueue persists the job and queues it for the workers, returning its ID.
+func (j *Jobs) Enqueue(job *Job) (string, error) {
+	j.mu.Lock()
+	_, ok := j.kinds[job.Kind]
+	j.mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("no handler for jobs of kind %q", job.Kind)
+	}
+	if job.ID == "" {
+		job.ID = newJobID()
+	}
+	job.Status, job.Attempts, job.Error = JobPending, 0, ""
+	job.Created = time.Now()
+	job.Updated = job.Created
+	if err := j.save(job); err != nil {
+		return "", err
+	}
+	if err := j.index(func(ids []string) []string { return append(ids, job.ID) }); err != nil {
+		return "", err
+	}
+	j.push(job.ID)
+	return job.ID, nil
+}
+
+// Get returns the job with the ID, if it was not pruned.
+func (j *Jobs) Get(id string) (*Job, bool, error) {
+	data, found, err := j.bot.Store().Get(jobKey(id))
+	if err != nil || !found {
+		return nil, false, err
+	}
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, false, err
+	}
+	return job, true, nil
+}
+
+// List returns the jobs requested by the user, or every job when user is empty, newest first.
+func (j *Jobs) List(user string) ([]*Job, error) {
+	ids, err := j.ids()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []*Job
+	for _, id := range ids {
+		job, found, err := j.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if found && (user == "" || job.User == user) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.SliceStable(jobs, func(a, b int) bool { return jobs[a].Created.After(jobs[b].Created) })
+	return jobs, nil
+}
+
+// Cancel stops the job if it runs, or marks it cancelled so that it does not run.
+func (j *Jobs) Cancel(id string) error {
+	// the status is checked and written under the lock of the workers starting and finishing
+	// jobs
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if h := j.running[id]; h != nil {
+		h.cancel()
+		return nil
+	}
+	job, found, err := j.Get(id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrJobNotFound
+	}
+	if job.Status != JobPending {
+		return fmt.Errorf("job is %s", job.Status)
+	}
+	job.Status = JobCancelled
+	job.Updated = time.Now()
+	return j.save(job)
+}
+
+// JobFromContext returns the job run by a JobFunc.
+func JobFromContext(ctx context.Context) *Job {
+	if job, ok := ctx.Value(JOB_CONTEXT).(*Job); ok {
+		return job
+	}
+	return nil
+}
+
+// ReportProgress records the progress of the job running in context, shown by `job status`.
+func ReportProgress(ctx context.Context, progress string) {
+	job := JobFromContext(ctx)
+	bot := BotFromContext(ctx)
+	if job == nil || bot == nil {
+		return
+	}
+	job.Progress = progress
+	job.Updated = time.Now()
+	if err := bot.Jobs.save(job); err != nil {
+		fmt.Printf("Error saving job %s: %s\n", job.ID, err)
+	}
+}
+
+// start resumes the unfinished jobs and runs the workers until the context is done.
+func (j *Jobs) start(ctx context.Context) {
+	j.mu.Lock()
+	if j.started {
+		j.mu.Unlock()
+		return
+	}
+	j.started = true
+	queued := make(map[string]bool)
+	for _, id := range j.queue {
+		queued[id] = true
+	}
+	j.mu.Unlock()
+
+	j.prune()
+	ids, err := j.ids()
+	if err != nil {
+		fmt.Printf("Error loading jobs: %s\n", err)
+	}
+	for _, id := range ids {
+		job, found, err := j.Get(id)
+		if err != nil || !found || queued[id] {
+			continue
+		}
+		if job.Status == JobPending || job.Status == JobRunning {
+			j.push(id)
+		}
+	}
+
+	ctx = AddBotToContext(ctx, j.bot)
+	for i := 0; i < j.Workers; i++ {
+		go j.work(ctx)
+	}
+}
+
+// QueueLength returns the number of jobs waiting for a worker.
+func (j *Jobs) QueueLength() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.queue)
+}
+
+// Running returns the number of jobs being run.
+func (j *Jobs) Running() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.running)
+}
+
+func (j *Jobs) push(id string) {
+	j.mu.Lock()
+	j.queue = append(j.queue, id)
+	j.mu.Unlock()
+	select {
+	case j.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (j *Jobs) pop() (string, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.queue) == 0 {
+		return "", false
+	}
+	id := j.queue[0]
+	j.queue = j.queue[1:]
+	if len(j.queue) > 0 {
+		// let another worker pick the next job
+		select {
+		case j.wake <- struct{}{}:
+		default:
+		}
+	}
+	return id, true
+}
+
+func (j *Jobs) work(ctx context.Context) {
+	for {
+		id, ok := j.pop()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-j.wake:
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		j.run(ctx, id)
+	}
+}
+
+func (j *Jobs) run(ctx context.Context, id string) {
+	j.mu.Lock()
+	job, found, err := j.Get(id)
+	if err != nil || !found || job.Status == JobCancelled {
+		j.mu.Unlock()
+		if err != nil {
+			fmt.Printf("Error loading job %s: %s\n", id, err)
+		}
+		return
+	}
+	fn := j.kinds[job.Kind]
+	if fn == nil {
+		j.mu.Unlock()
+		j.finish(job, context.Background(), fmt.Errorf("no handler for jobs of kind %q", job.Kind), false)
+		return
+	}
+	job.Status = JobRunning
+	job.Attempts++
+	job.Updated = time.Now()
+	if err := j.save(job); err != nil {
+		fmt.Printf("Error saving job %s: %s\n", job.ID, err)
+	}
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	h := &cancelHandle{user: job.User, ctx: jobCtx, cancel: cancel}
+	j.running[job.ID] = h
+	j.mu.Unlock()
+	j.bot.cancels.add(h, job.Channel, job.Message, job.Thread)
+
+	err = runJob(context.WithValue(jobCtx, JOB_CONTEXT, job), fn, job)
+	j.bot.cancels.remove(h)
+	if ctx.Err() != nil {
+		// stopped with the bot, the job stays running and is resumed on restart
+		j.mu.Lock()
+		delete(j.running, job.ID)
+		j.mu.Unlock()
+		return
+	}
+	maxAttempts := job.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultJobAttempts
+	}
+	j.finish(job, jobCtx, err, err != nil && job.Attempts < maxAttempts)
+}
+
+func runJob(ctx context.Context, fn JobFunc, job *Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return fn(ctx, job)
+}
+
+// finish records the outcome of a run of the job, cancelled if its context was, then retries
+// it or, once it is over, notifies its failure and prunes the jobs past their retention.
+func (j *Jobs) finish(job *Job, jobCtx context.Context, err error, retry bool) {
+	// the cancellation is checked under the lock of Cancel, so that a job cancelled while
+	// finishing is not recorded as done
+	j.mu.Lock()
+	delete(j.running, job.ID)
+	job.Updated = time.Now()
+	switch {
+	case jobCtx.Err() != nil:
+		job.Status, retry = JobCancelled, false
+	case err == nil:
+		job.Status, job.Error = JobDone, ""
+	case retry:
+		job.Status, job.Error = JobPending, err.Error()
+	default:
+		job.Status, job.Error = JobFailed, err.Error()
+	}
+	if err := j.save(job); err != nil {
+		fmt.Printf("Error saving job %s: %s\n", job.ID, err)
+	}
+	j.mu.Unlock()
+
+	if retry {
+		time.AfterFunc(j.RetryDelay*time.Duration(job.Attempts), func() { j.push(job.ID) })
+		return
+	}
+	if job.Status == JobFailed && job.Channel != "" {
+		text := fmt.Sprintf("Job `%s` (%s) failed after %d attempts: %s", job.ID, job.Kind, job.Attempts, job.Error)
+		if job.User != "" {
+			text = fmt.Sprintf("<@%s> %s", job.User, text)
+		}
+		j.bot.Send(&OutgoingMessage{Channel: job.Channel, Text: text})
+	}
+	j.prune()
+}
+
+func (j *Jobs) save(job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return j.bot.Store().Set(jobKey(job.ID), data, 0)
+}
+
+func (j *Jobs) ids() ([]string, error) {
+	var ids []string
+	data, found, err := j.bot.Store().Get(jobsIndexKey)
+	if err != nil || !found {
+		return nil, err
+	}
+	return ids, json.Unmarshal(data, &ids)
+}
+
+// index updates the list of job IDs persisted in the store.
+func (j *Jobs) index(update func(ids []string) []string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	ids, err := j.ids()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(update(ids))
+	if err != nil {
+		return err
+	}
+	return j.bot.Store().Set(jobsIndexKey, data, 0)
+}
+
+// prune forgets the jobs finished for longer than the retention.
+func (j *Jobs) prune() {
+	cutoff := time.Now().Add(-j.Retention)
+	err := j.index(func(ids []string) []string {
+		kept := ids[:0]
+		for _, id := range ids {
+			job, found, err := j.Get(id)
+			if err == nil && found && job.finished() && job.Updated.Before(cutoff) {
+				_ = j.bot.Store().Delete(jobKey(id))
+				continue
+			}
+			if err == nil && found {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	})
+	if err != nil {
+		fmt.Printf("Error pruning jobs: %s\n", err)
+	}
+}
+
+func (j *Job) finished() bool {
+	return j.Status == JobDone || j.Status == JobFailed || j.Status == JobCancelled
+}
+
+const jobsIndexKey = "jobs:index"
+
+func jobKey(id string) string {
+	return "jobs:" + id
+}
+
+func newJobID() string {
+	buf := make([]byte, 4)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// ErrJobNotFound is returned for the IDs of unknown jobs.
+var ErrJobNotFound = errors.New("job not found")
+
+// EnableJobCommands adds the `jobs` command listing the jobs requested by the sender,
+// `job status <id>` detailing one of them and `job cancel <id>`. Admins may see, and
+// cancel, the jobs of every user.
+func (b *Bot) EnableJobCommands() *Bot {
+	b.Hear(`(?i)^jobs( all)?$`).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		user := evt.User
+		if submatches(`(?i)^jobs( all)?$`, TextFromContext(ctx))[1] != "" && bot.IsAdmin(evt.User) {
+			user = ""
+		}
+		jobs, err := bot.Jobs.List(user)
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Could not list jobs: %s", err))
+			return
+		}
+		if len(jobs) == 0 {
+			bot.Reply(evt, "No jobs.")
+			return
+		}
+		lines := make([]string, len(jobs))
+		for i, job := range jobs {
+			lines[i] = jobLine(job)
+		}
+		bot.Reply(evt, strings.Join(lines, "\n"))
+	})
+	b.Hear(jobStatusRegexp).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		id := submatches(jobStatusRegexp, TextFromContext(ctx))[1]
+		job, found, err := bot.Jobs.Get(id)
+		if err == nil && found && job.User != evt.User && !bot.IsAdmin(evt.User) {
+			found = false
+		}
+		if err == nil && !found {
+			err = ErrJobNotFound
+		}
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Job `%s`: %s", id, err))
+			return
+		}
+		bot.Reply(evt, jobStatusText(job))
+	})
+	b.Hear(jobCancelRegexp).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		id := submatches(jobCancelRegexp, TextFromContext(ctx))[1]
+		job, found, err := bot.Jobs.Get(id)
+		if err == nil && (!found || job.User != evt.User && !bot.IsAdmin(evt.User)) {
+			err = ErrJobNotFound
+		}
+		if err == nil {
+			err = bot.Jobs.Cancel(id)
+		}
+		if err != nil {
+			bot.Reply(evt, fmt.Sprintf("Job `%s`: %s", id, err))
+			return
+		}
+		bot.Reply(evt, fmt.Sprintf("Job `%s` cancelled.", id))
+	})
+	return b
+}
+
+const (
+	jobStatusRegexp = `(?i)^job status (\S+)$`
+	jobCancelRegexp = `(?i)^job cancel (\S+)$`
+)
+
+func jobLine(job *Job) string {
+	line := fmt.Sprintf("`%s` %s %s, %s", job.ID, job.Kind, job.Status, job.Created.Format("Jan 2 15:04"))
+	if job.Progress != "" && job.Status == JobRunning {
+		line += ": " + job.Progress
+	}
+	return line
+}
+
+func jobStatusText(job *Job) string {
+	lines := []string{
+		fmt.Sprintf("Job `%s` (%s) is %s", job.ID, job.Kind, job.Status),
+		fmt.Sprintf("Attempts: %d", job.Attempts),
+		fmt.Sprintf("Created: %s, updated: %s", job.Created.Format(time.RFC1123), job.Updated.Format(time.RFC1123)),
+	}
+	if job.Progress != "" {
+		lines = append(lines, "Progress: "+job.Progress)
+	}
+	if job.Error != "" {
+		lines = append(lines, "Last error: "+job.Error)
+	}
+	return strings.Join(lines, "\n")
+}
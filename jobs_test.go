@@ -0,0 +1,169 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitJob(t *testing.T, bot *Bot, id string, status JobStatus) *Job {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		job, found, err := bot.Jobs.Get(id)
+		if err == nil && found && job.Status == status {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s never became %s", id, status)
+	return nil
+}
+
+func TestJobsRetries(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.Jobs.RetryDelay = time.Millisecond
+	runs := 0
+	bot.Jobs.Handle("report", func(ctx context.Context, job *Job) error {
+		runs++
+		var days int
+		assert.NoError(job.Decode(&days))
+		assert.Equal(7, days)
+		ReportProgress(ctx, "collecting")
+		if runs < 2 {
+			return errors.New("timeout")
+		}
+		return nil
+	})
+
+	evt := &slack.MessageEvent{Msg: slack.Msg{User: "U1", Channel: "C1"}}
+	job, err := NewJob(AddMessageToContext(context.Background(), evt), "report", 7)
+	assert.NoError(err)
+	id, err := bot.Jobs.Enqueue(job)
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+
+	done := waitJob(t, bot, id, JobDone)
+	assert.Equal(2, done.Attempts)
+	assert.Equal("collecting", done.Progress)
+	assert.Equal("U1", done.User)
+
+	_, err = bot.Jobs.Enqueue(&Job{Kind: "unknown"})
+	assert.Error(err)
+}
+
+func TestJobsResume(t *testing.T) {
+	assert := assert.New(t)
+	store := NewMemoryStore()
+	bot := New("").SetStore(store)
+	bot.Jobs.Handle("export", func(ctx context.Context, job *Job) error { return nil })
+	id, err := bot.Jobs.Enqueue(&Job{Kind: "export", User: "U1"})
+	assert.NoError(err)
+
+	// a new bot sharing the store runs the job left pending
+	restarted := New("").SetStore(store)
+	restarted.Jobs.Handle("export", func(ctx context.Context, job *Job) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	restarted.Jobs.start(ctx)
+	waitJob(t, restarted, id, JobDone)
+
+	jobs, err := restarted.Jobs.List("U1")
+	assert.NoError(err)
+	assert.Len(jobs, 1)
+	jobs, err = restarted.Jobs.List("U2")
+	assert.NoError(err)
+	assert.Len(jobs, 0)
+}
+
+func TestJobsFailure(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	sent := make(chan string, 1)
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		sent <- msg.Text
+		return false
+	})
+	bot.Jobs.RetryDelay = time.Millisecond
+	bot.Jobs.Handle("crash", func(ctx context.Context, job *Job) error { panic("boom") })
+	id, err := bot.Jobs.Enqueue(&Job{Kind: "crash", User: "U1", Channel: "C1", MaxAttempts: 2})
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+	job := waitJob(t, bot, id, JobFailed)
+	assert.Equal(2, job.Attempts)
+	assert.Equal("panic: boom", job.Error)
+	assert.Contains(jobStatusText(job), "failed")
+	assert.Contains(<-sent, "<@U1> Job `"+id+"` (crash) failed after 2 attempts")
+}
+
+func TestJobsCancel(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	started := make(chan struct{})
+	bot.Jobs.Handle("sync", func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		// jobs stopping cleanly are still recorded as cancelled
+		return nil
+	})
+	bot.Jobs.Handle("never", func(ctx context.Context, job *Job) error {
+		t.Error("cancelled job ran")
+		return nil
+	})
+	pending, err := bot.Jobs.Enqueue(&Job{Kind: "never"})
+	assert.NoError(err)
+	assert.NoError(bot.Jobs.Cancel(pending))
+	id, err := bot.Jobs.Enqueue(&Job{Kind: "sync"})
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+	<-started
+	assert.NoError(bot.Jobs.Cancel(id))
+	job := waitJob(t, bot, id, JobCancelled)
+	assert.Equal(1, job.Attempts)
+	assert.EqualError(bot.Jobs.Cancel(id), "job is cancelled")
+	assert.Equal(ErrJobNotFound, bot.Jobs.Cancel("unknown"))
+	assert.Equal(JobCancelled, waitJob(t, bot, pending, JobCancelled).Status)
+}
+
+func TestJobsPruneOnFinish(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.Jobs.Retention = 50 * time.Millisecond
+	bot.Jobs.Handle("report", func(ctx context.Context, job *Job) error { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot.Jobs.start(ctx)
+
+	old, err := bot.Jobs.Enqueue(&Job{Kind: "report"})
+	assert.NoError(err)
+	waitJob(t, bot, old, JobDone)
+	time.Sleep(100 * time.Millisecond)
+	id, err := bot.Jobs.Enqueue(&Job{Kind: "report"})
+	assert.NoError(err)
+	waitJob(t, bot, id, JobDone)
+
+	// finished jobs past their retention are forgotten once another job finishes
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if ids, _ := bot.Jobs.ids(); len(ids) == 1 {
+			break
+		}
+	}
+	ids, err := bot.Jobs.ids()
+	assert.NoError(err)
+	assert.Equal([]string{id}, ids)
+	_, found, _ := bot.Jobs.Get(old)
+	assert.False(found)
+}
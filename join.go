@@ -0,0 +1,65 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/slack-go/slack"
+)
+
+const JOIN_CONTEXT = "__JOIN_CONTEXT__"
+
+// JoinOnSend makes the bot join public channels it is not a member of when sending a message
+// there fails, and retry once.
+func (b *Bot) JoinOnSend() *Bot {
+	b.RequireScopes("channels:join")
+	b.joinOnSend = true
+	return b
+}
+
+// JoinOnSend makes the replies of the handler of the route, and the messages it sends with
+// SendContext, join public channels the bot is not a member of.
+func (r *Route) JoinOnSend() *Route {
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			ctx = context.WithValue(ctx, JOIN_CONTEXT, true)
+			bot, evt := BotFromContext(ctx), MessageFromContext(ctx)
+			if bot == nil || evt == nil {
+				next(ctx)
+				return
+			}
+			bot.routeJoin.Store(evt, true)
+			defer bot.routeJoin.Delete(evt)
+			next(ctx)
+		}
+	})
+}
+
+// attachJoin makes a reply to the message event join its channel, when the route handling
+// the event joins on send.
+func (b *Bot) attachJoin(msg *OutgoingMessage, evt *slack.MessageEvent) {
+	if _, ok := b.routeJoin.Load(evt); ok {
+		msg.JoinChannel = true
+	}
+}
+
+// SendContext is like Send, honoring the options of the route handling the context.
+func (b *Bot) SendContext(ctx context.Context, msg *OutgoingMessage) (string, error) {
+	if join, _ := ctx.Value(JOIN_CONTEXT).(bool); join {
+		msg.JoinChannel = true
+	}
+	return b.Send(msg)
+}
+
+// joinAndRetry joins the channel of a message which failed because the bot is not a member,
+// when allowed, and sends it again.
+func (b *Bot) joinAndRetry(msg *OutgoingMessage, ts string, err error) (string, error) {
+	if !errors.Is(err, ErrNotInChannel) || !msg.JoinChannel && !b.joinOnSend {
+		return ts, err
+	}
+	if joinErr := b.join(msg.Channel); joinErr != nil {
+		return ts, err
+	}
+	ts, err = b.send(msg)
+	return ts, WrapError(err)
+}
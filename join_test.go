@@ -0,0 +1,61 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinOnSend(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.join", `{"ok": true, "channel": {"id": "C1"}}`)
+	notInChannel := `{"ok": false, "error": "not_in_channel"}`
+	sent := `{"ok": true, "channel": "C1", "ts": "2.0"}`
+
+	// not joined by default
+	api.respond("chat.postMessage", notInChannel, sent)
+	_, err := bot.Send(&OutgoingMessage{Channel: "C1", Text: "hi"})
+	assert.Error(err)
+	assert.Empty(api.values("conversations.join", "channel"))
+
+	// joined and sent again per message
+	api.respond("chat.postMessage", notInChannel, sent)
+	ts, err := bot.Send(&OutgoingMessage{Channel: "C1", Text: "hi", JoinChannel: true})
+	assert.NoError(err)
+	assert.Equal("2.0", ts)
+	assert.Equal([]string{"C1"}, api.values("conversations.join", "channel"))
+}
+
+func TestRouteJoinOnSend(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.join", `{"ok": true, "channel": {"id": "C1"}}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	bot.Hear("^announce$").JoinOnSend().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.ReplyPost(evt, "replied")
+		_, _ = bot.SendContext(ctx, &OutgoingMessage{Channel: "C2", Text: "sent"})
+	})
+	bot.Hear("^other$").MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.ReplyPost(evt, "replied")
+	})
+	handle := func(text string) {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text = "C1", "U1", text
+		bot.handleMessage(ctx, evt)
+	}
+
+	notInChannel := `{"ok": false, "error": "not_in_channel"}`
+	api.respond("chat.postMessage", notInChannel, `{"ok": true}`, notInChannel, `{"ok": true}`)
+	handle("announce")
+	// both the reply and the message sent with the context join their channel
+	assert.Equal([]string{"C1", "C2"}, api.values("conversations.join", "channel"))
+
+	api.respond("chat.postMessage", notInChannel)
+	handle("other")
+	assert.Len(api.values("conversations.join", "channel"), 2)
+}
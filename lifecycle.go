@@ -0,0 +1,66 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LifecycleFunc is called when the bot comes online or shuts down.
+type LifecycleFunc func(ctx context.Context, bot *Bot)
+
+// ShutdownTimeout bounds the time given to the OnShutdown callbacks.
+var ShutdownTimeout = 10 * time.Second
+
+// OnReady registers a callback run once the bot is connected, the first time only.
+func (b *Bot) OnReady(fn LifecycleFunc) *Bot {
+	b.onReady = append(b.onReady, fn)
+	return b
+}
+
+// OnShutdown registers a callback run when Serve stops, e.g. once its context is cancelled
+// on SIGTERM. Callbacks get a fresh context, cancelled after ShutdownTimeout.
+func (b *Bot) OnShutdown(fn LifecycleFunc) *Bot {
+	b.onShutdown = append(b.onShutdown, fn)
+	return b
+}
+
+// Announcements posted by Announce, formatted with the version.
+var (
+	ReadyAnnouncement    = ":large_green_circle: Online, running version %s."
+	ShutdownAnnouncement = ":red_circle: Shutting down, was running version %s."
+)
+
+// Announce posts to the channel when the bot comes online and when it shuts down, along with
+// its version, so deploys are visible.
+func (b *Bot) Announce(channel, version string) *Bot {
+	b.RequireScopes("chat:write")
+	announce := func(format string) LifecycleFunc {
+		return func(ctx context.Context, bot *Bot) {
+			msg := &OutgoingMessage{Channel: channel, Text: fmt.Sprintf(format, version)}
+			if _, err := bot.Send(msg); err != nil {
+				fmt.Printf("Error announcing to %s: %s\n", channel, err)
+			}
+		}
+	}
+	return b.OnReady(announce(ReadyAnnouncement)).OnShutdown(announce(ShutdownAnnouncement))
+}
+
+// ready runs the OnReady callbacks, once.
+func (b *Bot) ready(ctx context.Context) {
+	b.readyOnce.Do(func() {
+		for _, fn := range b.onReady {
+			fn(ctx, b)
+		}
+	})
+}
+
+// shutdown runs the OnShutdown callbacks.
+func (b *Bot) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	ctx = AddBotToContext(ctx, b)
+	for _, fn := range b.onShutdown {
+		fn(ctx, b)
+	}
+}
@@ -0,0 +1,34 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	var events []string
+	bot := New("").Announce("C1", "1.2.3").OnReady(func(ctx context.Context, bot *Bot) {
+		events = append(events, "ready")
+	}).OnShutdown(func(ctx context.Context, bot *Bot) {
+		assert.NotNil(BotFromContext(ctx))
+		events = append(events, "shutdown")
+	})
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		events = append(events, msg.Channel+": "+msg.Text)
+		return false
+	})
+
+	bot.ready(context.Background())
+	// reconnections do not run the callbacks again
+	bot.ready(context.Background())
+	bot.shutdown()
+	assert.Equal([]string{
+		"C1: :large_green_circle: Online, running version 1.2.3.",
+		"ready",
+		"C1: :red_circle: Shutting down, was running version 1.2.3.",
+		"shutdown",
+	}, events)
+}
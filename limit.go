@@ -0,0 +1,77 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+)
+
+// RateLimitText is the reply sent when a user exceeds the concurrency limit of a route.
+var RateLimitText = "Slow down, I'm still working on your previous requests."
+
+// LimitPerUser allows at most max concurrent invocations of the route per user. Excess
+// requests are answered with RateLimitText.
+func (r *Route) LimitPerUser(max int) *Route {
+	return r.Use(newUserLimiter(max, false).middleware)
+}
+
+// QueuePerUser allows at most max concurrent invocations of the route per user. Excess
+// requests are queued and run, in order, as the previous ones of the same user complete.
+func (r *Route) QueuePerUser(max int) *Route {
+	return r.Use(newUserLimiter(max, true).middleware)
+}
+
+type userLimiter struct {
+	max   int
+	queue bool
+
+	mu       sync.Mutex
+	inflight map[string]int
+	waiting  map[string][]func()
+}
+
+func newUserLimiter(max int, queue bool) *userLimiter {
+	return &userLimiter{
+		max:      max,
+		queue:    queue,
+		inflight: make(map[string]int),
+		waiting:  make(map[string][]func()),
+	}
+}
+
+func (l *userLimiter) middleware(next Handler) Handler {
+	return func(ctx context.Context) {
+		msg := MessageFromContext(ctx)
+		user := msg.User
+		run := func() { next(ctx) }
+
+		l.mu.Lock()
+		if l.inflight[user] >= l.max {
+			if l.queue {
+				l.waiting[user] = append(l.waiting[user], run)
+				l.mu.Unlock()
+				return
+			}
+			l.mu.Unlock()
+			BotFromContext(ctx).Reply(msg, RateLimitText)
+			return
+		}
+		l.inflight[user]++
+		l.mu.Unlock()
+
+		// drain the queue of the user in this goroutine once done
+		for run != nil {
+			run()
+			l.mu.Lock()
+			if queued := l.waiting[user]; len(queued) > 0 {
+				run, l.waiting[user] = queued[0], queued[1:]
+			} else {
+				run = nil
+				delete(l.waiting, user)
+				if l.inflight[user]--; l.inflight[user] == 0 {
+					delete(l.inflight, user)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
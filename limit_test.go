@@ -0,0 +1,84 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func limitContext(bot *Bot, user, text string) context.Context {
+	msg := &slack.MessageEvent{}
+	msg.Channel, msg.User, msg.Text = "C1", user, text
+	return AddMessageToContext(AddBotToContext(context.Background(), bot), msg)
+}
+
+func TestLimitPerUser(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	started, release := make(chan string), make(chan struct{})
+	handler := newUserLimiter(1, false).middleware(func(ctx context.Context) {
+		started <- MessageFromContext(ctx).Text
+		<-release
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); handler(limitContext(bot, "U1", "first")) }()
+	assert.Equal("first", <-started)
+	go func() { defer wg.Done(); handler(limitContext(bot, "U2", "other user")) }()
+	assert.Equal("other user", <-started)
+
+	// the second request of U1 is refused while the first runs
+	handler(limitContext(bot, "U1", "second"))
+	assert.Equal([]string{RateLimitText}, api.values("chat.postMessage", "text"))
+
+	close(release)
+	wg.Wait()
+	go handler(limitContext(bot, "U1", "third"))
+	assert.Equal("third", <-started)
+}
+
+func TestQueuePerUser(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	started, release := make(chan string, 3), make(chan struct{})
+	handler := newUserLimiter(1, true).middleware(func(ctx context.Context) {
+		started <- MessageFromContext(ctx).Text
+		<-release
+	})
+
+	done := make(chan struct{})
+	go func() { handler(limitContext(bot, "U1", "first")); close(done) }()
+	assert.Equal("first", <-started)
+	// queued requests return at once and run after the first
+	handler(limitContext(bot, "U1", "second"))
+	handler(limitContext(bot, "U1", "third"))
+	close(release)
+	<-done
+	assert.Equal("second", <-started)
+	assert.Equal("third", <-started)
+	assert.Empty(api.calls("text"))
+}
+
+func TestWorkers(t *testing.T) {
+	bot := New("").Workers(2)
+	for round := 0; round < 2; round++ {
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		for i := 0; i < 2; i++ {
+			// both handlers run at once, and their slots are reused once done
+			bot.dispatch(context.Background(), func(ctx context.Context) {
+				defer wg.Done()
+				<-release
+			})
+		}
+		close(release)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// defaultLiveInterval is the minimum delay between two chat.update calls of a live message.
+// chat.update is a Tier 3 method, so one update per second stays well within limits.
+const defaultLiveInterval time.Duration = time.Second
+
+// liveMessage is a message that is posted once and then edited in place with throttled
+// chat.update calls. render is invoked with mu held and fills in the current content.
+type liveMessage struct {
+	bot      *Bot
+	channel  string
+	ts       string
+	interval time.Duration
+	render   func(msg *OutgoingMessage)
+
+	mu      sync.Mutex
+	last    time.Time
+	pending *time.Timer
+	err     error
+}
+
+func newLiveMessage(b *Bot, channel string, render func(msg *OutgoingMessage)) *liveMessage {
+	return &liveMessage{bot: b, channel: channel, interval: defaultLiveInterval, render: render}
+}
+
+// updateLocked posts or edits the message. Unless force is set, edits arriving before the
+// interval has elapsed are deferred and merged. The caller must hold mu.
+func (m *liveMessage) updateLocked(force bool) error {
+	if m.err != nil {
+		return m.err
+	}
+	if !force && m.ts != "" {
+		if wait := m.interval - time.Since(m.last); wait > 0 {
+			if m.pending == nil {
+				m.pending = time.AfterFunc(wait, func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.pending = nil
+					m.sendLocked()
+				})
+			}
+			return nil
+		}
+	}
+	if m.pending != nil {
+		m.pending.Stop()
+		m.pending = nil
+	}
+	return m.sendLocked()
+}
+
+func (m *liveMessage) sendLocked() error {
+	msg := &OutgoingMessage{
+		Channel:   m.channel,
+		Timestamp: m.ts,
+		Params:    slack.PostMessageParameters{AsUser: true},
+	}
+	m.render(msg)
+	if msg.Text == "" && len(msg.Blocks) == 0 {
+		return nil
+	}
+	var ts string
+	ts, m.err = m.bot.Send(msg)
+	if m.ts == "" {
+		m.ts = ts
+	}
+	m.last = time.Now()
+	return m.err
+}
@@ -0,0 +1,50 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveMessage(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+
+	text := ""
+	m := newLiveMessage(bot, "C1", func(msg *OutgoingMessage) { msg.Text = text })
+	m.interval = 50 * time.Millisecond
+	m.mu.Lock()
+	// nothing is posted until there is something to show
+	assert.NoError(m.updateLocked(false))
+	text = "1"
+	assert.NoError(m.updateLocked(false))
+	// edits within the interval are merged into one deferred update
+	text = "2"
+	assert.NoError(m.updateLocked(false))
+	text = "3"
+	assert.NoError(m.updateLocked(false))
+	m.mu.Unlock()
+	api.wait("chat.update", 1)
+	assert.Equal([]string{"chat.postMessage 1", "chat.update 3"}, api.calls("text"))
+	assert.Equal([]string{"1.0"}, api.values("chat.update", "ts"))
+
+	// forced updates are sent at once and replace the deferred one
+	m.mu.Lock()
+	text = "4"
+	assert.NoError(m.updateLocked(false))
+	text = "5"
+	assert.NoError(m.updateLocked(true))
+	m.mu.Unlock()
+	time.Sleep(2 * m.interval)
+	assert.Equal([]string{"3", "5"}, api.values("chat.update", "text"))
+
+	// after a failure, the message is no longer updated
+	api.respond("chat.update", `{"ok": false, "error": "message_not_found"}`)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	assert.Error(m.updateLocked(true))
+	assert.Error(m.updateLocked(true))
+	assert.Len(api.values("chat.update", ""), 3)
+}
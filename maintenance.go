@@ -0,0 +1,81 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// MaintenanceText is the reply to messages handled during maintenance, unless another
+// message is given to SetMaintenance.
+var MaintenanceText = ":construction: I am under maintenance, please try again later."
+
+// SetMaintenance turns maintenance mode on or off. During maintenance, messages matching
+// routes other than admin ones, and those left to the Fallback responder, are answered with
+// the message, or MaintenanceText when empty, and scheduled functions are paused.
+func (b *Bot) SetMaintenance(on bool, message string) *Bot {
+	b.maintenanceMu.Lock()
+	defer b.maintenanceMu.Unlock()
+	b.maintenance = on
+	b.maintenanceText = message
+	return b
+}
+
+// InMaintenance returns whether the bot is in maintenance, and the reply to messages then.
+func (b *Bot) InMaintenance() (bool, string) {
+	b.maintenanceMu.Lock()
+	defer b.maintenanceMu.Unlock()
+	if b.maintenanceText == "" {
+		return b.maintenance, MaintenanceText
+	}
+	return b.maintenance, b.maintenanceText
+}
+
+// EnableMaintenanceCommand adds the admin command toggling maintenance mode:
+//
+//	maintenance on [message]
+//	maintenance off
+func (b *Bot) EnableMaintenanceCommand() *Bot {
+	b.Hear(maintenanceRegexp).AdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		args := submatches(maintenanceRegexp, TextFromContext(ctx))
+		on := strings.EqualFold(args[1], "on")
+		bot.SetMaintenance(on, strings.TrimSpace(args[2]))
+		if on {
+			bot.Reply(evt, "Maintenance mode is on.")
+		} else {
+			bot.Reply(evt, "Maintenance mode is off.")
+		}
+	})
+	return b
+}
+
+const maintenanceRegexp = `(?is)^maintenance (on|off)\b(.*)$`
+
+// maintenanceState is the JSON of the maintenance endpoint of the admin API.
+type maintenanceState struct {
+	On      bool   `json:"on"`
+	Message string `json:"message,omitempty"`
+}
+
+// maintenanceAPI reports the maintenance mode on GET, and sets it on PUT or POST.
+func (b *Bot) maintenanceAPI(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var state maintenanceState
+		if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b.SetMaintenance(state.On, state.Message)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	on, message := b.InMaintenance()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(maintenanceState{On: on, Message: message})
+}
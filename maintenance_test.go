@@ -0,0 +1,75 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetMaintenance(true, "")
+	on, text := bot.InMaintenance()
+	assert.True(on)
+	assert.Equal(MaintenanceText, text)
+
+	bot.Hear("deploy").Handler(func(ctx context.Context) {})
+	bot.Hear("maintenance").AdminOnly().Handler(func(ctx context.Context) {})
+	match := func(text string) *RouteMatch {
+		var m RouteMatch
+		ctx := AddTextToContext(AddBotToContext(context.Background(), bot), text)
+		ctx = AddMessageToContext(ctx, &slack.MessageEvent{Msg: slack.Msg{Text: text}})
+		bot.Match(ctx, &m)
+		return &m
+	}
+	assert.False(match("deploy").admin)
+	assert.True(match("maintenance off").admin)
+}
+
+func TestMaintenanceAPI(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetHTTPConfig(HTTPConfig{AdminToken: "secret"})
+	handler := bot.HTTPHandler()
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{"on": true, "message": "Upgrading"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{"on": true, "message": "Upgrading"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.JSONEq(`{"on": true, "message": "Upgrading"}`, rec.Body.String())
+	on, text := bot.InMaintenance()
+	assert.True(on)
+	assert.Equal("Upgrading", text)
+}
+
+func TestMaintenanceFallback(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetMaintenance(true, "Upgrading")
+	api := newSlackAPI(t, bot)
+	asked := 0
+	bot.Fallback(ResponderFunc(func(ctx context.Context, text string) (string, error) {
+		asked++
+		return "An answer", nil
+	}))
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "D1", "U1", "what is the weather?"
+	bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	assert.Equal(0, asked)
+	assert.Equal([]string{"Upgrading"}, api.values("chat.postMessage", "text"))
+
+	bot.SetMaintenance(false, "")
+	bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	assert.Equal(1, asked)
+	assert.Equal([]string{"Upgrading", "An answer"}, api.values("chat.postMessage", "text"))
+}
@@ -0,0 +1,201 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes the commands of a bot, for documentation sites and the help command.
+type Manifest struct {
+	Commands []CommandSpec `json:"commands" yaml:"commands"`
+}
+
+// CommandSpec describes a command of the bot.
+type CommandSpec struct {
+	Name        string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Usage       string    `json:"usage" yaml:"usage"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Arguments   []ArgSpec `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+	// "admin" for commands restricted to admins, "everyone" otherwise
+	Permission string   `json:"permission" yaml:"permission"`
+	Examples   []string `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+// ArgSpec describes an argument of a command.
+type ArgSpec struct {
+	Name     string `json:"name" yaml:"name"`
+	Type     string `json:"type" yaml:"type"`
+	Required bool   `json:"required" yaml:"required"`
+}
+
+const (
+	PermissionEveryone = "everyone"
+	PermissionAdmin    = "admin"
+)
+
+// Description documents what the route does, in the Manifest and the help command.
+func (r *Route) Description(text string) *Route {
+	r.description = text
+	return r
+}
+
+// Examples documents invocations of the route, in the Manifest and the help command.
+func (r *Route) Examples(examples ...string) *Route {
+	r.examples = append(r.examples, examples...)
+	return r
+}
+
+// Manifest describes the routes of the bot documented with a Usage, the subcommands of
+// Cmd included, in registration order.
+//
+//	data, _ := bot.Manifest().YAML()
+//	os.WriteFile("docs/commands.yaml", data, 0644)
+func (b *Bot) Manifest() Manifest {
+	m := Manifest{Commands: []CommandSpec{}}
+	walkCommands(&b.SimpleRouter, false, func(r *Route, admin bool) {
+		m.Commands = append(m.Commands, r.spec(admin))
+	})
+	return m
+}
+
+// walkCommands calls fn for every route with a usage, with whether it or a parent route is
+// restricted to admins. Commands are replaced by their subcommands.
+func walkCommands(router *SimpleRouter, admin bool, fn func(r *Route, admin bool)) {
+	for _, r := range router.routes {
+		switch {
+		case r.command != nil:
+			walkCommands(r.command.router, admin || r.admin, fn)
+		case r.subrouter != nil:
+			if sub, ok := r.subrouter.(*SimpleRouter); ok {
+				walkCommands(sub, admin || r.admin, fn)
+			}
+		case r.usage != "":
+			fn(r, admin || r.admin)
+		}
+	}
+}
+
+func (r *Route) spec(admin bool) CommandSpec {
+	spec := CommandSpec{
+		Name:        r.name,
+		Usage:       r.usage,
+		Description: r.description,
+		Permission:  PermissionEveryone,
+		Examples:    r.examples,
+	}
+	if admin {
+		spec.Permission = PermissionAdmin
+	}
+	schema := r.schema
+	if schema == nil {
+		schema = usageSchema(r.usage)
+	}
+	for _, arg := range schema {
+		spec.Arguments = append(spec.Arguments, ArgSpec{Name: arg.Name, Type: arg.Type.String(), Required: arg.Required})
+	}
+	return spec
+}
+
+// String returns the name of the type, e.g. "int".
+func (t ArgType) String() string {
+	switch t {
+	case IntArg:
+		return "int"
+	case BoolArg:
+		return "bool"
+	case DurationArg:
+		return "duration"
+	}
+	return "string"
+}
+
+// JSON encodes the manifest as indented JSON.
+func (m Manifest) JSON() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// YAML encodes the manifest as YAML.
+func (m Manifest) YAML() ([]byte, error) {
+	return yaml.Marshal(m)
+}
+
+// Help lists the commands, those restricted to admins only if admin is set.
+func (m Manifest) Help(admin bool) string {
+	var lines []string
+	for _, c := range m.Commands {
+		if c.Permission == PermissionAdmin && !admin {
+			continue
+		}
+		line := "• `" + c.Usage + "`"
+		if c.Description != "" {
+			line += " " + c.Description
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "No commands."
+	}
+	return "Commands:\n" + strings.Join(lines, "\n")
+}
+
+// CommandHelp details the commands starting with the words, or returns false if none does.
+func (m Manifest) CommandHelp(command string, admin bool) (string, bool) {
+	words := strings.ToLower(strings.Join(strings.Fields(command), " "))
+	var sections []string
+	for _, c := range m.Commands {
+		name := strings.Join(usageCommand(c.Usage), " ")
+		if c.Permission == PermissionAdmin && !admin || name != words && !strings.HasPrefix(name, words+" ") {
+			continue
+		}
+		lines := []string{"`" + c.Usage + "`"}
+		if c.Description != "" {
+			lines = append(lines, c.Description)
+		}
+		for _, arg := range c.Arguments {
+			optional := ""
+			if !arg.Required {
+				optional = ", optional"
+			}
+			lines = append(lines, fmt.Sprintf("• `%s` %s%s", arg.Name, arg.Type, optional))
+		}
+		if c.Permission == PermissionAdmin {
+			lines = append(lines, "_Admins only._")
+		}
+		for _, e := range c.Examples {
+			lines = append(lines, "> "+e)
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	if len(sections) == 0 {
+		return "", false
+	}
+	return strings.Join(sections, "\n\n"), true
+}
+
+var helpRegexp = regexp.MustCompile(`(?i)^help(?:\s+(.+))?$`)
+
+// EnableHelp adds the `help [command]` command, listing the commands of the Manifest, or
+// detailing those starting with the given words. Admin commands are only shown to admins.
+func (b *Bot) EnableHelp() *Bot {
+	b.Hear(helpRegexp.String()).Usage("help [command]").Description("Lists the commands, or details one.").
+		MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+			admin := bot.IsAdmin(evt.User) || WorkspaceConfig(ctx).IsAdmin(evt.User)
+			command := helpRegexp.FindStringSubmatch(TextFromContext(ctx))[1]
+			if command == "" {
+				bot.Reply(evt, bot.Manifest().Help(admin))
+				return
+			}
+			if help, ok := bot.Manifest().CommandHelp(command, admin); ok {
+				bot.Reply(evt, help)
+				return
+			}
+			bot.Reply(evt, fmt.Sprintf("Unknown command `%s`.", command))
+		})
+	return b
+}
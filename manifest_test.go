@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestManifest(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	noop := func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {}
+	type ScaleArgs struct {
+		Service  string `arg:"service"`
+		Replicas int    `arg:"replicas"`
+	}
+	Handle(bot, "scale <service> [replicas]", func(ctx context.Context, bot *Bot, args ScaleArgs) error { return nil }).
+		Name("scale").Description("Scales a service.").Examples("scale api 3")
+	bot.Hear("^undocumented$").MessageHandler(noop)
+	bot.Hear("^purge$").Usage("purge").AdminOnly().MessageHandler(noop)
+	config := bot.Cmd("config")
+	config.Sub("get <key>", noop)
+	config.Cmd("user").Sub("set <key> <value>", noop)
+	bot.EnableHelp()
+
+	m := bot.Manifest()
+	usages := make([]string, len(m.Commands))
+	for i, c := range m.Commands {
+		usages[i] = c.Usage
+	}
+	assert.Equal([]string{"scale <service> [replicas]", "purge", "config get <key>", "config user set <key> <value>", "help [command]"}, usages)
+	assert.Equal(CommandSpec{
+		Name:        "scale",
+		Usage:       "scale <service> [replicas]",
+		Description: "Scales a service.",
+		Arguments:   []ArgSpec{{Name: "service", Type: "string", Required: true}, {Name: "replicas", Type: "int"}},
+		Permission:  PermissionEveryone,
+		Examples:    []string{"scale api 3"},
+	}, m.Commands[0])
+	assert.Equal(PermissionAdmin, m.Commands[1].Permission)
+
+	data, err := m.JSON()
+	assert.NoError(err)
+	var decoded Manifest
+	assert.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal(m, decoded)
+	data, err = m.YAML()
+	assert.NoError(err)
+	decoded = Manifest{}
+	assert.NoError(yaml.Unmarshal(data, &decoded))
+	assert.Equal(m, decoded)
+}
+
+func TestManifestHelp(t *testing.T) {
+	assert := assert.New(t)
+	m := Manifest{Commands: []CommandSpec{
+		{Usage: "deploy <env>", Description: "Deploys.", Permission: PermissionEveryone,
+			Arguments: []ArgSpec{{Name: "env", Type: "string", Required: true}}, Examples: []string{"deploy prod"}},
+		{Usage: "purge", Permission: PermissionAdmin},
+	}}
+	assert.Equal("Commands:\n• `deploy <env>` Deploys.", m.Help(false))
+	assert.Equal("Commands:\n• `deploy <env>` Deploys.\n• `purge`", m.Help(true))
+
+	help, ok := m.CommandHelp("Deploy", false)
+	assert.True(ok)
+	assert.Equal("`deploy <env>`\nDeploys.\n• `env` string\n> deploy prod", help)
+	_, ok = m.CommandHelp("purge", false)
+	assert.False(ok)
+	help, ok = m.CommandHelp("purge", true)
+	assert.True(ok)
+	assert.Equal("`purge`\n_Admins only._", help)
+}
@@ -0,0 +1,59 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// messageCacheTTL is how long fetched messages are kept in the Store.
+const messageCacheTTL = time.Hour
+
+// FetchMessage returns the message posted at ts in the channel, including thread replies.
+// Messages are cached in the bot Store.
+func (b *Bot) FetchMessage(channel, ts string) (*slack.MessageEvent, error) {
+	key := "message:" + channel + ":" + ts
+	if data, found, err := b.Store().Get(key); err == nil && found {
+		var msg slack.MessageEvent
+		if err := json.Unmarshal(data, &msg); err == nil {
+			return &msg, nil
+		}
+	}
+
+	history, err := b.Client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Latest:    ts,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	messages := history.Messages
+	if len(messages) == 0 || messages[0].Timestamp != ts {
+		// thread replies only show up in the replies of the thread
+		messages, _, _, err = b.Client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+			ChannelID: channel,
+			Timestamp: ts,
+			Latest:    ts,
+			Inclusive: true,
+			Limit:     1,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range messages {
+		if m.Timestamp == ts {
+			msg := slack.MessageEvent(m)
+			msg.Channel = channel
+			if data, err := json.Marshal(msg); err == nil {
+				_ = b.Store().Set(key, data, messageCacheTTL)
+			}
+			return &msg, nil
+		}
+	}
+	return nil, fmt.Errorf("message %s not found in %s", ts, channel)
+}
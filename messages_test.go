@@ -0,0 +1,37 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchMessage(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": true, "messages": [{"type": "message", "user": "U1", "text": "ship it", "ts": "1.0"}]}`)
+	api.respond("conversations.replies", `{"ok": true, "messages": [{"type": "message", "user": "U2", "text": "in a thread", "ts": "2.0", "thread_ts": "1.0"}]}`)
+
+	msg, err := bot.FetchMessage("C1", "1.0")
+	if assert.NoError(err) {
+		assert.Equal("C1", msg.Channel)
+		assert.Equal("ship it", msg.Text)
+	}
+	// thread replies are looked up in the replies
+	msg, err = bot.FetchMessage("C1", "2.0")
+	if assert.NoError(err) {
+		assert.Equal("in a thread", msg.Text)
+		assert.Equal("1.0", msg.ThreadTimestamp)
+	}
+	_, err = bot.FetchMessage("C1", "3.0")
+	assert.Error(err)
+
+	// fetched messages are cached
+	msg, err = bot.FetchMessage("C1", "1.0")
+	if assert.NoError(err) {
+		assert.Equal("ship it", msg.Text)
+	}
+	assert.Equal([]string{"1.0", "2.0", "3.0"}, api.values("conversations.history", "latest"))
+	assert.Equal([]string{"2.0", "3.0"}, api.values("conversations.replies", "ts"))
+}
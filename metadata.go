@@ -0,0 +1,121 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// MessageMetadata is machine-readable data attached to a message, for other apps and
+// workflows. EventType names the kind of event, e.g. "deploy_finished".
+type MessageMetadata struct {
+	EventType    string                 `json:"event_type"`
+	EventPayload map[string]interface{} `json:"event_payload"`
+}
+
+// MetadataEvent is a message_metadata_posted event, sent through the Events API when a
+// message with metadata is posted in a channel the app is a member of.
+type MetadataEvent struct {
+	Type      string          `json:"type"`
+	AppID     string          `json:"app_id"`
+	BotID     string          `json:"bot_id"`
+	UserID    string          `json:"user_id"`
+	TeamID    string          `json:"team_id"`
+	Channel   string          `json:"channel_id"`
+	MessageTS string          `json:"message_ts"`
+	EventTS   string          `json:"event_ts"`
+	Metadata  MessageMetadata `json:"metadata"`
+}
+
+// MetadataHandler handles a message_metadata_posted event.
+type MetadataHandler func(ctx context.Context, bot *Bot, evt *MetadataEvent)
+
+// OnMetadata registers a handler for messages posted with metadata of the event type, or any
+// metadata if empty. Metadata events are only delivered through the Events API.
+func (b *Bot) OnMetadata(eventType string, fn MetadataHandler) *Bot {
+	b.RequireScopes("metadata.message:read")
+	return b.OnEvent("message_metadata_posted", func(ctx context.Context, bot *Bot, evt interface{}) {
+		e, ok := evt.(*MetadataEvent)
+		if ok && (eventType == "" || e.Metadata.EventType == eventType) {
+			fn(ctx, bot, e)
+		}
+	})
+}
+
+// WithMetadata attaches metadata to the reply.
+func WithMetadata(metadata MessageMetadata) ReplyOption {
+	return func(c *replyConfig) { c.metadata = &metadata }
+}
+
+// sendWithMetadata posts, or edits, a message with metadata, which the slack package does
+// not support.
+func (b *Bot) sendWithMetadata(msg *OutgoingMessage) (string, error) {
+	values := url.Values{"channel": {msg.Channel}}
+	metadata, err := json.Marshal(msg.Metadata)
+	if err != nil {
+		return "", err
+	}
+	values.Set("metadata", string(metadata))
+	if msg.Text != "" {
+		values.Set("text", msg.Text)
+	}
+	if len(msg.Attachments) > 0 {
+		attachments, err := json.Marshal(msg.Attachments)
+		if err != nil {
+			return "", err
+		}
+		values.Set("attachments", string(attachments))
+	}
+	if len(msg.Blocks) > 0 {
+		blocks, err := json.Marshal(msg.Blocks)
+		if err != nil {
+			return "", err
+		}
+		values.Set("blocks", string(blocks))
+	}
+	if msg.UnfurlLinks != nil {
+		values.Set("unfurl_links", strconv.FormatBool(*msg.UnfurlLinks))
+	}
+	if msg.UnfurlMedia != nil {
+		values.Set("unfurl_media", strconv.FormatBool(*msg.UnfurlMedia))
+	}
+	if msg.Markdown != nil {
+		values.Set("mrkdwn", strconv.FormatBool(*msg.Markdown))
+	}
+
+	method := "chat.postMessage"
+	if msg.Timestamp != "" {
+		method = "chat.update"
+		values.Set("ts", msg.Timestamp)
+	} else {
+		p := msg.Params
+		if p.ThreadTimestamp != "" {
+			values.Set("thread_ts", p.ThreadTimestamp)
+			values.Set("reply_broadcast", strconv.FormatBool(p.ReplyBroadcast))
+		}
+		if p.AsUser {
+			values.Set("as_user", "true")
+		}
+		if p.Username != "" {
+			values.Set("username", p.Username)
+		}
+		if p.LinkNames != 0 {
+			values.Set("link_names", "1")
+		}
+		if p.IconEmoji != "" {
+			values.Set("icon_emoji", p.IconEmoji)
+		}
+		if p.IconURL != "" {
+			values.Set("icon_url", p.IconURL)
+		}
+	}
+
+	var resp struct {
+		Timestamp string `json:"ts"`
+	}
+	if err := b.callAPI(context.Background(), method, values, &resp); err != nil {
+		return "", err
+	}
+	return resp.Timestamp, nil
+}
@@ -0,0 +1,56 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyWithMetadata(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Timestamp = "C1", "U1", "1.0"
+
+	bot.Reply(evt, "deployed", InThread(), WithMetadata(MessageMetadata{
+		EventType:    "deploy_finished",
+		EventPayload: map[string]interface{}{"service": "web"},
+	}))
+	assert.Equal([]string{"deployed"}, api.values("chat.postMessage", "text"))
+	assert.Equal([]string{"1.0"}, api.values("chat.postMessage", "thread_ts"))
+	metadata := api.values("chat.postMessage", "metadata")
+	if assert.Len(metadata, 1) {
+		assert.JSONEq(`{"event_type": "deploy_finished", "event_payload": {"service": "web"}}`, metadata[0])
+	}
+
+	// edits keep their metadata
+	_, err := bot.Send(&OutgoingMessage{Channel: "C1", Timestamp: "2.0", Text: "rolled back",
+		Metadata: &MessageMetadata{EventType: "deploy_rolled_back"}})
+	assert.NoError(err)
+	assert.Equal([]string{"2.0"}, api.values("chat.update", "ts"))
+}
+
+func TestOnMetadata(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var heard []string
+	bot.OnMetadata("deploy_finished", func(ctx context.Context, bot *Bot, evt *MetadataEvent) {
+		heard = append(heard, evt.Channel+" "+evt.Metadata.EventPayload["service"].(string))
+	})
+
+	for _, eventType := range []string{"deploy_finished", "deploy_started"} {
+		data, _ := json.Marshal(map[string]interface{}{
+			"type":       "message_metadata_posted",
+			"channel_id": "C1",
+			"message_ts": "1.0",
+			"metadata":   map[string]interface{}{"event_type": eventType, "event_payload": map[string]string{"service": "web"}},
+		})
+		bot.handleCallbackEvent("T1", "", data)
+	}
+	assert.Equal([]string{"C1 web"}, heard)
+	assert.True(bot.requiredScopes["metadata.message:read"])
+}
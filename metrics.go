@@ -0,0 +1,164 @@
+package slackbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics is a snapshot of the activity of the bot.
+type Metrics struct {
+	// Messages received, and those matching a route
+	MessagesReceived int64
+	MessagesHandled  int64
+	// Calls of the Slack Web API, and those failing
+	APICalls  int64
+	APIErrors int64
+	// Round trip time of the last RTM ping, zero before the first one
+	RTMLatency time.Duration
+	// Reconnections of RTM, and the time of the last one
+	Reconnects    int64
+	LastReconnect time.Time
+	// Handlers run, for messages or events
+	HandlerRuns int64
+	// Total time spent in Web API calls and in handlers
+	APITime     time.Duration
+	HandlerTime time.Duration
+}
+
+// APIErrorRate returns the ratio of failed Web API calls.
+func (m Metrics) APIErrorRate() float64 {
+	if m.APICalls == 0 {
+		return 0
+	}
+	return float64(m.APIErrors) / float64(m.APICalls)
+}
+
+// MethodLatency describes the durations of the calls of a Web API method.
+type MethodLatency struct {
+	Calls   int64
+	Last    time.Duration
+	Max     time.Duration
+	Average time.Duration
+}
+
+// Latency describes the round trips to Slack, and the time spent in handlers, so that
+// Slack slowness can be told from handler slowness.
+type Latency struct {
+	// Round trip time of the last RTM ping
+	RTM time.Duration
+	// Average duration of all Web API calls, and of the calls of each method
+	API       time.Duration
+	APIMethod map[string]MethodLatency
+	// Average duration of handlers
+	Handler time.Duration
+}
+
+// botMetrics records the activity of the bot.
+type botMetrics struct {
+	mu      sync.Mutex
+	m       Metrics
+	methods map[string]MethodLatency
+}
+
+// Metrics returns a snapshot of the activity of the bot since it was created.
+func (b *Bot) Metrics() Metrics {
+	b.metrics.mu.Lock()
+	defer b.metrics.mu.Unlock()
+	return b.metrics.m
+}
+
+// Latency returns the latency of Slack and of the handlers since the bot was created.
+func (b *Bot) Latency() Latency {
+	b.metrics.mu.Lock()
+	defer b.metrics.mu.Unlock()
+	m := b.metrics.m
+	l := Latency{RTM: m.RTMLatency, APIMethod: make(map[string]MethodLatency, len(b.metrics.methods))}
+	if m.APICalls > 0 {
+		l.API = m.APITime / time.Duration(m.APICalls)
+	}
+	if m.HandlerRuns > 0 {
+		l.Handler = m.HandlerTime / time.Duration(m.HandlerRuns)
+	}
+	for method, ml := range b.metrics.methods {
+		l.APIMethod[method] = ml
+	}
+	return l
+}
+
+// recordCall records the duration of a Web API call.
+func (m *botMetrics) recordCall(method string, d time.Duration, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.APICalls++
+	m.m.APITime += d
+	if !ok {
+		m.m.APIErrors++
+	}
+	if m.methods == nil {
+		m.methods = make(map[string]MethodLatency)
+	}
+	ml := m.methods[method]
+	ml.Average = (ml.Average*time.Duration(ml.Calls) + d) / time.Duration(ml.Calls+1)
+	ml.Calls++
+	ml.Last = d
+	if d > ml.Max {
+		ml.Max = d
+	}
+	m.methods[method] = ml
+}
+
+func (m *botMetrics) update(fn func(*Metrics)) {
+	m.mu.Lock()
+	fn(&m.m)
+	m.mu.Unlock()
+}
+
+// metricsTransport times the Web API calls made through it, and counts their failures, be
+// they HTTP errors or responses with "ok": false.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *botMetrics
+}
+
+func newMetricsClient(metrics *botMetrics) *http.Client {
+	return &http.Client{Transport: &metricsTransport{next: http.DefaultTransport, metrics: metrics}}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	ok := err == nil && resp.StatusCode == http.StatusOK
+	if ok && strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var body []byte
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		var status struct {
+			Ok bool `json:"ok"`
+		}
+		ok = err == nil && json.Unmarshal(body, &status) == nil && status.Ok
+	}
+	t.metrics.recordCall(strings.TrimPrefix(req.URL.Path, "/api/"), time.Since(start), ok)
+	return resp, err
+}
+
+// timeHandler records how long the handler runs.
+func (b *Bot) timeHandler(handler Handler) Handler {
+	return func(ctx context.Context) {
+		start := time.Now()
+		defer func() {
+			d := time.Since(start)
+			b.metrics.update(func(m *Metrics) {
+				m.HandlerRuns++
+				m.HandlerTime += d
+			})
+		}()
+		handler(ctx)
+	}
+}
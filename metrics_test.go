@@ -0,0 +1,65 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsTransport(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/fail":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+		case "/api/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok": true}`))
+		}
+	}))
+	defer server.Close()
+
+	bot := New("")
+	assert.Equal(0.0, bot.Metrics().APIErrorRate())
+	for _, path := range []string{"/api/ok", "/api/ok", "/api/ok", "/api/fail", "/api/down"} {
+		resp, err := bot.httpClient.Get(server.URL + path)
+		assert.NoError(err)
+		resp.Body.Close()
+	}
+	m := bot.Metrics()
+	assert.Equal(int64(5), m.APICalls)
+	assert.Equal(int64(2), m.APIErrors)
+	assert.Equal(0.4, m.APIErrorRate())
+	l := bot.Latency()
+	assert.Equal(int64(3), l.APIMethod["ok"].Calls)
+	assert.Equal(int64(1), l.APIMethod["fail"].Calls)
+	assert.Equal(int64(1), l.APIMethod["down"].Calls)
+	assert.True(l.API > 0)
+}
+
+func TestLatency(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	for _, d := range []time.Duration{10, 30, 20} {
+		bot.metrics.recordCall("chat.postMessage", d*time.Millisecond, true)
+	}
+	bot.metrics.recordCall("users.info", 60*time.Millisecond, true)
+	l := bot.Latency()
+	assert.Equal(30*time.Millisecond, l.API)
+	assert.Equal(MethodLatency{Calls: 3, Last: 20 * time.Millisecond, Max: 30 * time.Millisecond, Average: 20 * time.Millisecond}, l.APIMethod["chat.postMessage"])
+
+	// the snapshot is not changed by later calls
+	bot.metrics.recordCall("users.info", time.Second, false)
+	assert.Equal(int64(1), l.APIMethod["users.info"].Calls)
+
+	bot.timeHandler(func(ctx context.Context) { time.Sleep(10 * time.Millisecond) })(context.Background())
+	assert.Equal(int64(1), bot.Metrics().HandlerRuns)
+	assert.True(bot.Latency().Handler >= 10*time.Millisecond)
+}
@@ -0,0 +1,234 @@
+package slackbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// Verdict is the result of classifying a message.
+type Verdict struct {
+	Flagged bool   `json:"flagged"`
+	Reason  string `json:"reason"`
+	// Offending terms found in the message, if known
+	Terms []string `json:"terms"`
+}
+
+// Classifier decides whether a message violates the content policy.
+type Classifier interface {
+	Classify(ctx context.Context, text string) (Verdict, error)
+}
+
+// ClassifierFunc adapts a function to a Classifier.
+type ClassifierFunc func(ctx context.Context, text string) (Verdict, error)
+
+func (f ClassifierFunc) Classify(ctx context.Context, text string) (Verdict, error) {
+	return f(ctx, text)
+}
+
+// WordlistClassifier flags messages containing any of the words, ignoring case.
+func WordlistClassifier(words ...string) Classifier {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	re := regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	return ClassifierFunc(func(ctx context.Context, text string) (Verdict, error) {
+		terms := re.FindAllString(text, -1)
+		if len(terms) == 0 {
+			return Verdict{}, nil
+		}
+		return Verdict{Flagged: true, Reason: "wordlist", Terms: terms}, nil
+	})
+}
+
+// APIClassifier classifies messages with a moderation endpoint, receiving the JSON of
+// {"text": ...} and answering with the JSON of a Verdict.
+type APIClassifier struct {
+	URL string
+	// Headers added to requests, e.g. Authorization
+	Header http.Header
+	// HTTP client, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func (c *APIClassifier) Classify(ctx context.Context, text string) (Verdict, error) {
+	var v Verdict
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return v, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return v, err
+	}
+	for k, values := range c.Header {
+		req.Header[k] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return v, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return v, fmt.Errorf("slackbot: moderation %s: %s", c.URL, resp.Status)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&v)
+	return v, err
+}
+
+// ModerationAction is what happens to messages flagged by the classifier. Actions combine.
+type ModerationAction int
+
+const (
+	// ModerationBlock stops the message from being handled.
+	ModerationBlock ModerationAction = 1 << iota
+	// ModerationDeleteQuotes deletes the recent bot messages quoting the flagged message.
+	ModerationDeleteQuotes
+	// ModerationNotify posts the flagged message to the moderator channel.
+	ModerationNotify
+)
+
+// ModerationOptions configures the moderation of incoming messages.
+type ModerationOptions struct {
+	Classifier Classifier
+	Actions    ModerationAction
+	// Channel notified of flagged messages with ModerationNotify
+	ModeratorChannel string
+	// Reply to blocked messages, none when empty
+	BlockedText string
+}
+
+// ModerationNotifyText is posted to the moderator channel, formatted with the author,
+// channel, reason and text of the flagged message.
+var ModerationNotifyText = ":warning: Message from <@%s> in <#%s> flagged (%s):\n%s"
+
+// maxRecentSent is the number of bot messages checked for quotes of flagged messages.
+const maxRecentSent = 100
+
+// moderation tracks the recent messages of the bot which may quote flagged messages.
+type moderation struct {
+	mu   sync.Mutex
+	sent []sentMessage
+	// channels exempt from filtering, where moderators are notified
+	exempt map[string]bool
+}
+
+type sentMessage struct {
+	channel, ts, text string
+}
+
+// Moderate classifies every incoming message, and applies the actions of the options to
+// those flagged before routing.
+func (b *Bot) Moderate(opts ModerationOptions) *Bot {
+	b.moderationOpts = &opts
+	return b
+}
+
+// Moderation returns a middleware classifying the messages of the route, and applying the
+// actions of the options to those flagged.
+func Moderation(opts ModerationOptions) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) {
+			if BotFromContext(ctx).moderate(ctx, &opts, MessageFromContext(ctx)) {
+				next(ctx)
+			}
+		}
+	}
+}
+
+// moderate classifies the message and returns false if it is blocked.
+func (b *Bot) moderate(ctx context.Context, opts *ModerationOptions, evt *slack.MessageEvent) bool {
+	m := b.moderationState()
+	if opts.ModeratorChannel != "" {
+		m.mu.Lock()
+		m.exempt[opts.ModeratorChannel] = true
+		m.mu.Unlock()
+	}
+	if evt == nil || evt.Text == "" || evt.Channel == opts.ModeratorChannel {
+		return true
+	}
+	v, err := opts.Classifier.Classify(ctx, evt.Text)
+	if err != nil {
+		b.HandleError(ctx, err)
+		return true
+	}
+	if !v.Flagged {
+		return true
+	}
+
+	if opts.Actions&ModerationNotify != 0 && opts.ModeratorChannel != "" {
+		text := fmt.Sprintf(ModerationNotifyText, evt.User, evt.Channel, v.Reason, quoteText(evt.Text))
+		if _, err := b.Send(&OutgoingMessage{Channel: opts.ModeratorChannel, Text: text}); err != nil {
+			fmt.Printf("Error notifying moderators: %s\n", err)
+		}
+	}
+	if opts.Actions&ModerationDeleteQuotes != 0 {
+		b.deleteQuotes(evt.Text)
+	}
+	if opts.Actions&ModerationBlock != 0 {
+		if opts.BlockedText != "" {
+			b.Reply(evt, opts.BlockedText)
+		}
+		return false
+	}
+	return true
+}
+
+// moderationState returns the moderation state, registering the hook recording the bot
+// messages the first time.
+func (b *Bot) moderationState() *moderation {
+	b.moderationOnce.Do(func() {
+		b.moderation = &moderation{exempt: make(map[string]bool)}
+		b.AfterSend(func(msg *OutgoingMessage, ts string, err error) {
+			if err != nil || ts == "" || msg.EphemeralUser != "" || msg.Timestamp != "" {
+				return
+			}
+			m := b.moderation
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.sent = append(m.sent, sentMessage{msg.Channel, ts, msg.Text})
+			if len(m.sent) > maxRecentSent {
+				m.sent = m.sent[len(m.sent)-maxRecentSent:]
+			}
+		})
+	})
+	return b.moderation
+}
+
+// deleteQuotes deletes the recent bot messages quoting the flagged message, outside of
+// exempt channels.
+func (b *Bot) deleteQuotes(flagged string) {
+	flagged = strings.ToLower(flagged)
+	m := b.moderationState()
+	m.mu.Lock()
+	var quoting []sentMessage
+	kept := m.sent[:0]
+	for _, s := range m.sent {
+		if !m.exempt[s.channel] && strings.Contains(strings.ToLower(s.text), flagged) {
+			quoting = append(quoting, s)
+		} else {
+			kept = append(kept, s)
+		}
+	}
+	m.sent = kept
+	m.mu.Unlock()
+
+	for _, s := range quoting {
+		if _, _, err := b.Client.DeleteMessage(s.channel, s.ts); err != nil {
+			fmt.Printf("Error deleting quoted message: %s\n", err)
+		}
+	}
+}
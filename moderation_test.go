@@ -0,0 +1,61 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordlistClassifier(t *testing.T) {
+	assert := assert.New(t)
+	c := WordlistClassifier("darn", "heck")
+	v, err := c.Classify(context.Background(), "Darn it, what the heck")
+	assert.NoError(err)
+	assert.True(v.Flagged)
+	assert.Equal([]string{"Darn", "heck"}, v.Terms)
+
+	v, _ = c.Classify(context.Background(), "darning socks")
+	assert.False(v.Flagged)
+}
+
+func TestModerate(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.postMessage", `{"ok": true, "channel": "C1", "ts": "1.0"}`, `{"ok": true, "channel": "C2", "ts": "2.0"}`,
+		`{"ok": true, "channel": "CMOD", "ts": "3.0"}`, `{"ok": true, "channel": "C1", "ts": "4.0"}`)
+	opts := &ModerationOptions{
+		Classifier:       WordlistClassifier("darn"),
+		Actions:          ModerationBlock | ModerationDeleteQuotes | ModerationNotify,
+		ModeratorChannel: "CMOD",
+		BlockedText:      "Please keep it civil.",
+	}
+	bot.moderationState()
+
+	// bot messages sent before the flagged message
+	for _, msg := range []*OutgoingMessage{
+		{Channel: "C1", Text: "You said: DARN deploys"},
+		{Channel: "C2", Text: "darn, the build failed"},
+	} {
+		_, err := bot.Send(msg)
+		assert.NoError(err)
+	}
+
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Text: "darn deploys"}}
+	assert.True(bot.moderate(context.Background(), opts, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Text: "deploy"}}))
+	assert.False(bot.moderate(context.Background(), opts, evt))
+	texts := api.values("chat.postMessage", "text")
+	if assert.Len(texts, 4) {
+		assert.Contains(texts[2], "> darn deploys")
+		assert.Equal("Please keep it civil.", texts[3])
+	}
+	assert.Equal([]string{"CMOD", "C1"}, api.values("chat.postMessage", "channel")[2:])
+	// only the message quoting the flagged one is deleted, not the moderator notification
+	assert.Equal([]string{"1.0"}, api.values("chat.delete", "ts"))
+
+	// later messages are not filtered
+	_, err := bot.Send(&OutgoingMessage{Channel: "C1", Text: "You said: darn deploys"})
+	assert.NoError(err)
+}
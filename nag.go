@@ -0,0 +1,203 @@
+package slackbot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// NagText reminds a user of an unanswered question.
+var NagText = "Reminder: I'm still waiting for your answer."
+
+// NagOptions configures how a user is reminded of an unanswered question.
+type NagOptions struct {
+	// Delay without an answer before each reminder, an hour when zero
+	After time.Duration
+	// Reminders sent before escalating, 3 when zero
+	MaxAttempts int
+	// Text of the reminders, NagText when empty
+	Text string
+	// Channel, or user, told when the user never answers, none when empty
+	EscalateTo string
+	// Called when the user never answers, after escalating
+	OnEscalate func(n *Nag)
+}
+
+// Nag reminds a user of a question of the bot until they answer it.
+type Nag struct {
+	bot  *Bot
+	opts NagOptions
+	// Question, the thread answers are expected in, and the user asked
+	channel string
+	ts      string
+	thread  string
+	user    string
+
+	mu       sync.Mutex
+	attempts int
+	timer    *time.Timer
+	done     bool
+	answered chan struct{}
+}
+
+// nags are the pending Nags, by channel.
+type nags struct {
+	mu      sync.Mutex
+	pending map[string][]*Nag
+}
+
+// NagUntilAnswered reminds the user of the question of the bot, the message with ts in the
+// channel, when they do not answer it in time. Messages of the user in the thread of the
+// question, or in the channel outside threads, answer it. Once MaxAttempts reminders went
+// unanswered, the EscalateTo channel or user is told.
+func (b *Bot) NagUntilAnswered(channel, ts, user string, opts NagOptions) *Nag {
+	return b.startNag(channel, ts, "", user, opts)
+}
+
+// AskAndNag replies to the message with the question, in its thread if it is in one, and
+// reminds its sender until they answer, see NagUntilAnswered.
+func (b *Bot) AskAndNag(evt *slack.MessageEvent, question string, opts NagOptions) (*Nag, error) {
+	ts, err := b.Send(&OutgoingMessage{
+		Channel: evt.Channel,
+		Text:    question,
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: evt.ThreadTimestamp},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.startNag(evt.Channel, ts, evt.ThreadTimestamp, evt.User, opts), nil
+}
+
+func (b *Bot) startNag(channel, ts, thread, user string, opts NagOptions) *Nag {
+	if opts.After == 0 {
+		opts.After = time.Hour
+	}
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.Text == "" {
+		opts.Text = NagText
+	}
+	n := &Nag{bot: b, opts: opts, channel: channel, ts: ts, thread: thread, user: user, answered: make(chan struct{})}
+
+	b.nags.mu.Lock()
+	if b.nags.pending == nil {
+		b.nags.pending = make(map[string][]*Nag)
+	}
+	b.nags.pending[channel] = append(b.nags.pending[channel], n)
+	b.nags.mu.Unlock()
+
+	n.mu.Lock()
+	n.timer = time.AfterFunc(opts.After, n.remind)
+	n.mu.Unlock()
+	return n
+}
+
+// Answered is closed once the user answered.
+func (n *Nag) Answered() <-chan struct{} {
+	return n.answered
+}
+
+// Stop stops reminding the user.
+func (n *Nag) Stop() {
+	n.finish(false)
+}
+
+func (n *Nag) finish(answered bool) bool {
+	n.mu.Lock()
+	if n.done {
+		n.mu.Unlock()
+		return false
+	}
+	n.done = true
+	n.timer.Stop()
+	n.mu.Unlock()
+	if answered {
+		close(n.answered)
+	}
+
+	b := n.bot
+	b.nags.mu.Lock()
+	defer b.nags.mu.Unlock()
+	pending := b.nags.pending[n.channel][:0]
+	for _, other := range b.nags.pending[n.channel] {
+		if other != n {
+			pending = append(pending, other)
+		}
+	}
+	if len(pending) == 0 {
+		delete(b.nags.pending, n.channel)
+	} else {
+		b.nags.pending[n.channel] = pending
+	}
+	return true
+}
+
+// remind pings the user in the thread of the question, or escalates after the last attempt.
+func (n *Nag) remind() {
+	n.mu.Lock()
+	if n.done {
+		n.mu.Unlock()
+		return
+	}
+	n.attempts++
+	escalate := n.attempts > n.opts.MaxAttempts
+	if !escalate {
+		n.timer.Reset(n.opts.After)
+	}
+	n.mu.Unlock()
+
+	if escalate {
+		if n.finish(false) {
+			n.escalate()
+		}
+		return
+	}
+	thread := n.thread
+	if thread == "" {
+		thread = n.ts
+	}
+	_, _ = n.bot.Send(&OutgoingMessage{
+		Channel: n.channel,
+		Text:    fmt.Sprintf("<@%s> %s", n.user, n.opts.Text),
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: thread},
+	})
+}
+
+func (n *Nag) escalate() {
+	if n.opts.EscalateTo != "" {
+		link, err := n.bot.Permalink(n.channel, n.ts)
+		if err != nil {
+			link = fmt.Sprintf("<#%s>", n.channel)
+		}
+		text := fmt.Sprintf("<@%s> did not answer %s after %d reminders.", n.user, link, n.opts.MaxAttempts)
+		if strings.HasPrefix(n.opts.EscalateTo, "U") || strings.HasPrefix(n.opts.EscalateTo, "W") {
+			if res := n.bot.DM(n.opts.EscalateTo, text); res.Err != nil {
+				fmt.Printf("Error escalating unanswered question: %s\n", res.Err)
+			}
+		} else if _, err := n.bot.Send(&OutgoingMessage{Channel: n.opts.EscalateTo, Text: text, Params: slack.PostMessageParameters{AsUser: true}}); err != nil {
+			fmt.Printf("Error escalating unanswered question: %s\n", err)
+		}
+	}
+	if n.opts.OnEscalate != nil {
+		n.opts.OnEscalate(n)
+	}
+}
+
+// resolveNags stops the Nags the message answers.
+func (b *Bot) resolveNags(evt *slack.MessageEvent) {
+	b.nags.mu.Lock()
+	var answered []*Nag
+	for _, n := range b.nags.pending[evt.Channel] {
+		if n.user == evt.User && (evt.ThreadTimestamp == n.ts || evt.ThreadTimestamp == n.thread) {
+			answered = append(answered, n)
+		}
+	}
+	b.nags.mu.Unlock()
+	for _, n := range answered {
+		n.finish(true)
+	}
+}
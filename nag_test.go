@@ -0,0 +1,51 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNagUntilAnswered(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	sent := make(chan *OutgoingMessage, 10)
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		sent <- msg
+		return false
+	})
+
+	n := bot.NagUntilAnswered("C1", "1.0", "U1", NagOptions{After: 10 * time.Millisecond})
+	msg := <-sent
+	assert.Equal("<@U1> "+NagText, msg.Text)
+	assert.Equal("1.0", msg.Params.ThreadTimestamp)
+
+	ctx := AddBotToContext(context.Background(), bot)
+	bot.handleMessage(ctx, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U2", ThreadTimestamp: "1.0", Text: "not me"}})
+	bot.handleMessage(ctx, &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", ThreadTimestamp: "1.0", Text: "yes"}})
+	<-n.Answered()
+}
+
+func TestNagEscalates(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	sent := make(chan *OutgoingMessage, 10)
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		sent <- msg
+		return false
+	})
+	escalated := make(chan *Nag, 1)
+	n := bot.NagUntilAnswered("C1", "1.0", "U1", NagOptions{
+		After:       5 * time.Millisecond,
+		MaxAttempts: 2,
+		Text:        "Ping?",
+		OnEscalate:  func(n *Nag) { escalated <- n },
+	})
+	assert.Equal("<@U1> Ping?", (<-sent).Text)
+	assert.Equal("<@U1> Ping?", (<-sent).Text)
+	assert.Equal(n, <-escalated)
+	assert.Len(bot.nags.pending, 0)
+}
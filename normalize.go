@@ -0,0 +1,62 @@
+package slackbot
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Normalizer transforms incoming message text before it is matched against routes.
+type Normalizer func(text string) string
+
+var whitespaceRegexp = regexp.MustCompile(`\s+`)
+
+var entityReplacer = strings.NewReplacer(
+	"&amp;", "&",
+	"&lt;", "<",
+	"&gt;", ">",
+	"‘", "'",
+	"’", "'",
+	"“", `"`,
+	"”", `"`,
+	"\u00a0", " ",
+)
+
+// Normalizers applied by DefaultNormalization, in order.
+var (
+	TrimSpace          Normalizer = strings.TrimSpace
+	CollapseWhitespace Normalizer = func(text string) string { return whitespaceRegexp.ReplaceAllString(text, " ") }
+	DecodeEntities     Normalizer = entityReplacer.Replace
+	StripMention       Normalizer = StripDirectMention
+	Lowercase          Normalizer = strings.ToLower
+)
+
+// DefaultNormalization is a sensible pipeline, lowercasing excepted.
+var DefaultNormalization = []Normalizer{StripMention, DecodeEntities, CollapseWhitespace, TrimSpace}
+
+// Normalize sets the pipeline applied to incoming text before matching. Hear patterns are
+// then matched against the normalized text, also available through TextFromContext.
+func (b *Bot) Normalize(normalizers ...Normalizer) *Bot {
+	b.normalizers = normalizers
+	return b
+}
+
+// normalize runs the text through the normalizers in order.
+func normalize(text string, normalizers []Normalizer) string {
+	for _, n := range normalizers {
+		text = n(text)
+	}
+	return text
+}
+
+// TextFromContext returns the text routes are matched against: the normalized message text
+// when a pipeline is configured, the text without a leading mention otherwise.
+func TextFromContext(ctx context.Context) string {
+	if result, ok := ctx.Value(TEXT_CONTEXT).(string); ok {
+		return result
+	}
+	if msg := MessageFromContext(ctx); msg != nil {
+		return StripDirectMention(msg.Text)
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert := assert.New(t)
+
+	pairs := []string{
+		"<@USOMEUSER>:  deploy   &amp; ship ", "deploy & ship",
+		"it’s  “done”", `it's "done"`,
+		"   \n  ", "",
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		assert.Equal(pairs[i+1], normalize(pairs[i], DefaultNormalization))
+	}
+
+	assert.Equal("deploy", normalize(" DEPLOY ", []Normalizer{TrimSpace, Lowercase}))
+}
@@ -0,0 +1,109 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Origin describes what a message sent by the bot was replying to.
+type Origin struct {
+	// Name of the route which handled the message, see Route.Name
+	Route string `json:"route,omitempty"`
+	// The message replied to
+	Channel   string `json:"channel"`
+	User      string `json:"user"`
+	MessageTS string `json:"message_ts"`
+	// Correlation ID of the conversation, if the bot correlates messages
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	SentAt        time.Time `json:"sent_at"`
+}
+
+// TrackOrigins records, for ttl, the origin of the replies of the bot, so later events
+// about them, e.g. reactions, thread replies or button clicks, can be attributed to the
+// workflow which sent them with OriginOf.
+func (b *Bot) TrackOrigins(ttl time.Duration) *Bot {
+	b.originTTL = ttl
+	return b.AfterSend(func(msg *OutgoingMessage, ts string, err error) {
+		if err != nil || ts == "" || msg.Origin == nil {
+			return
+		}
+		if data, err := json.Marshal(msg.Origin); err == nil {
+			_ = b.Store().Set(originKey(msg.Channel, ts), data, ttl)
+		}
+	})
+}
+
+func originKey(channel, ts string) string {
+	return "origin:" + channel + ":" + ts
+}
+
+// OriginOf returns the origin of the message of the bot the event in context relates to:
+// the message reacted to, the thread replied to, or the message holding the button clicked.
+func OriginOf(ctx context.Context) (*Origin, bool) {
+	bot := BotFromContext(ctx)
+	if bot == nil {
+		return nil, false
+	}
+	if msg := MessageFromContext(ctx); msg != nil {
+		if origin, ok := bot.OriginOfMessage(msg.Channel, msg.Timestamp); ok {
+			return origin, true
+		}
+		if msg.ThreadTimestamp != "" {
+			return bot.OriginOfMessage(msg.Channel, msg.ThreadTimestamp)
+		}
+	}
+	if callback := InteractionFromContext(ctx); callback != nil {
+		return bot.OriginOfMessage(callback.Channel.ID, callback.Message.Timestamp)
+	}
+	return nil, false
+}
+
+// OriginOfMessage returns the origin of a message sent by the bot, if still recorded.
+func (b *Bot) OriginOfMessage(channel, ts string) (*Origin, bool) {
+	data, found, err := b.Store().Get(originKey(channel, ts))
+	if err != nil || !found {
+		return nil, false
+	}
+	var origin Origin
+	if err := json.Unmarshal(data, &origin); err != nil {
+		return nil, false
+	}
+	return &origin, true
+}
+
+// attachOrigin sets the origin of a reply to the message event.
+func (b *Bot) attachOrigin(msg *OutgoingMessage, evt *slack.MessageEvent) {
+	if b.originTTL == 0 {
+		return
+	}
+	origin := &Origin{
+		Channel:   evt.Channel,
+		User:      evt.User,
+		MessageTS: evt.Timestamp,
+		SentAt:    time.Now(),
+	}
+	if route, ok := b.handling.Load(evt); ok {
+		origin.Route = route.(string)
+	}
+	if data, found, err := b.Store().Get(correlationKey(evt.Channel, evt.Timestamp)); err == nil && found {
+		origin.CorrelationID = string(data)
+	}
+	msg.Origin = origin
+	// the ts of messages sent through RTM is unknown
+	msg.RTM = false
+}
+
+// trackRoute records the name of the route handling the message event while it runs.
+func (b *Bot) trackRoute(evt *slack.MessageEvent, route *Route, handler Handler) Handler {
+	if b.originTTL == 0 || route == nil || route.name == "" {
+		return handler
+	}
+	return func(ctx context.Context) {
+		b.handling.Store(evt, route.name)
+		defer b.handling.Delete(evt)
+		handler(ctx)
+	}
+}
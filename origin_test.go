@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrigins(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").TrackOrigins(time.Hour)
+	route := bot.Hear("deploy").Name("deploy")
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0"}}
+
+	reply := &OutgoingMessage{Channel: "C1", RTM: true}
+	bot.trackRoute(evt, route, func(ctx context.Context) {
+		bot.attachOrigin(reply, evt)
+	})(context.Background())
+	assert.False(reply.RTM)
+	bot.afterSend[0](reply, "2.0", nil)
+
+	ctx := AddBotToContext(context.Background(), bot)
+	answer := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Timestamp: "3.0", ThreadTimestamp: "2.0"}}
+	origin, ok := OriginOf(AddMessageToContext(ctx, answer))
+	assert.True(ok)
+	assert.Equal("deploy", origin.Route)
+	assert.Equal("U1", origin.User)
+	assert.Equal("1.0", origin.MessageTS)
+
+	_, ok = OriginOf(AddMessageToContext(ctx, evt))
+	assert.False(ok)
+}
@@ -0,0 +1,132 @@
+package slackbot
+
+import (
+	"errors"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrMessageCancelled is returned when a BeforeSend hook cancels an outgoing message.
+var ErrMessageCancelled = errors.New("slackbot: outgoing message cancelled")
+
+// OutgoingMessage describes a message sent, or edited, by the bot. Hooks may modify it.
+type OutgoingMessage struct {
+	Channel string
+	// Timestamp of the message being edited, empty for new messages
+	Timestamp   string
+	Text        string
+	Attachments []slack.Attachment
+	Blocks      []slack.Block
+	// Parameters of chat.postMessage, unused for edits and RTM messages. Markdown and
+	// UnfurlMedia are ignored, being indistinguishable from their zero value, set the
+	// fields below instead.
+	Params slack.PostMessageParameters
+	// Link previews and formatting of the text, Slack defaults when nil
+	UnfurlLinks *bool
+	UnfurlMedia *bool
+	Markdown    *bool
+	// Messages sent through RTM, when connected, only support Channel and Text
+	RTM bool
+	// What to do if Text is too long, see Bot.MessageOverflow
+	Overflow Overflow
+	// User the message is shown to, for ephemeral messages
+	EphemeralUser string
+	// Machine-readable data attached to the message, unsupported by ephemeral messages
+	Metadata *MessageMetadata
+	// What the message replies to, recorded when the bot tracks origins
+	Origin *Origin
+	// Join the channel and retry if the bot is not a member, see Bot.JoinOnSend
+	JoinChannel bool
+}
+
+// SendHook inspects or modifies an outgoing message. Returning false cancels it.
+type SendHook func(msg *OutgoingMessage) bool
+
+// SentHook observes the result of sending a message.
+type SentHook func(msg *OutgoingMessage, ts string, err error)
+
+// BeforeSend registers a hook run, in registration order, before every outgoing message.
+func (b *Bot) BeforeSend(hook SendHook) *Bot {
+	b.beforeSend = append(b.beforeSend, hook)
+	return b
+}
+
+// AfterSend registers a hook run after every outgoing message, including failed ones.
+func (b *Bot) AfterSend(hook SentHook) *Bot {
+	b.afterSend = append(b.afterSend, hook)
+	return b
+}
+
+// Send sends or edits a message after running the BeforeSend hooks, and returns its ts.
+// Errors are wrapped with WrapError.
+func (b *Bot) Send(msg *OutgoingMessage) (string, error) {
+	for _, hook := range b.beforeSend {
+		if !hook(msg) {
+			return "", ErrMessageCancelled
+		}
+	}
+
+	ts, err := b.send(msg)
+	ts, err = b.joinAndRetry(msg, ts, WrapError(err))
+	for _, hook := range b.afterSend {
+		hook(msg, ts, err)
+	}
+	return ts, err
+}
+
+func (b *Bot) send(msg *OutgoingMessage) (string, error) {
+	if b.overflows(msg) {
+		return b.sendOverflow(msg)
+	}
+	return b.sendMessage(msg)
+}
+
+func (b *Bot) sendMessage(msg *OutgoingMessage) (string, error) {
+	// without an RTM connection, e.g. with the Events API, messages are posted instead
+	if msg.RTM && msg.EphemeralUser == "" && msg.Metadata == nil && b.RTM != nil {
+		b.RTM.SendMessage(b.RTM.NewOutgoingMessage(msg.Text, msg.Channel))
+		return "", nil
+	}
+
+	if msg.Metadata != nil && msg.EphemeralUser == "" {
+		return b.sendWithMetadata(msg)
+	}
+
+	var opts []slack.MsgOption
+	if msg.Text != "" {
+		opts = append(opts, slack.MsgOptionText(msg.Text, false))
+	}
+	if len(msg.Attachments) > 0 {
+		opts = append(opts, slack.MsgOptionAttachments(msg.Attachments...))
+	}
+	if len(msg.Blocks) > 0 {
+		opts = append(opts, slack.MsgOptionBlocks(msg.Blocks...))
+	}
+	if msg.UnfurlLinks != nil {
+		if *msg.UnfurlLinks {
+			opts = append(opts, slack.MsgOptionEnableLinkUnfurl())
+		} else {
+			opts = append(opts, slack.MsgOptionDisableLinkUnfurl())
+		}
+	}
+	if msg.UnfurlMedia != nil && !*msg.UnfurlMedia {
+		opts = append(opts, slack.MsgOptionDisableMediaUnfurl())
+	}
+	if msg.Markdown != nil && !*msg.Markdown {
+		opts = append(opts, slack.MsgOptionDisableMarkdown())
+	}
+
+	params := msg.Params
+	params.Markdown, params.UnfurlMedia = true, true
+	if msg.EphemeralUser != "" {
+		opts = append([]slack.MsgOption{slack.MsgOptionPostMessageParameters(params)}, opts...)
+		return b.Client.PostEphemeral(msg.Channel, msg.EphemeralUser, opts...)
+	}
+	if msg.Timestamp != "" {
+		_, ts, _, err := b.Client.UpdateMessage(msg.Channel, msg.Timestamp, opts...)
+		return ts, err
+	}
+	opts = append([]slack.MsgOption{slack.MsgOptionPostMessageParameters(params)}, opts...)
+	_, ts, err := b.Client.PostMessage(msg.Channel, opts...)
+	return ts, err
+}
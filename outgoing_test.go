@@ -0,0 +1,50 @@
+package slackbot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendHooks(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	var sent []string
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		msg.Text = strings.ToUpper(msg.Text)
+		return true
+	}).BeforeSend(func(msg *OutgoingMessage) bool {
+		return msg.Channel != "CBLOCKED"
+	}).AfterSend(func(msg *OutgoingMessage, ts string, err error) {
+		sent = append(sent, msg.Channel+" "+ts)
+	})
+
+	ts, err := bot.Send(&OutgoingMessage{Channel: "C1", Text: "hello"})
+	assert.NoError(err)
+	assert.Equal("1.0", ts)
+	_, err = bot.Send(&OutgoingMessage{Channel: "CBLOCKED", Text: "hello"})
+	assert.True(errors.Is(err, ErrMessageCancelled))
+
+	assert.Equal([]string{"chat.postMessage HELLO"}, api.calls("text"))
+	assert.Equal([]string{"C1 1.0"}, sent)
+}
+
+func TestSendEdit(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	var failed error
+	bot.AfterSend(func(msg *OutgoingMessage, ts string, err error) { failed = err })
+
+	_, err := bot.Send(&OutgoingMessage{Channel: "C1", Timestamp: "0.5", Text: "edited"})
+	assert.NoError(err)
+	assert.Equal([]string{"chat.update 0.5"}, api.calls("ts"))
+
+	api.respond("chat.postMessage", `{"ok": false, "error": "is_archived"}`)
+	_, err = bot.Send(&OutgoingMessage{Channel: "C1", Text: "hello"})
+	assert.Error(err)
+	assert.Equal(err, failed)
+}
@@ -0,0 +1,153 @@
+package slackbot
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxMessageLength is the length, in characters, above which outgoing messages
+// overflow. Slack truncates longer messages.
+const DefaultMaxMessageLength = 4000
+
+// minMessageLength is the shortest maximum length messages are split to: enough for a code
+// block reopened and closed around at least one character.
+const minMessageLength = 2*(len(codeFence)+1) + 1
+
+// Overflow selects what happens to outgoing messages whose text is too long.
+type Overflow int
+
+const (
+	// OverflowDefault uses the mode set with Bot.MessageOverflow, splitting by default.
+	OverflowDefault Overflow = iota
+	// OverflowSplit sends the text as several messages, cut at line boundaries. Code blocks
+	// cut in two are closed and reopened.
+	OverflowSplit
+	// OverflowSnippet uploads the text as a snippet instead.
+	OverflowSnippet
+)
+
+// MessageOverflow sets how outgoing messages longer than maxLength characters are sent,
+// unless set per message. A maxLength of 0 keeps DefaultMaxMessageLength, and lengths too
+// short to split code blocks are raised to the minimum. Edits are never split.
+func (b *Bot) MessageOverflow(mode Overflow, maxLength int) *Bot {
+	if maxLength > 0 && maxLength < minMessageLength {
+		maxLength = minMessageLength
+	}
+	b.overflow = mode
+	b.maxMessageLength = maxLength
+	return b
+}
+
+// overflows returns true if the text of the message is too long to be sent as is.
+func (b *Bot) overflows(msg *OutgoingMessage) bool {
+	return msg.Timestamp == "" && utf8.RuneCountInString(msg.Text) > b.maxLength()
+}
+
+func (b *Bot) maxLength() int {
+	if b.maxMessageLength > 0 {
+		return b.maxMessageLength
+	}
+	return DefaultMaxMessageLength
+}
+
+// sendOverflow sends a message too long for Slack, and returns the ts of the first
+// message sent.
+func (b *Bot) sendOverflow(msg *OutgoingMessage) (string, error) {
+	mode := msg.Overflow
+	if mode == OverflowDefault {
+		mode = b.overflow
+	}
+
+	if mode == OverflowSnippet {
+		err := b.uploadSnippet(msg.Channel, msg.Params.ThreadTimestamp, "", msg.Text, "text")
+		if err != nil || len(msg.Attachments) == 0 && len(msg.Blocks) == 0 {
+			return "", err
+		}
+		rest := *msg
+		rest.Text = ""
+		return b.sendMessage(&rest)
+	}
+
+	var first string
+	chunks := splitText(msg.Text, b.maxLength())
+	for i, chunk := range chunks {
+		part := *msg
+		part.Text = chunk
+		if i < len(chunks)-1 {
+			part.Attachments, part.Blocks = nil, nil
+		}
+		ts, err := b.sendMessage(&part)
+		if err != nil {
+			return first, err
+		}
+		if i == 0 {
+			first = ts
+		}
+	}
+	return first, nil
+}
+
+const codeFence = "```"
+
+// splitText cuts the text in chunks of at most limit characters, at line boundaries when
+// possible. Code blocks spanning two chunks are closed at the end of the first one and
+// reopened at the start of the next. Limits below minMessageLength are raised to it.
+func splitText(text string, limit int) []string {
+	if limit < minMessageLength {
+		limit = minMessageLength
+	}
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	var cur strings.Builder
+	opening, inCode := 0, false
+	// room for the fence closing a code block
+	max := limit - len(codeFence) - 1
+
+	flush := func() {
+		chunk := strings.TrimSuffix(cur.String(), "\n")
+		if inCode {
+			chunk += "\n" + codeFence
+		}
+		chunks = append(chunks, chunk)
+		cur.Reset()
+		opening = 0
+		if inCode {
+			cur.WriteString(codeFence + "\n")
+			opening = cur.Len()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		fences := strings.Count(line, codeFence)
+		for line != "" {
+			room := max - utf8.RuneCountInString(cur.String())
+			if utf8.RuneCountInString(line) <= room {
+				cur.WriteString(line)
+				break
+			}
+			if cur.Len() > opening {
+				flush()
+				continue
+			}
+			// the line alone is too long, cut it
+			cut := 0
+			for i := 0; i < room; i++ {
+				_, size := utf8.DecodeRuneInString(line[cut:])
+				cut += size
+			}
+			cur.WriteString(line[:cut])
+			line = line[cut:]
+			flush()
+		}
+		if fences%2 == 1 {
+			inCode = !inCode
+		}
+	}
+	if cur.Len() > opening {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
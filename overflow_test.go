@@ -0,0 +1,39 @@
+package slackbot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitText(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"short"}, splitText("short", 20))
+	assert.Equal([]string{"first line", "second line", "third"}, splitText("first line\nsecond line\nthird", 16))
+
+	chunks := splitText("intro\n```\nline one\nline two\n```\nend", 24)
+	assert.Equal([]string{"intro\n```\nline one\n```", "```\nline two\n```\nend"}, chunks)
+
+	chunks = splitText(strings.Repeat("é", 30), 14)
+	assert.Equal([]string{strings.Repeat("é", 10), strings.Repeat("é", 10), strings.Repeat("é", 10)}, chunks)
+	for _, c := range chunks {
+		assert.True(len([]rune(c)) <= 14, c)
+	}
+}
+
+func TestSplitTextTinyLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	text := "```\nsome code\n```"
+	for _, limit := range []int{-1, 0, 1, 4, minMessageLength} {
+		chunks := splitText(text, limit)
+		assert.True(len(chunks) > 1, "limit %d", limit)
+		for _, c := range chunks {
+			assert.True(len([]rune(c)) <= minMessageLength, c)
+		}
+	}
+	assert.Equal(minMessageLength, New("").MessageOverflow(OverflowSplit, 2).maxLength())
+	assert.Equal(DefaultMaxMessageLength, New("").MessageOverflow(OverflowSplit, 0).maxLength())
+}
@@ -0,0 +1,55 @@
+package slackbot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// permalinkCacheTTL is how long permalinks are kept in the Store. They never change, the TTL
+// only bounds the size of the store.
+const permalinkCacheTTL = 7 * 24 * time.Hour
+
+// Permalink returns the permanent URL of the message posted at ts in the channel. Permalinks
+// are cached in the bot Store.
+func (b *Bot) Permalink(channel, ts string) (string, error) {
+	key := "permalink:" + channel + ":" + ts
+	if link, found, err := b.Store().Get(key); err == nil && found {
+		return string(link), nil
+	}
+	link, err := b.Client.GetPermalink(&slack.PermalinkParameters{Channel: channel, Ts: ts})
+	if err != nil {
+		return "", err
+	}
+	if err := b.Store().Set(key, []byte(link), permalinkCacheTTL); err != nil {
+		fmt.Printf("Error caching permalink: %s\n", err)
+	}
+	return link, nil
+}
+
+// CrossPost posts a quote of the message to another channel, attributed to its author and
+// followed by a link to the original, and returns the ts of the new message.
+func (b *Bot) CrossPost(msg *slack.MessageEvent, channel string) (string, error) {
+	return b.Send(&OutgoingMessage{
+		Channel: channel,
+		Text:    b.quote(msg),
+		Params:  slack.PostMessageParameters{AsUser: true},
+	})
+}
+
+// quote renders the message as a quote attributed to its author, with its permalink when
+// it can be resolved.
+func (b *Bot) quote(msg *slack.MessageEvent) string {
+	text := fmt.Sprintf("<@%s> in <#%s>:\n%s", msg.User, msg.Channel, quoteText(msg.Text))
+	if link, err := b.Permalink(msg.Channel, msg.Timestamp); err == nil {
+		text += "\n<" + link + "|View original>"
+	}
+	return text
+}
+
+// quoteText formats text as a Slack quote.
+func quoteText(text string) string {
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
@@ -0,0 +1,38 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossPost(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.getPermalink", `{"ok": true, "permalink": "https://team.slack.com/archives/C1/p15"}`)
+
+	msg := &slack.MessageEvent{}
+	msg.Channel, msg.User, msg.Text, msg.Timestamp = "C1", "U1", "Outage\nin eu-west", "1.5"
+	_, err := bot.CrossPost(msg, "CINCIDENTS")
+	assert.NoError(err)
+	_, err = bot.CrossPost(msg, "COPS")
+	assert.NoError(err)
+
+	text := "<@U1> in <#C1>:\n> Outage\n> in eu-west\n<https://team.slack.com/archives/C1/p15|View original>"
+	assert.Equal([]string{text, text}, api.values("chat.postMessage", "text"))
+	// the permalink is resolved once
+	assert.Equal([]string{"1.5"}, api.values("chat.getPermalink", "message_ts"))
+}
+
+func TestCrossPostWithoutPermalink(t *testing.T) {
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.getPermalink", `{"ok": false, "error": "message_not_found"}`)
+
+	msg := &slack.MessageEvent{}
+	msg.Channel, msg.User, msg.Text = "C1", "U1", "Outage"
+	bot.CrossPost(msg, "COPS")
+	assert.Equal(t, []string{"<@U1> in <#C1>:\n> Outage"}, api.values("chat.postMessage", "text"))
+}
@@ -0,0 +1,133 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+var (
+	// ErrUnknownPref is returned when setting a preference which was not defined.
+	ErrUnknownPref = errors.New("slackbot: unknown preference")
+	// ErrInvalidPref is returned when setting a preference to a value which is not allowed.
+	ErrInvalidPref = errors.New("slackbot: invalid preference value")
+)
+
+type prefSpec struct {
+	def     string
+	allowed []string
+}
+
+// DefinePref declares a user preference, its default value and, optionally, the values
+// users may choose from. Undefined preferences cannot be set.
+func (b *Bot) DefinePref(key, def string, allowed ...string) *Bot {
+	b.prefsMu.Lock()
+	defer b.prefsMu.Unlock()
+	if b.prefs == nil {
+		b.prefs = make(map[string]prefSpec)
+	}
+	b.prefs[strings.ToLower(key)] = prefSpec{def: def, allowed: allowed}
+	return b
+}
+
+func (b *Bot) prefSpec(key string) (prefSpec, bool) {
+	b.prefsMu.Lock()
+	defer b.prefsMu.Unlock()
+	spec, ok := b.prefs[strings.ToLower(key)]
+	return spec, ok
+}
+
+// Pref returns the preference of the author of the message in context, or its default
+// value when unset.
+func Pref(ctx context.Context, key string) string {
+	spec, _ := BotFromContext(ctx).prefSpec(key)
+	var value string
+	if found, err := RecallIn(ctx, PerUser, prefKey(key), &value); err != nil || !found {
+		return spec.def
+	}
+	return value
+}
+
+// SetPref sets the preference of the author of the message in context. An empty value
+// restores the default.
+func SetPref(ctx context.Context, key, value string) error {
+	spec, ok := BotFromContext(ctx).prefSpec(key)
+	if !ok {
+		return ErrUnknownPref
+	}
+	if value == "" {
+		return ForgetIn(ctx, PerUser, prefKey(key))
+	}
+	if len(spec.allowed) > 0 && !containsFold(spec.allowed, value) {
+		return fmt.Errorf("%w, one of: %s", ErrInvalidPref, strings.Join(spec.allowed, ", "))
+	}
+	return RememberIn(ctx, PerUser, prefKey(key), value)
+}
+
+func prefKey(key string) string {
+	return "pref:" + strings.ToLower(key)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnablePrefCommands registers commands letting users manage their preferences:
+// "set <key> <value>", "unset <key>" and "prefs".
+func (b *Bot) EnablePrefCommands() *Bot {
+	b.Hear(prefSetRegexp).MessageHandler(prefSetHandler)
+	b.Hear(prefUnsetRegexp).MessageHandler(prefUnsetHandler)
+	b.Hear(`(?i)^prefs$`).MessageHandler(prefListHandler)
+	return b
+}
+
+const (
+	prefSetRegexp   = `(?i)^set (\S+) (\S+)$`
+	prefUnsetRegexp = `(?i)^unset (\S+)$`
+)
+
+func prefSetHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	args := submatches(prefSetRegexp, TextFromContext(ctx))
+	if err := SetPref(ctx, args[1], args[2]); err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not set `%s`: %s", args[1], err))
+		return
+	}
+	bot.Reply(evt, fmt.Sprintf("`%s` is now `%s`.", args[1], args[2]))
+}
+
+func prefUnsetHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	args := submatches(prefUnsetRegexp, TextFromContext(ctx))
+	if err := SetPref(ctx, args[1], ""); err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not unset `%s`: %s", args[1], err))
+		return
+	}
+	bot.Reply(evt, fmt.Sprintf("`%s` is back to `%s`.", args[1], Pref(ctx, args[1])))
+}
+
+func prefListHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	bot.prefsMu.Lock()
+	keys := make([]string, 0, len(bot.prefs))
+	for key := range bot.prefs {
+		keys = append(keys, key)
+	}
+	bot.prefsMu.Unlock()
+	if len(keys) == 0 {
+		bot.Reply(evt, "No preferences defined.")
+		return
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("`%s`: `%s`", key, Pref(ctx, key))
+	}
+	bot.Reply(evt, strings.Join(lines, "\n"))
+}
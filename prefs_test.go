@@ -0,0 +1,29 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefs(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").DefinePref("notify", "on", "on", "off").DefinePref("nickname", "")
+	ctx := AddBotToContext(context.Background(), bot)
+	ctx = AddMessageToContext(ctx, &slack.MessageEvent{Msg: slack.Msg{User: "U1", Channel: "C1"}})
+
+	assert.Equal("on", Pref(ctx, "notify"))
+	assert.NoError(SetPref(ctx, "notify", "off"))
+	assert.Equal("off", Pref(ctx, "notify"))
+	assert.True(errors.Is(SetPref(ctx, "notify", "maybe"), ErrInvalidPref))
+	assert.Equal(ErrUnknownPref, SetPref(ctx, "color", "blue"))
+
+	assert.NoError(SetPref(ctx, "nickname", "ally"))
+	assert.Equal("ally", Pref(ctx, "nickname"))
+
+	assert.NoError(SetPref(ctx, "notify", ""))
+	assert.Equal("on", Pref(ctx, "notify"))
+}
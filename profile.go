@@ -0,0 +1,111 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// UserProfile is a Slack user profile with its custom fields decoded, keyed by label,
+// e.g. "Team", "Manager" or "Location".
+type UserProfile struct {
+	*slack.UserProfile
+	Fields map[string]string
+}
+
+// Field returns the value of a custom field, matching its label case-insensitively.
+func (p *UserProfile) Field(label string) string {
+	for l, v := range p.Fields {
+		if strings.EqualFold(l, label) {
+			return v
+		}
+	}
+	return ""
+}
+
+// UserProfile returns the profile of a user with the custom fields defined by the workspace.
+func (b *Bot) UserProfile(ctx context.Context, userID string) (*UserProfile, error) {
+	b.RequireScopes("users.profile:read")
+	var resp struct {
+		Profile *slack.UserProfile `json:"profile"`
+	}
+	err := b.callAPI(ctx, "users.profile.get", url.Values{
+		"user":           {userID},
+		"include_labels": {"true"},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &UserProfile{UserProfile: resp.Profile, Fields: make(map[string]string)}
+	for id, f := range resp.Profile.Fields.ToMap() {
+		label := f.Label
+		if label == "" {
+			label = id
+		}
+		profile.Fields[label] = f.Value
+	}
+	return profile, nil
+}
+
+// SetUserStatus sets the status of the user owning the user token, e.g. (":construction:",
+// "Deploying"). An empty text clears the status. Slack only lets user tokens granted the
+// users.profile:write user scope set a status, so bot users have none: the status is
+// typically set on behalf of the user who installed the app.
+func (b *Bot) SetUserStatus(ctx context.Context, userToken, emoji, text string) error {
+	profile, err := json.Marshal(map[string]interface{}{
+		"status_emoji":      emoji,
+		"status_text":       text,
+		"status_expiration": 0,
+	})
+	if err != nil {
+		return err
+	}
+	var resp struct{}
+	return b.callAPIWithToken(ctx, userToken, "users.profile.set", url.Values{"profile": {string(profile)}}, &resp)
+}
+
+// callAPI calls a Web API method the slack package does not cover and decodes the
+// response into v.
+func (b *Bot) callAPI(ctx context.Context, method string, values url.Values, v interface{}) error {
+	return b.callAPIWithToken(ctx, b.token, method, values, v)
+}
+
+// callAPIWithToken calls a Web API method with another token than the bot token.
+func (b *Bot) callAPIWithToken(ctx context.Context, token, method string, values url.Values, v interface{}) error {
+	values.Set("token", token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slack.APIURL+method, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", method, resp.Status)
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+	var status slack.SlackResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		return err
+	}
+	if !status.Ok {
+		if kind, ok := apiErrorKinds[status.Error]; ok {
+			return &apiError{kind: kind, err: fmt.Errorf("%s: %s", method, status.Error)}
+		}
+		return fmt.Errorf("%s: %s", method, status.Error)
+	}
+	return json.Unmarshal(body, v)
+}
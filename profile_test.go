@@ -0,0 +1,60 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserProfile(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/api/users.profile.get", r.URL.Path)
+		assert.Equal("true", r.FormValue("include_labels"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("user") != "U1" {
+			w.Write([]byte(`{"ok": false, "error": "user_not_found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "profile": {"real_name": "Ada", "fields": {
+			"Xf01": {"value": "Platform", "label": "Team"},
+			"Xf02": {"value": "Paris", "label": ""}
+		}}}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	bot := New("xoxb-token")
+	bot.httpClient = &http.Client{Transport: redirectTransport{server: serverURL}}
+
+	profile, err := bot.UserProfile(context.Background(), "U1")
+	assert.NoError(err)
+	assert.Equal("Ada", profile.RealName)
+	assert.Equal("Platform", profile.Field("team"))
+	// fields without a label are keyed by ID
+	assert.Equal("Paris", profile.Field("Xf02"))
+	assert.Equal("", profile.Field("Manager"))
+	assert.True(bot.requiredScopes["users.profile:read"])
+
+	_, err = bot.UserProfile(context.Background(), "U2")
+	assert.EqualError(err, "users.profile.get: user_not_found")
+}
+
+func TestSetUserStatus(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("xoxb-bot")
+	api := newSlackAPI(t, bot)
+
+	assert.NoError(bot.SetUserStatus(context.Background(), "xoxp-user", ":construction:", "Deploying"))
+	profiles := api.values("users.profile.set", "profile")
+	if assert.Len(profiles, 1) {
+		assert.Contains(profiles[0], `"status_emoji":":construction:"`)
+		assert.Contains(profiles[0], `"status_text":"Deploying"`)
+	}
+	assert.Equal([]string{"xoxp-user"}, api.values("users.profile.set", "token"))
+	// the user scope is not one of the bot
+	assert.False(bot.requiredScopes["users.profile:write"])
+}
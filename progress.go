@@ -0,0 +1,95 @@
+package slackbot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const progressBarWidth = 20
+
+// Progress reports the advancement of a long running task as a single progress bar
+// message that is updated in place.
+type Progress struct {
+	msg   *liveMessage
+	label string
+	total int
+	done  int
+	final string
+}
+
+// Progress posts a progress bar for a task made of total steps in reply to the message event.
+func (b *Bot) Progress(evt *slack.MessageEvent, total int) *Progress {
+	p := &Progress{total: total}
+	p.msg = newLiveMessage(b, evt.Channel, p.render)
+	p.msg.mu.Lock()
+	p.msg.updateLocked(true)
+	p.msg.mu.Unlock()
+	return p
+}
+
+// Label sets a short description displayed above the bar.
+func (p *Progress) Label(label string) *Progress {
+	p.msg.mu.Lock()
+	defer p.msg.mu.Unlock()
+	p.label = label
+	p.msg.updateLocked(false)
+	return p
+}
+
+// Set records that n steps out of total are done. Updates are throttled.
+func (p *Progress) Set(n int) error {
+	p.msg.mu.Lock()
+	defer p.msg.mu.Unlock()
+	if n > p.total {
+		n = p.total
+	}
+	if n < 0 {
+		n = 0
+	}
+	p.done = n
+	return p.msg.updateLocked(false)
+}
+
+// Done marks the task as finished, successfully when err is nil, and sends the final state.
+func (p *Progress) Done(err error) error {
+	p.msg.mu.Lock()
+	defer p.msg.mu.Unlock()
+	if err != nil {
+		p.final = fmt.Sprintf(":x: Failed: %s", err)
+	} else {
+		p.done = p.total
+		p.final = ":white_check_mark: Done"
+	}
+	return p.msg.updateLocked(true)
+}
+
+func (p *Progress) render(msg *OutgoingMessage) {
+	text := progressBar(p.done, p.total)
+	if p.final != "" {
+		text += "\n" + p.final
+	}
+	if p.label != "" {
+		text = "*" + p.label + "*\n" + text
+	}
+	section := slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+	msg.Text = text
+	msg.Blocks = []slack.Block{section}
+}
+
+// progressBar renders done/total as a fixed width text bar followed by a percentage.
+func progressBar(done, total int) string {
+	percent := 100
+	if total > 0 {
+		percent = done * 100 / total
+	}
+	filled := percent * progressBarWidth / 100
+	if filled < 0 {
+		filled = 0
+	} else if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return fmt.Sprintf("`%s%s` %d%% (%d/%d)",
+		strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled), percent, done, total)
+}
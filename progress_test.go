@@ -0,0 +1,49 @@
+package slackbot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBar(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("`░░░░░░░░░░░░░░░░░░░░` 0% (0/4)", progressBar(0, 4))
+	assert.Equal("`██████████░░░░░░░░░░` 50% (2/4)", progressBar(2, 4))
+	assert.Equal("`████████████████████` 100% (4/4)", progressBar(4, 4))
+	assert.Equal("`████████████████████` 100% (0/0)", progressBar(0, 0))
+
+	// out of range values stay within the bar
+	assert.Equal("`████████████████████` 150% (6/4)", progressBar(6, 4))
+	assert.Equal("`░░░░░░░░░░░░░░░░░░░░` -50% (-2/4)", progressBar(-2, 4))
+	assert.NotPanics(func() { progressBar(1, -4) })
+}
+
+func TestProgress(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+	p := bot.Progress(evt, 4)
+	p.msg.interval = time.Hour
+	p.Label("Deploying")
+	assert.NoError(p.Set(-1))
+	assert.NoError(p.Set(3))
+	assert.Equal(3, p.done)
+	assert.NoError(p.Set(10))
+	assert.Equal(4, p.done)
+	assert.NoError(p.Done(errors.New("timeout")))
+
+	texts := api.values("chat.update", "text")
+	if assert.Len(texts, 1) {
+		assert.True(strings.HasPrefix(texts[0], "*Deploying*\n"))
+		assert.True(strings.HasSuffix(texts[0], ":x: Failed: timeout"))
+	}
+	assert.Equal([]string{"C1"}, api.values("chat.postMessage", "channel"))
+}
@@ -0,0 +1,137 @@
+package slackbot
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// QuorumResult is the outcome of AwaitQuorum.
+type QuorumResult struct {
+	// Reached is false when the quorum timed out
+	Reached bool
+	// Users who reacted, in reaction order
+	Users []string
+}
+
+// quorum counts the distinct users reacting with an emoji to a message.
+type quorum struct {
+	emoji  string
+	needed int
+	users  []string
+	result chan QuorumResult
+	timer  *time.Timer
+	done   bool
+}
+
+// quorums indexes the pending quorums by message.
+type quorums struct {
+	mu      sync.Mutex
+	pending map[string][]*quorum
+}
+
+// AwaitQuorum resolves once n distinct users, other than the bot, reacted to the message
+// with the emoji, or when the timeout elapses. Removing a reaction withdraws the vote. The
+// result is sent on the returned channel, which is then closed.
+//
+//	ts, _ := bot.Send(&slackbot.OutgoingMessage{Channel: channel, Text: "Deploy v2? React with :+1: to approve."})
+//	if result := <-bot.AwaitQuorum(channel, ts, "+1", 2, time.Hour); result.Reached {
+//		deploy()
+//	}
+func (b *Bot) AwaitQuorum(channel, ts, emoji string, n int, timeout time.Duration) <-chan QuorumResult {
+	b.RequireScopes("reactions:read")
+	q := &quorum{emoji: strings.Trim(emoji, ":"), needed: n, result: make(chan QuorumResult, 1)}
+	key := messageKey(channel, ts)
+
+	b.quorums.mu.Lock()
+	defer b.quorums.mu.Unlock()
+	if b.quorums.pending == nil {
+		b.quorums.pending = make(map[string][]*quorum)
+	}
+	b.quorums.pending[key] = append(b.quorums.pending[key], q)
+	if n <= 0 {
+		b.quorums.resolveLocked(key, q, true)
+		return q.result
+	}
+	q.timer = time.AfterFunc(timeout, func() {
+		b.quorums.mu.Lock()
+		defer b.quorums.mu.Unlock()
+		b.quorums.resolveLocked(key, q, false)
+	})
+	return q.result
+}
+
+// resolveLocked sends the result of the quorum and forgets it. The caller must hold mu.
+func (qs *quorums) resolveLocked(key string, q *quorum, reached bool) {
+	if q.done {
+		return
+	}
+	q.done = true
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	q.result <- QuorumResult{Reached: reached, Users: append([]string(nil), q.users...)}
+	close(q.result)
+
+	pending := qs.pending[key][:0]
+	for _, other := range qs.pending[key] {
+		if other != q {
+			pending = append(pending, other)
+		}
+	}
+	if len(pending) == 0 {
+		delete(qs.pending, key)
+	} else {
+		qs.pending[key] = pending
+	}
+}
+
+// countVote updates the quorums of the message a reaction was added to, or removed from.
+func (b *Bot) countVote(evt interface{}) {
+	var itemType, channel, ts, user, emoji string
+	added := false
+	switch reaction := evt.(type) {
+	case *slack.ReactionAddedEvent:
+		itemType, channel, ts = reaction.Item.Type, reaction.Item.Channel, reaction.Item.Timestamp
+		user, emoji, added = reaction.User, reaction.Reaction, true
+	case *slack.ReactionRemovedEvent:
+		itemType, channel, ts = reaction.Item.Type, reaction.Item.Channel, reaction.Item.Timestamp
+		user, emoji = reaction.User, reaction.Reaction
+	default:
+		return
+	}
+	if itemType != "message" || user == b.botUserID {
+		return
+	}
+
+	key := messageKey(channel, ts)
+	b.quorums.mu.Lock()
+	defer b.quorums.mu.Unlock()
+	for _, q := range append([]*quorum(nil), b.quorums.pending[key]...) {
+		if q.emoji != emoji {
+			continue
+		}
+		q.users = withUser(q.users, user, added)
+		if len(q.users) >= q.needed {
+			b.quorums.resolveLocked(key, q, true)
+		}
+	}
+}
+
+// withUser adds the user to, or removes it from, the users, keeping them distinct.
+func withUser(users []string, user string, add bool) []string {
+	for i, u := range users {
+		if u == user {
+			if add {
+				return users
+			}
+			return append(users[:i], users[i+1:]...)
+		}
+	}
+	if add {
+		users = append(users, user)
+	}
+	return users
+}
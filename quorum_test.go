@@ -0,0 +1,42 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitQuorum(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+	react := func(user, emoji string, added bool) {
+		if added {
+			evt := &slack.ReactionAddedEvent{User: user, Reaction: emoji}
+			evt.Item.Type, evt.Item.Channel, evt.Item.Timestamp = "message", "C1", "1.0"
+			bot.handleEvent(ctx, "reaction_added", evt)
+			return
+		}
+		evt := &slack.ReactionRemovedEvent{User: user, Reaction: emoji}
+		evt.Item.Type, evt.Item.Channel, evt.Item.Timestamp = "message", "C1", "1.0"
+		bot.handleEvent(ctx, "reaction_removed", evt)
+	}
+
+	result := bot.AwaitQuorum("C1", "1.0", ":+1:", 2, time.Minute)
+	react("U1", "+1", true)
+	react("U1", "+1", true)
+	react("U2", "eyes", true)
+	react("U1", "+1", false)
+	react("U2", "+1", true)
+	react("U3", "+1", true)
+	assert.Equal(QuorumResult{Reached: true, Users: []string{"U2", "U3"}}, <-result)
+
+	result = bot.AwaitQuorum("C1", "1.0", "+1", 3, 10*time.Millisecond)
+	react("U1", "+1", true)
+	assert.Equal(QuorumResult{Reached: false, Users: []string{"U1"}}, <-result)
+	_, open := <-result
+	assert.False(open)
+}
@@ -0,0 +1,90 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// ReactionCommandHandler handles a reaction command. msg is the message of the bot reacted
+// to, and original the message it replied to, nil unless the bot tracks origins.
+type ReactionCommandHandler func(ctx context.Context, bot *Bot, reaction *slack.ReactionAddedEvent, msg, original *slack.MessageEvent)
+
+// reactionCommands are the handlers of reaction commands, by emoji.
+type reactionCommands struct {
+	mu       sync.Mutex
+	handlers map[string]ReactionCommandHandler
+	once     sync.Once
+}
+
+// OnReactionCommand registers a handler called when a user adds the emoji to any message
+// of the bot, e.g. to retry, delete or expand it without buttons. The reacted message is
+// fetched, and with TrackOrigins the message it replied to as well, which is then the
+// message in context:
+//
+//	bot.TrackOrigins(24 * time.Hour)
+//	bot.OnReactionCommand(":wastebasket:", func(ctx context.Context, bot *slackbot.Bot, reaction *slack.ReactionAddedEvent, msg, original *slack.MessageEvent) {
+//		bot.Client.DeleteMessage(msg.Channel, msg.Timestamp)
+//	})
+func (b *Bot) OnReactionCommand(emoji string, handler ReactionCommandHandler) *Bot {
+	b.reactionCommands.mu.Lock()
+	if b.reactionCommands.handlers == nil {
+		b.reactionCommands.handlers = make(map[string]ReactionCommandHandler)
+	}
+	b.reactionCommands.handlers[strings.Trim(emoji, ":")] = handler
+	b.reactionCommands.mu.Unlock()
+
+	b.reactionCommands.once.Do(func() {
+		b.RequireScopes("reactions:read", "channels:history")
+		b.OnEvent("reaction_added", func(ctx context.Context, bot *Bot, evt interface{}) {
+			if reaction, ok := evt.(*slack.ReactionAddedEvent); ok {
+				bot.runReactionCommand(ctx, reaction)
+			}
+		})
+	})
+	return b
+}
+
+// ReactionCommands returns the emoji of the registered reaction commands.
+func (b *Bot) ReactionCommands() []string {
+	b.reactionCommands.mu.Lock()
+	defer b.reactionCommands.mu.Unlock()
+	emoji := make([]string, 0, len(b.reactionCommands.handlers))
+	for e := range b.reactionCommands.handlers {
+		emoji = append(emoji, e)
+	}
+	return emoji
+}
+
+func (b *Bot) runReactionCommand(ctx context.Context, reaction *slack.ReactionAddedEvent) {
+	if reaction.Item.Type != "message" || reaction.ItemUser != b.botUserID || reaction.User == b.botUserID {
+		return
+	}
+	b.reactionCommands.mu.Lock()
+	handler := b.reactionCommands.handlers[reaction.Reaction]
+	b.reactionCommands.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	msg, err := b.FetchMessage(reaction.Item.Channel, reaction.Item.Timestamp)
+	if err != nil {
+		fmt.Printf("Error fetching reacted message: %s\n", err)
+		return
+	}
+	var original *slack.MessageEvent
+	if origin, ok := b.OriginOfMessage(msg.Channel, msg.Timestamp); ok {
+		if original, err = b.FetchMessage(origin.Channel, origin.MessageTS); err != nil {
+			fmt.Printf("Error fetching original message: %s\n", err)
+		}
+	}
+	if original != nil {
+		ctx = AddMessageToContext(ctx, original)
+	} else {
+		ctx = AddMessageToContext(ctx, msg)
+	}
+	handler(ctx, b, reaction, msg, original)
+}
@@ -0,0 +1,43 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactionCommand(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botUserID = "UBOT"
+	store := bot.Store()
+	for _, msg := range []*slack.MessageEvent{
+		{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0", Text: "weather paris"}},
+		{Msg: slack.Msg{Channel: "C1", User: "UBOT", Timestamp: "2.0", Text: "sunny"}},
+	} {
+		data, _ := json.Marshal(msg)
+		assert.NoError(store.Set("message:"+msg.Channel+":"+msg.Timestamp, data, 0))
+	}
+	origin, _ := json.Marshal(Origin{Channel: "C1", User: "U1", MessageTS: "1.0"})
+	assert.NoError(store.Set(originKey("C1", "2.0"), origin, time.Minute))
+
+	var got []string
+	bot.OnReactionCommand(":recycle:", func(ctx context.Context, bot *Bot, reaction *slack.ReactionAddedEvent, msg, original *slack.MessageEvent) {
+		got = append(got, msg.Text, original.Text, MessageFromContext(ctx).Text)
+	})
+	assert.Equal([]string{"recycle"}, bot.ReactionCommands())
+
+	react := func(emoji, itemUser string) {
+		evt := &slack.ReactionAddedEvent{User: "U1", Reaction: emoji, ItemUser: itemUser}
+		evt.Item.Type, evt.Item.Channel, evt.Item.Timestamp = "message", "C1", "2.0"
+		bot.handleEvent(AddBotToContext(context.Background(), bot), "reaction_added", evt)
+	}
+	react("recycle", "U2")
+	react("eyes", "UBOT")
+	react("recycle", "UBOT")
+	assert.Equal([]string{"sunny", "weather paris", "weather paris"}, got)
+}
@@ -0,0 +1,47 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// ReactionHandler handles a reaction added to a message, resolved along with the reaction.
+type ReactionHandler func(ctx context.Context, bot *Bot, reaction *slack.ReactionAddedEvent, msg *slack.MessageEvent)
+
+// NewRegexpMatcher returns a Matcher of the message text against a regular expression.
+func NewRegexpMatcher(regex string) *RegexpMatcher {
+	return &RegexpMatcher{regex: regex}
+}
+
+// OnReactionTo registers a handler called when the emoji is added to a message accepted by
+// the matcher, a nil matcher accepting all messages. The reacted message is fetched, and
+// cached, so the handler and the matcher see its content; it is also available through
+// MessageFromContext.
+//
+//	bot.OnReactionTo(slackbot.NewRegexpMatcher("(?i)error"), ":eyes:", TriageHandler)
+func (b *Bot) OnReactionTo(matcher Matcher, emoji string, handler ReactionHandler) *Bot {
+	b.RequireScopes("reactions:read", "channels:history")
+	emoji = strings.Trim(emoji, ":")
+	return b.OnEvent("reaction_added", func(ctx context.Context, bot *Bot, evt interface{}) {
+		reaction, ok := evt.(*slack.ReactionAddedEvent)
+		if !ok || reaction.Reaction != emoji || reaction.Item.Type != "message" {
+			return
+		}
+		msg, err := bot.FetchMessage(reaction.Item.Channel, reaction.Item.Timestamp)
+		if err != nil {
+			fmt.Printf("Error fetching reacted message: %s\n", err)
+			return
+		}
+		ctx = AddMessageToContext(ctx, msg)
+		if matcher != nil {
+			var matched bool
+			if matched, ctx = matcher.Match(ctx); !matched {
+				return
+			}
+		}
+		handler(ctx, bot, reaction, msg)
+	})
+}
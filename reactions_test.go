@@ -0,0 +1,58 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func reactionAdded(emoji, channel, ts string) *slack.ReactionAddedEvent {
+	var evt slack.ReactionAddedEvent
+	data := `{"type": "reaction_added", "user": "U2", "reaction": "` + emoji + `", "item": {"type": "message", "channel": "` + channel + `", "ts": "` + ts + `"}}`
+	_ = json.Unmarshal([]byte(data), &evt)
+	return &evt
+}
+
+func TestOnReactionTo(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": true, "messages": [{"type": "message", "user": "U1", "text": "Error: disk full", "ts": "1.5"}]}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var triaged []string
+	bot.OnReactionTo(NewRegexpMatcher("(?i)error"), ":eyes:", func(ctx context.Context, bot *Bot, reaction *slack.ReactionAddedEvent, msg *slack.MessageEvent) {
+		assert.Equal(msg, MessageFromContext(ctx))
+		triaged = append(triaged, reaction.User+" "+msg.Channel+" "+msg.Text)
+	})
+
+	bot.handleEvent(ctx, "reaction_added", reactionAdded("eyes", "C1", "1.5"))
+	bot.handleEvent(ctx, "reaction_added", reactionAdded("eyes", "C1", "1.5"))
+	bot.handleEvent(ctx, "reaction_added", reactionAdded("tada", "C1", "1.5"))
+	assert.Equal([]string{"U2 C1 Error: disk full", "U2 C1 Error: disk full"}, triaged)
+	// the message is fetched once
+	assert.Len(api.calls(""), 1)
+
+	// messages which the matcher rejects are ignored
+	api.respond("conversations.history", `{"ok": true, "messages": [{"type": "message", "text": "All good", "ts": "2.5"}]}`)
+	bot.handleEvent(ctx, "reaction_added", reactionAdded("eyes", "C1", "2.5"))
+	assert.Len(triaged, 2)
+}
+
+func TestFetchThreadReply(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": true, "messages": [{"type": "message", "text": "Parent", "ts": "1.0"}]}`)
+	api.respond("conversations.replies", `{"ok": true, "messages": [{"type": "message", "text": "Reply", "ts": "1.2", "thread_ts": "1.0"}]}`)
+
+	msg, err := bot.FetchMessage("C1", "1.2")
+	assert.NoError(err)
+	assert.Equal("Reply", msg.Text)
+	assert.Equal("C1", msg.Channel)
+
+	_, err = bot.FetchMessage("C1", "9.9")
+	assert.Error(err)
+}
@@ -0,0 +1,47 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/slack-go/slack"
+)
+
+// RelayTransform renders a relayed message, returning false to skip it.
+type RelayTransform func(bot *Bot, msg *slack.MessageEvent) (string, bool)
+
+// Relay mirrors the messages posted in channels whose name matches the pattern to another
+// channel. A nil transform quotes the message with its author and permalink. Messages posted
+// by bots, including relays by other instances, and messages of the target channel are never
+// relayed, which prevents loops.
+func (b *Bot) Relay(fromChannelPattern, toChannel string, transform RelayTransform) *Bot {
+	b.RequireScopes("channels:read", "chat:write")
+	pattern := regexp.MustCompile(fromChannelPattern)
+	if transform == nil {
+		transform = func(bot *Bot, msg *slack.MessageEvent) (string, bool) {
+			return bot.quote(msg), true
+		}
+	}
+	return b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+		msg, ok := evt.(*slack.MessageEvent)
+		if !ok || msg.Channel == toChannel || msg.BotID != "" || msg.SubType != "" {
+			return
+		}
+		channel, err := bot.ChannelInfo(msg.Channel)
+		if err != nil || !pattern.MatchString(channel.Name) {
+			return
+		}
+		text, ok := transform(bot, msg)
+		if !ok {
+			return
+		}
+		if _, err := bot.Send(&OutgoingMessage{
+			Channel: toChannel,
+			Text:    text,
+			Params:  slack.PostMessageParameters{AsUser: true},
+		}); err != nil {
+			fmt.Printf("Error relaying message: %s\n", err)
+		}
+	})
+}
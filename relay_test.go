@@ -0,0 +1,47 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func relayMessage(channel, text string) *slack.MessageEvent {
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = channel, "U1", text
+	return evt
+}
+
+func TestRelay(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.info", `{"ok": true, "channel": {"id": "C1", "name": "incident-db"}}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	bot.Relay("^incident-", "CSUMMARY", func(bot *Bot, msg *slack.MessageEvent) (string, bool) {
+		return "relayed: " + msg.Text, msg.Text != "skip"
+	})
+
+	bot.handleMessage(ctx, relayMessage("C1", "DB is back"))
+	bot.handleMessage(ctx, relayMessage("C1", "skip"))
+	// messages of bots and of the target channel are not relayed
+	fromBot := relayMessage("C1", "relayed: DB is back")
+	fromBot.BotID = "B1"
+	bot.handleMessage(ctx, fromBot)
+	bot.handleMessage(ctx, relayMessage("CSUMMARY", "thanks"))
+	assert.Equal([]string{"CSUMMARY relayed: DB is back"}, relayed(api))
+
+	api.respond("conversations.info", `{"ok": true, "channel": {"id": "C2", "name": "random"}}`)
+	bot.handleMessage(ctx, relayMessage("C2", "lunch?"))
+	assert.Len(relayed(api), 1)
+}
+
+func relayed(api *slackAPI) []string {
+	channels, texts := api.values("chat.postMessage", "channel"), api.values("chat.postMessage", "text")
+	for i := range channels {
+		channels[i] += " " + texts[i]
+	}
+	return channels
+}
@@ -0,0 +1,92 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// Namespace selects who shares the values remembered by handlers.
+type Namespace int
+
+const (
+	// PerUser values belong to the author of the message in context.
+	PerUser Namespace = iota
+	// PerChannel values are shared by everyone in the channel of the message in context.
+	PerChannel
+	// PerTeam values are shared by the whole workspace.
+	PerTeam
+)
+
+// ErrNoNamespace is returned when the context lacks the user or channel of a namespace.
+var ErrNoNamespace = errors.New("slackbot: no user or channel in context")
+
+// Remember stores val, encoded as JSON, for the author of the message in context. Values are
+// kept in the bot Store, scoped to the workspace.
+func Remember(ctx context.Context, key string, val interface{}) error {
+	return RememberIn(ctx, PerUser, key, val)
+}
+
+// Recall decodes into val the value remembered for the author of the message in context,
+// and returns false if there is none.
+func Recall(ctx context.Context, key string, val interface{}) (bool, error) {
+	return RecallIn(ctx, PerUser, key, val)
+}
+
+// Forget deletes the value remembered for the author of the message in context.
+func Forget(ctx context.Context, key string) error {
+	return ForgetIn(ctx, PerUser, key)
+}
+
+// RememberIn is like Remember, in the given namespace.
+func RememberIn(ctx context.Context, ns Namespace, key string, val interface{}) error {
+	k, err := memoryKey(ctx, ns, key)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return TeamStore(ctx).Set(k, data, 0)
+}
+
+// RecallIn is like Recall, in the given namespace.
+func RecallIn(ctx context.Context, ns Namespace, key string, val interface{}) (bool, error) {
+	k, err := memoryKey(ctx, ns, key)
+	if err != nil {
+		return false, err
+	}
+	data, found, err := TeamStore(ctx).Get(k)
+	if err != nil || !found {
+		return false, err
+	}
+	return true, json.Unmarshal(data, val)
+}
+
+// ForgetIn is like Forget, in the given namespace.
+func ForgetIn(ctx context.Context, ns Namespace, key string) error {
+	k, err := memoryKey(ctx, ns, key)
+	if err != nil {
+		return err
+	}
+	return TeamStore(ctx).Delete(k)
+}
+
+// memoryKey returns the Store key of a remembered value.
+func memoryKey(ctx context.Context, ns Namespace, key string) (string, error) {
+	if ns == PerTeam {
+		return "memory:team:" + key, nil
+	}
+	msg := MessageFromContext(ctx)
+	if msg == nil {
+		return "", ErrNoNamespace
+	}
+	if ns == PerChannel {
+		return "memory:channel:" + msg.Channel + ":" + key, nil
+	}
+	if msg.User == "" {
+		return "", ErrNoNamespace
+	}
+	return "memory:user:" + msg.User + ":" + key, nil
+}
@@ -0,0 +1,45 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemember(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+
+	message := func(user, channel string) context.Context {
+		evt := &slack.MessageEvent{Msg: slack.Msg{User: user, Channel: channel}}
+		return AddMessageToContext(ctx, evt)
+	}
+	alice, bob := message("U1", "C1"), message("U2", "C1")
+
+	assert.NoError(Remember(alice, "count", 3))
+	var count int
+	found, err := Recall(alice, "count", &count)
+	assert.NoError(err)
+	assert.True(found)
+	assert.Equal(3, count)
+
+	found, err = Recall(bob, "count", &count)
+	assert.NoError(err)
+	assert.False(found)
+
+	assert.NoError(RememberIn(alice, PerChannel, "topic", "release"))
+	var topic string
+	found, _ = RecallIn(bob, PerChannel, "topic", &topic)
+	assert.True(found)
+	assert.Equal("release", topic)
+
+	assert.NoError(Forget(alice, "count"))
+	found, _ = Recall(alice, "count", &count)
+	assert.False(found)
+
+	_, err = Recall(ctx, "count", &count)
+	assert.Equal(ErrNoNamespace, err)
+}
@@ -0,0 +1,147 @@
+package slackbot
+
+import (
+	"github.com/slack-go/slack"
+)
+
+// ReplyOption customizes a reply sent with Reply, ReplyPost or ReplyWithAttachments.
+type ReplyOption func(*replyConfig)
+
+type replyConfig struct {
+	typing      bool
+	typingOpts  *TypingOptions
+	thread      bool
+	broadcast   bool
+	ephemeral   bool
+	linkNames   *bool
+	unfurlLinks *bool
+	unfurlMedia *bool
+	markdown    *bool
+	metadata    *MessageMetadata
+}
+
+// Typing simulates typing before replying, as configured by the route or the bot.
+func Typing() ReplyOption {
+	return func(c *replyConfig) { c.typing = true }
+}
+
+// TypingWith simulates typing before replying, with the given options.
+func TypingWith(opts TypingOptions) ReplyOption {
+	return func(c *replyConfig) { c.typing, c.typingOpts = true, &opts }
+}
+
+// TypingIf simulates typing before replying when typing is true. It eases the migration of
+// code passing a bool to the Reply methods.
+func TypingIf(typing bool) ReplyOption {
+	return func(c *replyConfig) { c.typing = typing }
+}
+
+// InThread replies in the thread of the message, starting one if needed.
+func InThread() ReplyOption {
+	return func(c *replyConfig) { c.thread = true }
+}
+
+// Broadcast also shows the reply in the channel when replying in a thread, either because
+// the message is in a thread or with InThread. Other replies are unaffected.
+func Broadcast() ReplyOption {
+	return func(c *replyConfig) { c.broadcast = true }
+}
+
+// Ephemeral replies with a message only visible to the author of the message.
+func Ephemeral() ReplyOption {
+	return func(c *replyConfig) { c.ephemeral = true }
+}
+
+// LinkNames sets whether @names and #channels in the reply are turned into links.
+func LinkNames(enabled bool) ReplyOption {
+	return func(c *replyConfig) { c.linkNames = &enabled }
+}
+
+// UnfurlLinks sets whether Slack shows previews of the web pages linked in the reply.
+func UnfurlLinks(enabled bool) ReplyOption {
+	return func(c *replyConfig) { c.unfurlLinks = &enabled }
+}
+
+// UnfurlMedia sets whether Slack shows previews of the images and videos linked in the reply.
+func UnfurlMedia(enabled bool) ReplyOption {
+	return func(c *replyConfig) { c.unfurlMedia = &enabled }
+}
+
+// NoUnfurl disables all link previews of the reply, usually unwanted for bot output.
+func NoUnfurl() ReplyOption {
+	return func(c *replyConfig) {
+		disabled := false
+		c.unfurlLinks, c.unfurlMedia = &disabled, &disabled
+	}
+}
+
+// Markdown sets whether the reply is formatted as mrkdwn, or sent as plain text.
+func Markdown(enabled bool) ReplyOption {
+	return func(c *replyConfig) { c.markdown = &enabled }
+}
+
+// WithTyping and WithoutTyping replace the true and false arguments formerly passed to the
+// Reply methods. Code passing a bool does not compile anymore: literals are replaced with
+// these options, and variables are wrapped with TypingIf.
+//
+// Deprecated: use Typing(), or no option, instead.
+var (
+	WithTyping    ReplyOption = Typing()
+	WithoutTyping ReplyOption = TypingIf(false)
+)
+
+func newReplyConfig(opts []ReplyOption) *replyConfig {
+	c := &replyConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// typeReply simulates typing the reply, if requested.
+func (c *replyConfig) typeReply(b *Bot, evt *slack.MessageEvent, msg interface{}) {
+	if !c.typing {
+		return
+	}
+	if c.typingOpts != nil {
+		b.TypeWith(evt, msg, *c.typingOpts)
+		return
+	}
+	b.Type(evt, msg)
+}
+
+// apply sets the options on a reply to the message event. Replies needing the Web API are
+// not sent through RTM.
+func (c *replyConfig) apply(msg *OutgoingMessage, evt *slack.MessageEvent) {
+	if c.thread || c.broadcast && evt.ThreadTimestamp != "" {
+		msg.Params.ThreadTimestamp = evt.ThreadTimestamp
+		if msg.Params.ThreadTimestamp == "" {
+			msg.Params.ThreadTimestamp = evt.Timestamp
+		}
+		msg.Params.ReplyBroadcast = c.broadcast
+	}
+	if c.ephemeral {
+		msg.EphemeralUser = evt.User
+	}
+	if c.linkNames != nil {
+		msg.Params.LinkNames = 0
+		if *c.linkNames {
+			msg.Params.LinkNames = 1
+		}
+	}
+	if c.unfurlLinks != nil {
+		msg.UnfurlLinks = c.unfurlLinks
+	}
+	if c.unfurlMedia != nil {
+		msg.UnfurlMedia = c.unfurlMedia
+	}
+	if c.markdown != nil {
+		msg.Markdown = c.markdown
+	}
+	if c.metadata != nil {
+		msg.Metadata = c.metadata
+	}
+	if msg.Params.ThreadTimestamp != "" || c.ephemeral || c.metadata != nil || c.linkNames != nil || c.unfurlLinks != nil || c.unfurlMedia != nil || c.markdown != nil {
+		msg.RTM = false
+	}
+}
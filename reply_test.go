@@ -0,0 +1,47 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyOptions(t *testing.T) {
+	assert := assert.New(t)
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0"}}
+
+	msg := &OutgoingMessage{Channel: "C1", RTM: true}
+	newReplyConfig(nil).apply(msg, evt)
+	assert.True(msg.RTM)
+
+	msg = &OutgoingMessage{Channel: "C1", RTM: true}
+	newReplyConfig([]ReplyOption{Broadcast()}).apply(msg, evt)
+	assert.True(msg.RTM)
+	assert.Empty(msg.Params.ThreadTimestamp)
+	assert.False(msg.Params.ReplyBroadcast)
+
+	msg = &OutgoingMessage{Channel: "C1", RTM: true}
+	newReplyConfig([]ReplyOption{InThread(), Broadcast(), NoUnfurl()}).apply(msg, evt)
+	assert.False(msg.RTM)
+	assert.Equal("1.0", msg.Params.ThreadTimestamp)
+	assert.True(msg.Params.ReplyBroadcast)
+	assert.False(*msg.UnfurlLinks)
+	assert.False(*msg.UnfurlMedia)
+	assert.Nil(msg.Markdown)
+
+	evt.ThreadTimestamp = "0.5"
+	msg = &OutgoingMessage{Channel: "C1"}
+	newReplyConfig([]ReplyOption{Broadcast()}).apply(msg, evt)
+	assert.Equal("0.5", msg.Params.ThreadTimestamp)
+	assert.True(msg.Params.ReplyBroadcast)
+
+	msg = &OutgoingMessage{Channel: "C1"}
+	newReplyConfig([]ReplyOption{InThread(), Ephemeral(), LinkNames(false)}).apply(msg, evt)
+	assert.Equal("0.5", msg.Params.ThreadTimestamp)
+	assert.Equal("U1", msg.EphemeralUser)
+	assert.Equal(0, msg.Params.LinkNames)
+
+	assert.True(newReplyConfig([]ReplyOption{WithTyping}).typing)
+	assert.False(newReplyConfig([]ReplyOption{WithoutTyping}).typing)
+}
@@ -0,0 +1,114 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+const ROUTE_CONTEXT = "__ROUTE_CONTEXT__"
+
+// ErrorReport describes a failure of a handler, with the event and route it happened in.
+type ErrorReport struct {
+	Err error
+	// Panic is true when the handler panicked, Stack is then its stack trace
+	Panic bool
+	Stack []byte
+	// Route is the name of the route handling the message, if named
+	Route   string
+	Channel string
+	User    string
+	Team    string
+	Text    string
+}
+
+// ErrorReporter sends the failures of handlers to an error tracker.
+type ErrorReporter interface {
+	ReportError(ctx context.Context, report *ErrorReport)
+}
+
+// ErrorReporterFunc adapts a function to an ErrorReporter.
+type ErrorReporterFunc func(ctx context.Context, report *ErrorReport)
+
+func (f ErrorReporterFunc) ReportError(ctx context.Context, report *ErrorReport) {
+	f(ctx, report)
+}
+
+// SetErrorReporter sets where the errors handled by HandleError are reported.
+func (b *Bot) SetErrorReporter(r ErrorReporter) *Bot {
+	b.errorReporter = r
+	return b
+}
+
+// RecoverPanics recovers the panics of every handler, reporting them with HandleError,
+// so a failing handler does not bring the bot down.
+func (b *Bot) RecoverPanics() *Bot {
+	b.recoverPanics = true
+	return b
+}
+
+// RouteNameFromContext returns the name of the route being handled, empty if not named.
+func RouteNameFromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(ROUTE_CONTEXT).(string); ok {
+		return name
+	}
+	return ""
+}
+
+// HandleError logs an error of a handler and reports it to the error reporter, if any.
+func (b *Bot) HandleError(ctx context.Context, err error) {
+	b.handleError(ctx, &ErrorReport{Err: err})
+}
+
+func (b *Bot) handleError(ctx context.Context, report *ErrorReport) {
+	report.Route = RouteNameFromContext(ctx)
+	if msg := MessageFromContext(ctx); msg != nil {
+		report.Channel = msg.Channel
+		report.User = msg.User
+		report.Text = msg.Text
+	}
+	report.Team = TeamFromContext(ctx)
+
+	if call, ok := ctx.Value(CIRCUIT_CONTEXT).(*circuitCall); ok {
+		call.fail()
+	}
+	if call, ok := ctx.Value(IDEMPOTENCY_CONTEXT).(*idempotentCall); ok {
+		call.forget()
+	}
+	if call, ok := ctx.Value(CACHE_CONTEXT).(*cacheCall); ok {
+		call.fail()
+	}
+
+	fmt.Printf("Error handling message: %s\n", report.Err)
+	if b.errorReporter != nil {
+		b.errorReporter.ReportError(ctx, report)
+	}
+}
+
+// Recover returns a middleware recovering the panics of the handler and reporting them
+// with HandleError.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context) {
+			defer recoverHandler(ctx)
+			next(ctx)
+		}
+	}
+}
+
+func recoverHandler(ctx context.Context) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("panic: %v", r)
+	}
+	report := &ErrorReport{Err: err, Panic: true, Stack: debug.Stack()}
+	if b := BotFromContext(ctx); b != nil {
+		b.handleError(ctx, report)
+	} else {
+		fmt.Printf("Error handling message: %s\n%s", err, report.Stack)
+	}
+}
@@ -0,0 +1,32 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecover(t *testing.T) {
+	assert := assert.New(t)
+	var reports []*ErrorReport
+	bot := New("").SetErrorReporter(ErrorReporterFunc(func(ctx context.Context, report *ErrorReport) {
+		reports = append(reports, report)
+	}))
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Text: "deploy"}}
+	ctx := AddMessageToContext(AddBotToContext(context.Background(), bot), evt)
+	ctx = context.WithValue(ctx, ROUTE_CONTEXT, "deploy")
+
+	Recover()(func(ctx context.Context) {
+		panic("boom")
+	})(ctx)
+	if assert.Len(reports, 1) {
+		assert.True(reports[0].Panic)
+		assert.EqualError(reports[0].Err, "panic: boom")
+		assert.NotEmpty(reports[0].Stack)
+		assert.Equal("deploy", reports[0].Route)
+		assert.Equal("C1", reports[0].Channel)
+		assert.Equal("U1", reports[0].User)
+	}
+}
@@ -0,0 +1,203 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Document is a piece of knowledge relevant to a question.
+type Document struct {
+	Title   string  `json:"title"`
+	URL     string  `json:"url"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"`
+}
+
+// Retriever finds the documents relevant to a text, most relevant first.
+type Retriever interface {
+	Query(ctx context.Context, text string) ([]Document, error)
+}
+
+// DefaultMaxDocuments is the number of documents returned by retrievers by default.
+const DefaultMaxDocuments = 3
+
+// FileRetriever retrieves the paragraphs of the text and markdown files of a directory
+// sharing the most words with the query. Files are indexed on the first query.
+type FileRetriever struct {
+	Dir string
+	// Extensions of the files indexed, .md and .txt when empty
+	Extensions []string
+	// Maximum number of documents returned, DefaultMaxDocuments when zero
+	MaxDocuments int
+
+	mu     sync.Mutex
+	chunks []fileChunk
+}
+
+type fileChunk struct {
+	doc   Document
+	terms map[string]int
+}
+
+// Reload discards the index, so files are indexed again on the next query.
+func (r *FileRetriever) Reload() {
+	r.mu.Lock()
+	r.chunks = nil
+	r.mu.Unlock()
+}
+
+func (r *FileRetriever) Query(ctx context.Context, text string) ([]Document, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.chunks == nil {
+		if err := r.index(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := terms(text)
+	var docs []Document
+	for _, c := range r.chunks {
+		score := 0.0
+		for t := range query {
+			if c.terms[t] > 0 {
+				score += 1 + float64(c.terms[t]-1)/10
+			}
+		}
+		if score > 0 {
+			doc := c.doc
+			doc.Score = score / float64(len(query))
+			docs = append(docs, doc)
+		}
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+	return limitDocuments(docs, r.MaxDocuments), nil
+}
+
+// index splits the files in paragraphs.
+func (r *FileRetriever) index() error {
+	extensions := r.Extensions
+	if len(extensions) == 0 {
+		extensions = []string{".md", ".txt"}
+	}
+	r.chunks = []fileChunk{}
+	return filepath.WalkDir(r.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !hasExtension(path, extensions) {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(r.Dir, path)
+		for i, p := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n\n") {
+			p = strings.TrimSpace(p)
+			if p == "" {
+				continue
+			}
+			r.chunks = append(r.chunks, fileChunk{
+				doc:   Document{Title: rel + "#" + strconv.Itoa(i+1), URL: "file://" + path, Content: p},
+				terms: terms(p),
+			})
+		}
+		return nil
+	})
+}
+
+func hasExtension(path string, extensions []string) bool {
+	for _, ext := range extensions {
+		if strings.EqualFold(filepath.Ext(path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// terms counts the lowercase words of the text, ignoring words of less than 3 letters.
+func terms(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) >= 3 {
+			counts[w]++
+		}
+	}
+	return counts
+}
+
+func limitDocuments(docs []Document, max int) []Document {
+	if max == 0 {
+		max = DefaultMaxDocuments
+	}
+	if len(docs) > max {
+		docs = docs[:max]
+	}
+	return docs
+}
+
+// HTTPRetriever queries a search endpoint with GET requests, the text in the q parameter and
+// the maximum number of documents in limit. The endpoint answers with the JSON of
+// {"documents": [{"title": ..., "url": ..., "content": ..., "score": ...}]}.
+type HTTPRetriever struct {
+	URL string
+	// Headers added to requests, e.g. Authorization
+	Header http.Header
+	// Maximum number of documents returned, DefaultMaxDocuments when zero
+	MaxDocuments int
+	// HTTP client, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func (r *HTTPRetriever) Query(ctx context.Context, text string) ([]Document, error) {
+	max := r.MaxDocuments
+	if max == 0 {
+		max = DefaultMaxDocuments
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return nil, err
+	}
+	values := u.Query()
+	values.Set("q", text)
+	values.Set("limit", strconv.Itoa(max))
+	u.RawQuery = values.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("slackbot: search %s: %s", r.URL, resp.Status)
+	}
+	var result struct {
+		Documents []Document `json:"documents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return limitDocuments(result.Documents, max), nil
+}
@@ -0,0 +1,48 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRetriever(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "vpn.md"), []byte("# VPN\n\nRequest VPN access in the IT portal.\n\nThe office wifi is open."), 0o600))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "notes.bin"), []byte("vpn access"), 0o600))
+
+	r := &FileRetriever{Dir: dir}
+	docs, err := r.Query(context.Background(), "how do I get vpn access?")
+	assert.NoError(err)
+	if assert.Len(docs, 2) {
+		assert.Equal("vpn.md#2", docs[0].Title)
+		assert.Equal("Request VPN access in the IT portal.", docs[0].Content)
+		assert.Equal("vpn.md#1", docs[1].Title)
+	}
+}
+
+func TestHTTPRetriever(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("vpn", r.URL.Query().Get("q"))
+		assert.Equal("1", r.URL.Query().Get("limit"))
+		assert.Equal("Bearer t", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"documents": [{"title": "VPN", "url": "https://wiki/vpn"}, {"title": "Wifi"}]}`))
+	}))
+	defer server.Close()
+
+	r := &HTTPRetriever{URL: server.URL, Header: http.Header{"Authorization": {"Bearer t"}}, MaxDocuments: 1}
+	docs, err := r.Query(context.Background(), "vpn")
+	assert.NoError(err)
+	assert.Equal([]Document{{Title: "VPN", URL: "https://wiki/vpn"}}, docs)
+
+	answer, err := RetrievalResponder(r, nil).Respond(context.Background(), "vpn")
+	assert.NoError(err)
+	assert.Equal("These might help:\n• <https://wiki/vpn|VPN>", answer)
+}
@@ -11,6 +11,16 @@ type Route struct {
 	matchers     []Matcher
 	subrouter    Router
 	preprocessor Preprocessor
+	middlewares  []Middleware
+	aliases      map[string]string
+	usage        string
+	description  string
+	examples     []string
+	schema       Schema
+	command      *Command
+	name         string
+	admin        bool
+	cached       bool
 	botUserID    string
 }
 
@@ -25,30 +35,71 @@ func (r *Route) setBotID(botID string) {
 type RouteMatch struct {
 	Route   *Route
 	Handler Handler
+	// set when the route, or a parent route, is restricted to admins
+	admin bool
 }
 
 func (r *Route) Match(ctx context.Context, match *RouteMatch) (bool, context.Context) {
+	// a route which failed to build has no handler to run
+	if r.err != nil {
+		return false, ctx
+	}
 	if r.preprocessor != nil {
 		ctx = r.preprocessor(ctx)
 	}
+	// aliases only apply to this route, so the original context is returned when it fails
+	unaliased := ctx
+	ctx = applyAliases(ctx, r.aliases)
+	if r.cached {
+		ctx = stripFresh(ctx)
+	}
 	for _, m := range r.matchers {
 		var matched bool
 		matched, ctx = m.Match(ctx)
 		if !matched {
-			return false, ctx
+			return false, unaliased
 		}
 	}
 
 	// if this route contains a subrouter, invoke the subrouter match
 	if r.subrouter != nil {
-		return r.subrouter.Match(ctx, match)
+		matched, ctx := r.subrouter.Match(ctx, match)
+		if matched {
+			r.wrapHandler(match)
+			r.checkDisabled(ctx, match)
+			match.admin = match.admin || r.admin
+		}
+		return matched, ctx
 	}
 
 	match.Route = r
 	match.Handler = r.handler
+	r.wrapHandler(match)
+	r.checkDisabled(ctx, match)
+	match.admin = r.admin
 	return true, ctx
 }
 
+// wrapHandler applies the route middlewares to the matched handler.
+func (r *Route) wrapHandler(match *RouteMatch) {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		match.Handler = r.middlewares[i](match.Handler)
+	}
+}
+
+// Name sets the name of the route, recorded in the origin of its replies and with which
+// it is disabled and enabled at runtime.
+func (r *Route) Name(name string) *Route {
+	r.name = name
+	return r
+}
+
+// GetError returns the error which occurred while building the route, if any. Such a route
+// never matches.
+func (r *Route) GetError() error {
+	return r.err
+}
+
 // Hear adds a matcher for the message text
 func (r *Route) Hear(regex string) *Route {
 	r.err = r.addRegexpMatcher(regex)
@@ -76,6 +127,14 @@ func (r *Route) MessageHandler(fn MessageHandler) *Route {
 	})
 }
 
+// Use appends middlewares wrapping the route handler. The first middleware is the outermost.
+func (r *Route) Use(mw ...Middleware) *Route {
+	if r.err == nil {
+		r.middlewares = append(r.middlewares, mw...)
+	}
+	return r
+}
+
 func (r *Route) Preprocess(fn Preprocessor) *Route {
 	if r.err == nil {
 		r.preprocessor = fn
@@ -105,12 +164,17 @@ func (r *Route) AddMatcher(m Matcher) *Route {
 type RegexpMatcher struct {
 	regex     string
 	botUserID string
+	// set for Hear routes, which honour the bot addressing modes
+	hear bool
 }
 
 func (rm *RegexpMatcher) Match(ctx context.Context) (bool, context.Context) {
-	msg := MessageFromContext(ctx)
-	// A message be receded by a direct mention. For simplicity sake, strip out any potention direct mentions first
-	text := StripDirectMention(msg.Text)
+	if bot := BotFromContext(ctx); rm.hear && bot != nil && bot.requiresAddress() && !IsAddressed(ctx) {
+		return false, ctx
+	}
+	// A message be receded by a direct mention, which is stripped out unless a normalization
+	// pipeline prepared the text already
+	text := TextFromContext(ctx)
 	// now consider stripped text against regular expression
 	matched := regexp.MustCompile(rm.regex).MatchString(text)
 	return matched, ctx
@@ -126,7 +190,7 @@ func (r *Route) addRegexpMatcher(regex string) error {
 		return r.err
 	}
 
-	r.AddMatcher(&RegexpMatcher{regex: regex})
+	r.AddMatcher(&RegexpMatcher{regex: regex, hear: true})
 	return nil
 }
 
@@ -148,6 +212,10 @@ func (tm *TypesMatcher) Match(ctx context.Context) (bool, context.Context) {
 			if IsDirectMessage(msg) {
 				return true, ctx
 			}
+		case GroupMessage:
+			if bot.IsGroupDM(msg.Channel) {
+				return true, ctx
+			}
 		case DirectMention:
 			if IsDirectMention(msg, bot.botUserID) {
 				return true, ctx
@@ -65,3 +65,13 @@ func (r *SimpleRouter) SetBotID(botID string) {
 		route.setBotID(botID)
 	}
 }
+
+// walkRoutes calls fn for every route, including the routes of subrouters.
+func (r *SimpleRouter) walkRoutes(fn func(*Route)) {
+	for _, route := range r.routes {
+		fn(route)
+		if sub, ok := route.subrouter.(*SimpleRouter); ok {
+			sub.walkRoutes(fn)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package slackbot
+
+import (
+	"context"
+	"time"
+)
+
+// Schedule computes the successive times a scheduled function runs.
+type Schedule interface {
+	// Next returns the first time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+// ScheduledFunc is a function run by the scheduler.
+type ScheduledFunc func(ctx context.Context, bot *Bot)
+
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// Every returns a schedule running at a fixed interval. It panics if the interval is not
+// positive, as the schedule would never advance.
+func Every(interval time.Duration) Schedule {
+	if interval <= 0 {
+		panic("slackbot: non-positive interval for Every")
+	}
+	return every(interval)
+}
+
+type dailyAt struct {
+	hour, minute int
+	loc          *time.Location
+}
+
+func (d dailyAt) Next(t time.Time) time.Time {
+	t = t.In(d.loc)
+	next := time.Date(t.Year(), t.Month(), t.Day(), d.hour, d.minute, 0, 0, d.loc)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// DailyAt returns a schedule running every day at the given time of the location.
+func DailyAt(hour, minute int, loc *time.Location) Schedule {
+	return dailyAt{hour: hour, minute: minute, loc: loc}
+}
+
+type weeklyAt struct {
+	day          time.Weekday
+	hour, minute int
+	loc          *time.Location
+}
+
+func (w weeklyAt) Next(t time.Time) time.Time {
+	t = t.In(w.loc)
+	days := (int(w.day) - int(t.Weekday()) + 7) % 7
+	next := time.Date(t.Year(), t.Month(), t.Day()+days, w.hour, w.minute, 0, 0, w.loc)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// WeeklyAt returns a schedule running every week on the day, at the given time of the location.
+func WeeklyAt(day time.Weekday, hour, minute int, loc *time.Location) Schedule {
+	return weeklyAt{day: day, hour: hour, minute: minute, loc: loc}
+}
+
+type scheduled struct {
+	schedule Schedule
+	fn       ScheduledFunc
+}
+
+// Schedule registers a function run on the schedule while the bot runs.
+func (b *Bot) Schedule(schedule Schedule, fn ScheduledFunc) *Bot {
+	b.scheduleMu.Lock()
+	defer b.scheduleMu.Unlock()
+	s := scheduled{schedule: schedule, fn: fn}
+	b.scheduled = append(b.scheduled, s)
+	if b.scheduleCtx != nil {
+		go b.runScheduled(b.scheduleCtx, s)
+	}
+	return b
+}
+
+// startScheduler starts running the registered scheduled functions until the context is done.
+func (b *Bot) startScheduler(ctx context.Context) {
+	b.scheduleMu.Lock()
+	defer b.scheduleMu.Unlock()
+	b.scheduleCtx = ctx
+	for _, s := range b.scheduled {
+		go b.runScheduled(ctx, s)
+	}
+}
+
+func (b *Bot) runScheduled(ctx context.Context, s scheduled) {
+	for {
+		timer := time.NewTimer(time.Until(s.schedule.Next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if on, _ := b.InMaintenance(); on {
+				continue
+			}
+			s.fn(AddBotToContext(ctx, b), b)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyAt(t *testing.T) {
+	assert := assert.New(t)
+	s := DailyAt(17, 30, time.UTC)
+
+	morning := time.Date(2020, 7, 14, 9, 0, 0, 0, time.UTC)
+	assert.Equal(time.Date(2020, 7, 14, 17, 30, 0, 0, time.UTC), s.Next(morning))
+
+	evening := time.Date(2020, 7, 14, 17, 30, 0, 0, time.UTC)
+	assert.Equal(time.Date(2020, 7, 15, 17, 30, 0, 0, time.UTC), s.Next(evening))
+}
+
+func TestWeeklyAt(t *testing.T) {
+	assert := assert.New(t)
+	s := WeeklyAt(time.Monday, 9, 0, time.UTC)
+
+	// a Tuesday
+	tuesday := time.Date(2020, 7, 14, 9, 0, 0, 0, time.UTC)
+	assert.Equal(time.Date(2020, 7, 20, 9, 0, 0, 0, time.UTC), s.Next(tuesday))
+
+	monday := time.Date(2020, 7, 20, 8, 0, 0, 0, time.UTC)
+	assert.Equal(time.Date(2020, 7, 20, 9, 0, 0, 0, time.UTC), s.Next(monday))
+	assert.Equal(time.Date(2020, 7, 27, 9, 0, 0, 0, time.UTC), s.Next(monday.Add(time.Hour)))
+}
+
+func TestEvery(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Date(2020, 7, 14, 9, 0, 0, 0, time.UTC)
+	assert.Equal(start.Add(15*time.Minute), Every(15*time.Minute).Next(start))
+	assert.Panics(func() { Every(0) })
+	assert.Panics(func() { Every(-time.Second) })
+}
@@ -0,0 +1,95 @@
+package slackbot
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// RequireScopes declares OAuth scopes the bot token needs for the features in use. Features
+// of the package declare their own scopes; handlers calling the Web API directly may add more.
+func (b *Bot) RequireScopes(scopes ...string) *Bot {
+	b.scopesMu.Lock()
+	defer b.scopesMu.Unlock()
+	if b.requiredScopes == nil {
+		b.requiredScopes = make(map[string]bool)
+	}
+	for _, s := range scopes {
+		b.requiredScopes[s] = true
+	}
+	return b
+}
+
+// FailOnMissingScopes makes Run stop at startup when required scopes are missing, instead of
+// only warning.
+func (b *Bot) FailOnMissingScopes() *Bot {
+	b.strictScopes = true
+	return b
+}
+
+// MissingScopes calls auth.test and returns the required scopes not granted to the token.
+// Tokens of classic apps, granted the umbrella "bot" scope, are not checked.
+func (b *Bot) MissingScopes() ([]string, error) {
+	granted, err := b.grantedScopes()
+	if err != nil {
+		return nil, err
+	}
+	if granted["bot"] {
+		return nil, nil
+	}
+
+	b.scopesMu.Lock()
+	defer b.scopesMu.Unlock()
+	var missing []string
+	for s := range b.requiredScopes {
+		if !granted[s] {
+			missing = append(missing, s)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// grantedScopes returns the scopes of the token, which Slack reports in the X-OAuth-Scopes
+// header of Web API responses.
+func (b *Bot) grantedScopes() (map[string]bool, error) {
+	resp, err := b.httpClient.PostForm(slack.APIURL+"auth.test", url.Values{"token": {b.token}})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth.test: %s", resp.Status)
+	}
+
+	granted := make(map[string]bool)
+	for _, s := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			granted[s] = true
+		}
+	}
+	return granted, nil
+}
+
+// checkScopes warns about missing scopes and returns an error when they must be present.
+func (b *Bot) checkScopes() error {
+	missing, err := b.MissingScopes()
+	if err != nil {
+		fmt.Printf("Error checking token scopes: %s\n", err)
+		return nil
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	err = fmt.Errorf("the token lacks the scopes %s, add them in the OAuth & Permissions page of the app and reinstall it",
+		strings.Join(missing, ", "))
+	if b.strictScopes {
+		return err
+	}
+	fmt.Printf("Warning: %s\n", err)
+	return nil
+}
@@ -0,0 +1,48 @@
+package slackbot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redirectTransport sends the requests of a client to a test server.
+type redirectTransport struct {
+	server *url.URL
+}
+
+func (t redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme, r.URL.Host = t.server.Scheme, t.server.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestMissingScopes(t *testing.T) {
+	assert := assert.New(t)
+	granted := "chat:write, channels:read"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/api/auth.test", r.URL.Path)
+		w.Header().Set("X-OAuth-Scopes", granted)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	bot := New("xoxb-token")
+	bot.httpClient = &http.Client{Transport: redirectTransport{server: serverURL}}
+	bot.RequireScopes("chat:write", "reactions:read", "emoji:read")
+
+	missing, err := bot.MissingScopes()
+	assert.NoError(err)
+	assert.Equal([]string{"emoji:read", "reactions:read"}, missing)
+	assert.NoError(bot.checkScopes())
+	assert.Error(bot.FailOnMissingScopes().checkScopes())
+
+	// classic apps are not checked
+	granted = "bot"
+	missing, err = bot.MissingScopes()
+	assert.NoError(err)
+	assert.Empty(missing)
+}
@@ -0,0 +1,51 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// AskSelect posts a prompt with a menu of options in reply to the message event. The option
+// picked by a user is sent on the returned channel, which is closed if the prompt fails.
+// Choices are received through the InteractionHandler.
+func (b *Bot) AskSelect(evt *slack.MessageEvent, prompt string, options []string) <-chan string {
+	choice := make(chan string, 1)
+	actionID := newActionID("select")
+
+	opts := make([]*slack.OptionBlockObject, len(options))
+	for i, o := range options {
+		opts[i] = slack.NewOptionBlockObject(o, slack.NewTextBlockObject(slack.PlainTextType, o, false, false))
+	}
+	menu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+		slack.NewTextBlockObject(slack.PlainTextType, "Choose…", false, false), actionID, opts...)
+	text := slack.NewTextBlockObject(slack.MarkdownType, prompt, false, false)
+
+	ts, err := b.Send(&OutgoingMessage{
+		Channel: evt.Channel,
+		Text:    prompt,
+		Blocks:  []slack.Block{slack.NewSectionBlock(text, nil, slack.NewAccessory(menu))},
+		Params:  slack.PostMessageParameters{AsUser: true},
+	})
+	if err != nil {
+		fmt.Printf("Error asking selection: %s\n", err)
+		close(choice)
+		return choice
+	}
+
+	b.OnAction(actionID, func(ctx context.Context, bot *Bot, callback *slack.InteractionCallback, action *slack.BlockAction) {
+		bot.RemoveAction(actionID)
+		value := action.SelectedOption.Value
+		answered := fmt.Sprintf("%s *%s*", prompt, value)
+		_, _ = bot.Send(&OutgoingMessage{
+			Channel:   evt.Channel,
+			Timestamp: ts,
+			Text:      answered,
+			Blocks:    []slack.Block{slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, answered, false, false), nil, nil)},
+		})
+		choice <- value
+		close(choice)
+	})
+	return choice
+}
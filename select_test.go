@@ -0,0 +1,53 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAskSelect(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+
+	choice := bot.AskSelect(evt, "Which environment?", []string{"staging", "production"})
+	assert.Equal([]string{"Which environment?"}, api.values("chat.postMessage", "text"))
+	assert.Len(bot.actions, 1)
+	var actionID string
+	for id := range bot.actions {
+		actionID = id
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"type":    "block_actions",
+		"user":    map[string]string{"id": "U1"},
+		"actions": []map[string]interface{}{{"block_id": "b1", "action_id": actionID, "selected_option": map[string]string{"value": "staging"}}},
+	})
+	body := url.Values{"payload": {string(payload)}}.Encode()
+	rec := httptest.NewRecorder()
+	bot.InteractionHandler("secret").ServeHTTP(rec, signedRequest("secret", "/interactivity", body))
+	assert.Equal(http.StatusOK, rec.Code)
+
+	assert.Equal("staging", <-choice)
+	assert.Equal([]string{"Which environment? *staging*"}, api.values("chat.update", "text"))
+	assert.Empty(bot.actions)
+}
+
+func TestAskSelectFailure(t *testing.T) {
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.postMessage", `{"ok": false, "error": "channel_not_found"}`)
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+
+	_, open := <-bot.AskSelect(evt, "Which environment?", []string{"staging"})
+	assert.False(t, open)
+}
@@ -0,0 +1,78 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInvalidAuth is returned by Serve when Slack rejects the token.
+var ErrInvalidAuth = errors.New("slackbot: invalid credentials")
+
+// WithoutRTM makes the bot only serve its HTTP endpoints, receiving events through the
+// Events API instead of an RTM connection. The HTTP address must then be configured.
+func (b *Bot) WithoutRTM() *Bot {
+	b.withoutRTM = true
+	return b
+}
+
+// Serve runs the RTM connection, unless disabled, the HTTP endpoints, when an address is
+// configured, the scheduler and the job workers, after joining the watched channels, until
+// the context is done. The first fatal error stops everything and is returned, after running
+// the OnShutdown callbacks.
+func (b *Bot) Serve(ctx context.Context) error {
+	if b.withoutRTM && b.httpConfig.Addr == "" {
+		return errors.New("slackbot: no HTTP address configured to receive events without RTM")
+	}
+	if err := b.checkScopes(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	g := &group{cancel: cancel}
+	b.setStarted(time.Now())
+	b.startScheduler(ctx)
+	b.Jobs.start(ctx)
+	go b.joinWatched()
+	if !b.withoutRTM {
+		g.Go(func() error { return b.runRTM(ctx) })
+	} else {
+		b.identify()
+		b.setConnected(time.Now())
+		go b.ready(AddBotToContext(ctx, b))
+	}
+	if b.httpConfig.Addr != "" {
+		g.Go(func() error { return b.serveHTTP(ctx) })
+	}
+	err := g.Wait()
+	b.shutdown()
+	return err
+}
+
+// group runs functions concurrently, cancelling the others when one of them fails.
+type group struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+func (g *group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	}()
+}
+
+func (g *group) Wait() error {
+	g.wg.Wait()
+	return g.err
+}
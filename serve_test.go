@@ -0,0 +1,37 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeWithoutRTM(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "bot")
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bot := New("").WithoutRTM()
+	bot.botUserID = "UBOT"
+	bot.httpClient = &http.Client{Transport: redirectTransport{server: serverURL}}
+	// events cannot be received without an HTTP server
+	assert.Error(bot.Serve(ctx))
+
+	var ready bool
+	bot.SetHTTPConfig(HTTPConfig{Addr: "127.0.0.1:0"}).OnReady(func(ctx context.Context, bot *Bot) {
+		ready = true
+		cancel()
+	})
+	assert.NoError(bot.Serve(ctx))
+	assert.True(ready)
+}
@@ -0,0 +1,56 @@
+package slackbot
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MaxRequestAge is the maximum skew accepted between the timestamp of a signed request and
+// the local clock, which prevents replaying captured requests.
+var MaxRequestAge = 5 * time.Minute
+
+// VerifySignature returns an HTTP middleware rejecting requests that are not signed by Slack
+// with the signing secret of the app. It applies to every request URL configured in the app:
+// events, slash commands and interactivity.
+func VerifySignature(signingSecret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if !validSignature(signingSecret, r.Header, body, time.Now()) {
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validSignature checks the X-Slack-Signature header, an HMAC-SHA256 of the version, the
+// timestamp and the body, in constant time.
+func validSignature(signingSecret string, header http.Header, body []byte, now time.Time) bool {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > MaxRequestAge || age < -MaxRequestAge {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
@@ -0,0 +1,70 @@
+package slackbot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	assert := assert.New(t)
+	// example from https://api.slack.com/authentication/verifying-requests-from-slack
+	secret := "8f742231b10e8888abcd99yyyzzz85a5"
+	body := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c"
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", "1531420618")
+	header.Set("X-Slack-Signature", "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503")
+	signedAt := time.Unix(1531420618, 0)
+
+	assert.True(validSignature(secret, header, []byte(body), signedAt))
+	assert.False(validSignature(secret, header, []byte(body+"&x=1"), signedAt))
+	assert.False(validSignature("wrong", header, []byte(body), signedAt))
+	assert.False(validSignature(secret, header, []byte(body), signedAt.Add(time.Hour)))
+
+	handler := VerifySignature(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set("X-Slack-Signature", "v0=invalid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(http.StatusUnauthorized, rec.Code)
+}
+
+// signedRequest returns a request to the handlers of the bot, signed as Slack does.
+func signedRequest(secret, path, body string) *http.Request {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestVerifySignaturePassesBody(t *testing.T) {
+	assert := assert.New(t)
+	var received string
+	handler := VerifySignature("secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.FormValue("text")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest("secret", "/commands", "command=%2Fdeploy&text=web"))
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("web", received)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest("other secret", "/commands", "command=%2Fdeploy&text=web"))
+	assert.Equal(http.StatusUnauthorized, rec.Code)
+}
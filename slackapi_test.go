@@ -0,0 +1,116 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// slackAPI is a fake Slack Web API for tests, answering every method with ok and
+// recording the calls.
+type slackAPI struct {
+	mu        sync.Mutex
+	requests  []apiRequest
+	responses map[string][]string
+}
+
+type apiRequest struct {
+	Method string
+	Form   url.Values
+}
+
+const defaultAPIResponse = `{"ok": true, "channel": "C1", "ts": "1.0"}`
+
+// newSlackAPI starts a fake Slack Web API, closed with the test, and points the clients of
+// the bot to it.
+func newSlackAPI(t *testing.T, bot *Bot) *slackAPI {
+	api := &slackAPI{responses: make(map[string][]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			// uploads are recorded with the name of the file as the "file" value
+			_ = r.ParseMultipartForm(1 << 20)
+			if _, header, err := r.FormFile("file"); err == nil {
+				r.Form.Set("file", header.Filename)
+			}
+		}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			// the fields of JSON requests are recorded as form values
+			var fields map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&fields)
+			for k, v := range fields {
+				if s, ok := v.(string); ok {
+					r.Form.Set(k, s)
+				}
+			}
+		}
+		method := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"), "api/")
+		api.mu.Lock()
+		api.requests = append(api.requests, apiRequest{Method: method, Form: r.Form})
+		body := defaultAPIResponse
+		if bodies := api.responses[method]; len(bodies) > 0 {
+			body = bodies[0]
+			if len(bodies) > 1 {
+				api.responses[method] = bodies[1:]
+			}
+		}
+		api.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	bot.Client = slack.New("", slack.OptionAPIURL(server.URL+"/"))
+	// methods the slack package does not cover are called with the HTTP client
+	serverURL, _ := url.Parse(server.URL)
+	bot.httpClient = &http.Client{Transport: redirectTransport{server: serverURL}}
+	return api
+}
+
+// respond sets the JSON bodies answered to the method, e.g. "conversations.open", in turn
+// and then the last one.
+func (api *slackAPI) respond(method string, bodies ...string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.responses[method] = bodies
+}
+
+// calls lists the methods called, each followed by the value of the form field.
+func (api *slackAPI) calls(field string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	calls := make([]string, 0, len(api.requests))
+	for _, r := range api.requests {
+		calls = append(calls, r.Method+" "+r.Form.Get(field))
+	}
+	return calls
+}
+
+// values lists the values of the form field sent to the method.
+func (api *slackAPI) values(method, field string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	var values []string
+	for _, r := range api.requests {
+		if r.Method == method {
+			values = append(values, r.Form.Get(field))
+		}
+	}
+	return values
+}
+
+// wait waits up to a second for the method to be called n times, for calls made in
+// goroutines.
+func (api *slackAPI) wait(method string, n int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if len(api.values(method, "")) >= n {
+			return
+		}
+	}
+}
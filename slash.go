@@ -0,0 +1,66 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/slack-go/slack"
+)
+
+// SlashCommandHandler handles a slash command. The returned text, if any, is displayed to the
+// user who ran the command only.
+type SlashCommandHandler func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string
+
+// SlashCommand registers the handler of a slash command, e.g. "/deploy".
+func (b *Bot) SlashCommand(command string, handler SlashCommandHandler) *Bot {
+	b.interactionsMu.Lock()
+	defer b.interactionsMu.Unlock()
+	if b.commands == nil {
+		b.commands = make(map[string]SlashCommandHandler)
+	}
+	b.commands[command] = handler
+	return b
+}
+
+// SlashCommandsHandler returns the HTTP handler to configure as the Request URL of the slash
+// commands of the Slack app.
+func (b *Bot) SlashCommandsHandler(signingSecret string) http.Handler {
+	return VerifySignature(signingSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cmd, err := slack.SlashCommandParse(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b.interactionsMu.Lock()
+		handler, ok := b.commands[cmd.Command]
+		b.interactionsMu.Unlock()
+		if !ok {
+			http.Error(w, "unknown command "+cmd.Command, http.StatusNotFound)
+			return
+		}
+
+		ctx := AddTeamToContext(AddBotToContext(context.Background(), b), cmd.TeamID)
+		if cmd.EnterpriseID != "" {
+			ctx = AddEnterpriseToContext(ctx, cmd.EnterpriseID)
+		}
+		text, ok := b.runSlashCommand(ctx, handler, &cmd)
+		if !ok {
+			http.Error(w, "command failed", http.StatusInternalServerError)
+			return
+		}
+		if text == "" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"response_type": "ephemeral", "text": text})
+	}))
+}
+
+// runSlashCommand runs the handler, reporting its panics with HandleError as the
+// requests of slash commands are not answered otherwise.
+func (b *Bot) runSlashCommand(ctx context.Context, handler SlashCommandHandler, cmd *slack.SlashCommand) (text string, ok bool) {
+	defer recoverHandler(ctx)
+	return handler(ctx, b, cmd), true
+}
@@ -0,0 +1,51 @@
+package slackbot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashCommands(t *testing.T) {
+	assert := assert.New(t)
+	var reports []*ErrorReport
+	bot := New("").SetErrorReporter(ErrorReporterFunc(func(ctx context.Context, report *ErrorReport) {
+		reports = append(reports, report)
+	}))
+	bot.SlashCommand("/deploy", func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string {
+		return "Deploying " + cmd.Text + " in " + TeamFromContext(ctx) + " " + EnterpriseFromContext(ctx)
+	})
+	bot.SlashCommand("/quiet", func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string {
+		return ""
+	})
+	bot.SlashCommand("/crash", func(ctx context.Context, bot *Bot, cmd *slack.SlashCommand) string {
+		panic("boom")
+	})
+	handler := bot.SlashCommandsHandler("secret")
+	serve := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, signedRequest("secret", "/commands", body))
+		return rec
+	}
+
+	rec := serve("command=%2Fdeploy&text=web&team_id=T1&enterprise_id=E1")
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.JSONEq(`{"response_type": "ephemeral", "text": "Deploying web in T1 E1"}`, rec.Body.String())
+
+	rec = serve("command=%2Fquiet&team_id=T1")
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Empty(rec.Body.String())
+
+	assert.Equal(http.StatusNotFound, serve("command=%2Funknown&team_id=T1").Code)
+
+	// panics are reported and fail the request
+	assert.Equal(http.StatusInternalServerError, serve("command=%2Fcrash&team_id=T1").Code)
+	if assert.Len(reports, 1) {
+		assert.True(reports[0].Panic)
+		assert.EqualError(reports[0].Err, "panic: boom")
+	}
+}
@@ -0,0 +1,151 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// SlowModeAction is what happens when a user exceeds the rate of a channel. Actions combine.
+type SlowModeAction int
+
+const (
+	// SlowModeWarn warns the user by direct message.
+	SlowModeWarn SlowModeAction = 1 << iota
+	// SlowModeNotify posts to the moderator channel.
+	SlowModeNotify
+)
+
+// SlowModeWarningText is sent to users exceeding the rate of a channel, formatted with the
+// channel and the number of messages allowed per minute.
+var SlowModeWarningText = "Please slow down: <#%s> allows %d messages per minute."
+
+// SlowModeNotifyText is posted to the moderator channel, formatted with the user, the number
+// of messages sent in the last minute and the channel.
+var SlowModeNotifyText = ":snail: <@%s> sent %d messages in a minute in <#%s>."
+
+// ChannelConfig configures slow mode in a channel.
+type ChannelConfig struct {
+	// Messages a user may send per minute
+	MaxPerMinute int
+	// Taken when a user exceeds the rate, SlowModeWarn when zero
+	Actions SlowModeAction
+}
+
+// SlowModeOptions configures slow mode.
+type SlowModeOptions struct {
+	// Channels in slow mode, by ID
+	Channels map[string]ChannelConfig
+	// Channel notified with SlowModeNotify
+	ModeratorChannel string
+	// Delay before a user is warned again in a channel, 10 minutes when zero
+	Cooldown time.Duration
+}
+
+// slowMode tracks the recent messages of each user in the channels in slow mode.
+type slowMode struct {
+	opts SlowModeOptions
+
+	mu     sync.Mutex
+	recent map[string][]time.Time
+	warned map[string]time.Time
+	pruned time.Time
+}
+
+// SlowMode watches the messages of the configured channels, and warns users sending more than
+// the allowed messages per minute, or notifies the moderators. Messages are not blocked.
+//
+//	bot.SlowMode(slackbot.SlowModeOptions{
+//		Channels: map[string]slackbot.ChannelConfig{
+//			announcementsID: {MaxPerMinute: 3, Actions: slackbot.SlowModeWarn | slackbot.SlowModeNotify},
+//			randomID:        {MaxPerMinute: 10},
+//		},
+//		ModeratorChannel: modsID,
+//	})
+func (b *Bot) SlowMode(opts SlowModeOptions) *Bot {
+	if opts.Cooldown == 0 {
+		opts.Cooldown = 10 * time.Minute
+	}
+	first := b.slowMode == nil
+	b.slowMode = &slowMode{opts: opts, recent: make(map[string][]time.Time), warned: make(map[string]time.Time)}
+	if first {
+		b.OnEvent("message", func(ctx context.Context, bot *Bot, evt interface{}) {
+			if msg, ok := evt.(*slack.MessageEvent); ok && msg.SubType == "" && msg.BotID == "" {
+				bot.enforceSlowMode(msg, time.Now())
+			}
+		})
+	}
+	return b
+}
+
+// enforceSlowMode counts the message, and takes the actions of its channel once its sender
+// exceeds the rate.
+func (b *Bot) enforceSlowMode(msg *slack.MessageEvent, now time.Time) {
+	s := b.slowMode
+	cfg, ok := s.opts.Channels[msg.Channel]
+	if !ok || cfg.MaxPerMinute <= 0 {
+		return
+	}
+	count, exceeded := s.count(msg.Channel, msg.User, cfg.MaxPerMinute, now)
+	if !exceeded {
+		return
+	}
+	actions := cfg.Actions
+	if actions == 0 {
+		actions = SlowModeWarn
+	}
+	if actions&SlowModeWarn != 0 {
+		if res := b.DM(msg.User, fmt.Sprintf(SlowModeWarningText, msg.Channel, cfg.MaxPerMinute)); res.Err != nil {
+			fmt.Printf("Error warning %s: %s\n", msg.User, res.Err)
+		}
+	}
+	if actions&SlowModeNotify != 0 && s.opts.ModeratorChannel != "" {
+		text := fmt.Sprintf(SlowModeNotifyText, msg.User, count, msg.Channel)
+		if _, err := b.Send(&OutgoingMessage{Channel: s.opts.ModeratorChannel, Text: text,
+			Params: slack.PostMessageParameters{AsUser: true}}); err != nil {
+			fmt.Printf("Error notifying moderators: %s\n", err)
+		}
+	}
+}
+
+// count records a message of the user, and returns the messages of the last minute and
+// whether the user is to be warned: over the rate, and not warned within the cooldown.
+func (s *slowMode) count(channel, user string, max int, now time.Time) (int, bool) {
+	key := channel + ":" + user
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recent := s.recent[key][:0]
+	for _, t := range s.recent[key] {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	s.recent[key] = recent
+	if now.Sub(s.pruned) >= time.Minute {
+		// forget the users who stopped posting
+		for k, times := range s.recent {
+			if now.Sub(times[len(times)-1]) >= time.Minute {
+				delete(s.recent, k)
+			}
+		}
+		s.pruned = now
+	}
+	if len(recent) <= max {
+		return len(recent), false
+	}
+	if warned, ok := s.warned[key]; ok && now.Sub(warned) < s.opts.Cooldown {
+		return len(recent), false
+	}
+	s.warned[key] = now
+	// forget the users who calmed down
+	for k, t := range s.warned {
+		if now.Sub(t) >= s.opts.Cooldown {
+			delete(s.warned, k)
+		}
+	}
+	return len(recent), true
+}
@@ -0,0 +1,61 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowModeCount(t *testing.T) {
+	assert := assert.New(t)
+	s := &slowMode{opts: SlowModeOptions{Cooldown: 10 * time.Minute}, recent: make(map[string][]time.Time), warned: make(map[string]time.Time)}
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		_, exceeded := s.count("C1", "U1", 3, now.Add(time.Duration(i)*time.Second))
+		assert.False(exceeded)
+	}
+	n, exceeded := s.count("C1", "U1", 3, now.Add(3*time.Second))
+	assert.Equal(4, n)
+	assert.True(exceeded)
+	// warned once per cooldown
+	_, exceeded = s.count("C1", "U1", 3, now.Add(4*time.Second))
+	assert.False(exceeded)
+	// other users and channels are counted apart
+	_, exceeded = s.count("C2", "U1", 3, now.Add(4*time.Second))
+	assert.False(exceeded)
+
+	// a minute later the first messages are forgotten
+	n, _ = s.count("C1", "U1", 3, now.Add(63*time.Second))
+	assert.Equal(2, n)
+	later := now.Add(11 * time.Minute)
+	for i := 0; i < 3; i++ {
+		s.count("C1", "U1", 3, later)
+	}
+	_, exceeded = s.count("C1", "U1", 3, later)
+	assert.True(exceeded)
+}
+
+func TestSlowMode(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.open", `{"ok": true, "channel": {"id": "D1"}}`)
+	bot.SlowMode(SlowModeOptions{
+		Channels: map[string]ChannelConfig{
+			"C1": {MaxPerMinute: 1},
+			"C2": {MaxPerMinute: 1, Actions: SlowModeNotify},
+		},
+		ModeratorChannel: "CMODS",
+	})
+	now := time.Now()
+	for _, channel := range []string{"C1", "C1", "C2", "C2", "C3", "C3"} {
+		msg := &slack.MessageEvent{}
+		msg.Channel, msg.User = channel, "U1"
+		bot.enforceSlowMode(msg, now)
+	}
+
+	assert.Equal([]string{"conversations.open ", "chat.postMessage D1", "chat.postMessage CMODS"}, api.calls("channel"))
+}
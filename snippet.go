@@ -0,0 +1,52 @@
+package slackbot
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// maxInlineSnippetLines and maxInlineSnippetLength bound the snippets sent as code blocks,
+	// longer ones are uploaded as files.
+	maxInlineSnippetLines  = 30
+	maxInlineSnippetLength = 3000
+)
+
+// ReplySnippet replies to a message event with content, e.g. logs or a configuration file,
+// in a code block when short enough, or as a snippet file otherwise. lang is the Slack file
+// type used for syntax highlighting of uploaded snippets, e.g. "go", "yaml" or "text".
+func (b *Bot) ReplySnippet(evt *slack.MessageEvent, title, content, lang string) error {
+	if strings.Count(content, "\n") < maxInlineSnippetLines && utf8.RuneCountInString(content) <= maxInlineSnippetLength {
+		text := codeFence + "\n" + strings.TrimSuffix(content, "\n") + "\n" + codeFence
+		if title != "" {
+			text = "*" + title + "*\n" + text
+		}
+		out := &OutgoingMessage{
+			Channel: evt.Channel,
+			Text:    text,
+			Params:  slack.PostMessageParameters{ThreadTimestamp: evt.ThreadTimestamp},
+		}
+		b.attachJoin(out, evt)
+		_, err := b.Send(out)
+		return err
+	}
+	return b.uploadSnippet(evt.Channel, evt.ThreadTimestamp, title, content, lang)
+}
+
+// uploadSnippet uploads content as a snippet file in the channel, or thread when set.
+func (b *Bot) uploadSnippet(channel, threadTS, title, content, lang string) error {
+	b.RequireScopes("files:write")
+	if lang == "" {
+		lang = "text"
+	}
+	_, err := b.Client.UploadFile(slack.FileUploadParameters{
+		Content:         content,
+		Filetype:        lang,
+		Title:           title,
+		Channels:        []string{channel},
+		ThreadTimestamp: threadTS,
+	})
+	return err
+}
@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplySnippet(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.ThreadTimestamp = "C1", "1.0"
+
+	assert.NoError(bot.ReplySnippet(evt, "Config", "port: 80\n", "yaml"))
+	assert.Equal([]string{"*Config*\n```\nport: 80\n```"}, api.values("chat.postMessage", "text"))
+	assert.Equal([]string{"1.0"}, api.values("chat.postMessage", "thread_ts"))
+	assert.Empty(api.values("files.upload", "content"))
+
+	logs := strings.Repeat("log line\n", maxInlineSnippetLines)
+	assert.NoError(bot.ReplySnippet(evt, "Logs", logs, ""))
+	assert.Equal([]string{logs}, api.values("files.upload", "content"))
+	assert.Equal([]string{"text"}, api.values("files.upload", "filetype"))
+	assert.Equal([]string{"C1"}, api.values("files.upload", "channels"))
+	assert.Equal([]string{"1.0"}, api.values("files.upload", "thread_ts"))
+	assert.True(bot.requiredScopes["files:write"])
+
+	// long lines are uploaded too
+	assert.NoError(bot.ReplySnippet(evt, "", strings.Repeat("x", maxInlineSnippetLength+1), "text"))
+	assert.Len(api.values("files.upload", "content"), 2)
+	assert.Len(api.values("chat.postMessage", "text"), 1)
+}
@@ -0,0 +1,53 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// StarHandler handles a user starring, or unstarring, an item. msg is the starred message,
+// resolved when the item is a message and nil otherwise.
+type StarHandler func(ctx context.Context, bot *Bot, userID string, item *slack.StarredItem, msg *slack.MessageEvent)
+
+// OnStarAdded registers a handler called when a user stars an item.
+func (b *Bot) OnStarAdded(handler StarHandler) *Bot {
+	b.RequireScopes("stars:read")
+	return b.OnEvent("star_added", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if e, ok := evt.(*slack.StarAddedEvent); ok {
+			bot.handleStar(ctx, e.User, &e.Item, handler)
+		}
+	})
+}
+
+// OnStarRemoved registers a handler called when a user removes a star from an item.
+func (b *Bot) OnStarRemoved(handler StarHandler) *Bot {
+	b.RequireScopes("stars:read")
+	return b.OnEvent("star_removed", func(ctx context.Context, bot *Bot, evt interface{}) {
+		if e, ok := evt.(*slack.StarRemovedEvent); ok {
+			bot.handleStar(ctx, e.User, &e.Item, handler)
+		}
+	})
+}
+
+// IsFromBot returns true if the message was posted by the bot.
+func (b *Bot) IsFromBot(msg *slack.MessageEvent) bool {
+	return msg != nil && msg.User != "" && (msg.User == b.botUserID || msg.User == b.botEnterpriseID)
+}
+
+func (b *Bot) handleStar(ctx context.Context, userID string, item *slack.StarredItem, handler StarHandler) {
+	var msg *slack.MessageEvent
+	if item.Type == slack.TYPE_MESSAGE && item.Message != nil {
+		resolved, err := b.FetchMessage(item.Channel, item.Message.Timestamp)
+		if err != nil {
+			fmt.Printf("Error fetching starred message: %s\n", err)
+			m := slack.MessageEvent(*item.Message)
+			m.Channel = item.Channel
+			resolved = &m
+		}
+		msg = resolved
+		ctx = AddMessageToContext(ctx, msg)
+	}
+	handler(ctx, b, userID, item, msg)
+}
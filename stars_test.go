@@ -0,0 +1,53 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnStar(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botUserID = "UBOT"
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": true, "messages": [{"type": "message", "user": "UBOT", "text": "Deployed", "ts": "1.5"}]}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var stars []string
+	record := func(ctx context.Context, bot *Bot, userID string, item *slack.StarredItem, msg *slack.MessageEvent) {
+		star := userID + " " + item.Type
+		if msg != nil && bot.IsFromBot(msg) {
+			star += " " + msg.Text
+		}
+		stars = append(stars, star)
+	}
+	bot.OnStarAdded(record).OnStarRemoved(record)
+
+	added := &slack.StarAddedEvent{User: "U1", Item: slack.StarredItem{Type: slack.TYPE_MESSAGE, Channel: "C1"}}
+	added.Item.Message = &slack.Message{}
+	added.Item.Message.Timestamp = "1.5"
+	bot.handleEvent(ctx, "star_added", added)
+	bot.handleEvent(ctx, "star_removed", &slack.StarRemovedEvent{User: "U1", Item: slack.StarredItem{Type: slack.TYPE_FILE}})
+	assert.Equal([]string{"U1 message Deployed", "U1 file"}, stars)
+}
+
+func TestOnStarUnresolved(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.history", `{"ok": false, "error": "not_in_channel"}`)
+	ctx := AddBotToContext(context.Background(), bot)
+	var texts []string
+	bot.OnStarAdded(func(ctx context.Context, bot *Bot, userID string, item *slack.StarredItem, msg *slack.MessageEvent) {
+		texts = append(texts, msg.Channel+" "+msg.Text)
+	})
+
+	// the message of the event is used when it cannot be fetched
+	added := &slack.StarAddedEvent{User: "U1", Item: slack.StarredItem{Type: slack.TYPE_MESSAGE, Channel: "C1"}}
+	added.Item.Message = &slack.Message{}
+	added.Item.Message.Timestamp, added.Item.Message.Text = "1.5", "Deployed"
+	bot.handleEvent(ctx, "star_added", added)
+	assert.Equal([]string{"C1 Deployed"}, texts)
+}
@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"sync"
+	"time"
+)
+
+// Store persists small values on behalf of the bot and its handlers. Implementations must
+// be safe for concurrent use. A zero ttl means the value never expires.
+type Store interface {
+	Get(key string) (value []byte, found bool, err error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// SetStore replaces the store used by the bot, an in-memory store by default.
+func (b *Bot) SetStore(store Store) *Bot {
+	b.store = store
+	return b
+}
+
+// Store returns the store used by the bot.
+func (b *Bot) Store() Store {
+	if b.store == nil {
+		b.store = NewMemoryStore()
+	}
+	return b.store
+}
+
+// MemoryStore is a Store keeping values in memory. Values are lost when the process exits.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore constructs an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (s *MemoryStore) Set(key string, value []byte, ttl time.Duration) error {
+	e := memoryEntry{value: value}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	s.mu.Lock()
+	s.entries[key] = e
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
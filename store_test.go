@@ -0,0 +1,31 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	assert := assert.New(t)
+	s := NewMemoryStore()
+
+	_, found, err := s.Get("key")
+	assert.NoError(err)
+	assert.False(found)
+
+	assert.NoError(s.Set("key", []byte("value"), 0))
+	value, found, _ := s.Get("key")
+	assert.True(found)
+	assert.Equal([]byte("value"), value)
+
+	assert.NoError(s.Delete("key"))
+	_, found, _ = s.Get("key")
+	assert.False(found)
+
+	assert.NoError(s.Set("short", []byte("lived"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, found, _ = s.Get("short")
+	assert.False(found)
+}
@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// StreamWriter appends everything written to it to a single Slack message. The message
+// is posted on the first Write and edited in place afterwards, at most once per interval.
+type StreamWriter struct {
+	msg *liveMessage
+	buf bytes.Buffer
+	// Commands running for the message event, which the streamed message may cancel
+	cancels []*cancelHandle
+	linked  bool
+}
+
+// StartStream returns a StreamWriter replying to the message event. It is handy to
+// relay the output of long running commands as it is produced. Cancelling the command,
+// which may also be done by reacting to the streamed message, fails the following writes.
+func (b *Bot) StartStream(evt *slack.MessageEvent) *StreamWriter {
+	s := &StreamWriter{cancels: b.cancels.lookup(evt.Channel, evt.Timestamp)}
+	s.msg = newLiveMessage(b, evt.Channel, func(msg *OutgoingMessage) {
+		msg.Text = s.buf.String()
+	})
+	return s
+}
+
+// SetInterval changes the minimum delay between two updates of the message.
+func (s *StreamWriter) SetInterval(interval time.Duration) *StreamWriter {
+	s.msg.mu.Lock()
+	s.msg.interval = interval
+	s.msg.mu.Unlock()
+	return s
+}
+
+// Write appends p to the streamed message. Updates are throttled; writes arriving
+// too early are merged and sent when the interval has elapsed.
+func (s *StreamWriter) Write(p []byte) (int, error) {
+	s.msg.mu.Lock()
+	defer s.msg.mu.Unlock()
+
+	if s.msg.err != nil {
+		return 0, s.msg.err
+	}
+	for _, h := range s.cancels {
+		if err := h.ctx.Err(); err != nil {
+			return 0, err
+		}
+	}
+	s.buf.Write(p)
+	err := s.msg.updateLocked(false)
+	if !s.linked && s.msg.ts != "" {
+		s.linked = true
+		for _, h := range s.cancels {
+			s.msg.bot.cancels.add(h, s.msg.channel, s.msg.ts)
+		}
+	}
+	return len(p), err
+}
+
+// Close sends any pending text immediately.
+func (s *StreamWriter) Close() error {
+	s.msg.mu.Lock()
+	defer s.msg.mu.Unlock()
+	return s.msg.updateLocked(true)
+}
+
+// Timestamp returns the ts of the streamed message, empty until the first Write.
+func (s *StreamWriter) Timestamp() string {
+	s.msg.mu.Lock()
+	defer s.msg.mu.Unlock()
+	return s.msg.ts
+}
@@ -0,0 +1,49 @@
+package slackbot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamWriter(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.Timestamp = "C1", "0.1"
+	s := bot.StartStream(evt).SetInterval(time.Hour)
+	assert.Equal("", s.Timestamp())
+
+	fmt.Fprint(s, "building")
+	assert.Equal("1.0", s.Timestamp())
+	fmt.Fprint(s, "...")
+	fmt.Fprint(s, " done")
+	// writes within the interval are merged
+	assert.Equal([]string{"chat.postMessage building"}, api.calls("text"))
+
+	assert.NoError(s.Close())
+	assert.Equal([]string{"chat.postMessage building", "chat.update building... done"}, api.calls("text"))
+	assert.Equal([]string{"1.0"}, api.values("chat.update", "ts"))
+}
+
+func TestStreamWriterError(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("chat.postMessage", `{"ok": false, "error": "channel_not_found"}`)
+
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+	s := bot.StartStream(evt)
+	_, err := s.Write([]byte("output"))
+	assert.Error(err)
+	// the error is kept for the following writes
+	_, err = s.Write([]byte("more"))
+	assert.Error(err)
+	assert.Len(api.calls("text"), 1)
+}
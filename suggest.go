@@ -0,0 +1,130 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const defaultSuggestionDistance = 2
+
+// SuggestionText is the reply sent for an unmatched command close to a known one. It is
+// formatted with the suggested command.
+var SuggestionText = "Did you mean `%s`?"
+
+// Usage documents how to invoke the route, e.g. "deploy <env>". Words in angle or square
+// brackets are arguments, the leading words the command itself.
+func (r *Route) Usage(usage string) *Route {
+	if r.err == nil {
+		r.usage = usage
+	}
+	return r
+}
+
+// EnableSuggestions makes the bot answer messages addressed to it that match no route with
+// the closest known command, when the edit distance is below the threshold.
+func (b *Bot) EnableSuggestions() *Bot {
+	if b.suggestionDistance == 0 {
+		b.suggestionDistance = defaultSuggestionDistance
+	}
+	return b
+}
+
+// SuggestionDistance sets the maximum edit distance between a message and a suggested command.
+func (b *Bot) SuggestionDistance(distance int) *Bot {
+	b.suggestionDistance = distance
+	return b
+}
+
+// suggest replies with the closest command to an unmatched message, if any, and returns
+// whether it did.
+func (b *Bot) suggest(ctx context.Context, evt *slack.MessageEvent) bool {
+	if b.suggestionDistance == 0 {
+		return false
+	}
+	if !isForBot(ctx, b, evt) {
+		return false
+	}
+	var usages []string
+	b.walkRoutes(func(r *Route) {
+		if r.usage != "" {
+			usages = append(usages, r.usage)
+		}
+	})
+	if suggestion := closestCommand(TextFromContext(ctx), usages, b.suggestionDistance); suggestion != "" {
+		b.Reply(evt, fmt.Sprintf(SuggestionText, suggestion))
+		return true
+	}
+	return false
+}
+
+// isForBot returns true if the message is addressed to the bot, or sent to it directly.
+func isForBot(ctx context.Context, b *Bot, evt *slack.MessageEvent) bool {
+	return IsAddressed(ctx) || IsDirectMessage(evt) || IsDirectMention(evt, b.botUserID)
+}
+
+// closestCommand compares the leading words of text to the command words of the usages and
+// returns text with the closest command substituted, or an empty string if none is close.
+func closestCommand(text string, usages []string, maxDistance int) string {
+	words := strings.Fields(text)
+	best, bestDistance := "", maxDistance+1
+	for _, usage := range usages {
+		command := usageCommand(usage)
+		if len(command) == 0 || len(command) > len(words) {
+			continue
+		}
+		typed := strings.ToLower(strings.Join(words[:len(command)], " "))
+		d := levenshtein(typed, strings.Join(command, " "))
+		if d > 0 && d < bestDistance {
+			best = strings.Join(append(command, words[len(command):]...), " ")
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// usageCommand returns the leading words of a usage, up to the first argument.
+func usageCommand(usage string) []string {
+	var command []string
+	for _, w := range strings.Fields(usage) {
+		if strings.HasPrefix(w, "<") || strings.HasPrefix(w, "[") {
+			break
+		}
+		command = append(command, strings.ToLower(w))
+	}
+	return command
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,26 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, levenshtein("deploy", "deploy"))
+	assert.Equal(2, levenshtein("deplyo", "deploy"))
+	assert.Equal(1, levenshtein("deploi", "deploy"))
+	assert.Equal(6, levenshtein("", "deploy"))
+}
+
+func TestClosestCommand(t *testing.T) {
+	assert := assert.New(t)
+	usages := []string{"deploy <env>", "status [service]", "config get <key>"}
+
+	assert.Equal("deploy staging", closestCommand("deplyo staging", usages, 2))
+	assert.Equal("config get timeout", closestCommand("conifg get timeout", usages, 2))
+	assert.Equal("", closestCommand("deploy staging", usages, 2))
+	assert.Equal("", closestCommand("hello there", usages, 2))
+}
@@ -0,0 +1,106 @@
+package slackbot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultMaxTableRows is the number of rows above which tables are uploaded as CSV.
+const DefaultMaxTableRows = 20
+
+// TableOptions configures ReplyTable.
+type TableOptions struct {
+	// Title of the table, shown above it or as title of the CSV file
+	Title string
+	// Tables with more rows, or wider than a message allows, are uploaded as CSV.
+	// DefaultMaxTableRows when 0.
+	MaxRows int
+}
+
+// ReplyTable replies to a message event with a table, rendered as an aligned code block
+// when small, or uploaded as a CSV file otherwise.
+func (b *Bot) ReplyTable(evt *slack.MessageEvent, headers []string, rows [][]string, opts TableOptions) error {
+	maxRows := opts.MaxRows
+	if maxRows == 0 {
+		maxRows = DefaultMaxTableRows
+	}
+
+	table := renderTable(headers, rows)
+	if len(rows) > maxRows || utf8.RuneCountInString(table) > maxInlineSnippetLength {
+		data, err := renderCSV(headers, rows)
+		if err != nil {
+			return err
+		}
+		b.RequireScopes("files:write")
+		_, err = b.Client.UploadFile(slack.FileUploadParameters{
+			Content:         data,
+			Filename:        "table.csv",
+			Filetype:        "csv",
+			Title:           opts.Title,
+			Channels:        []string{evt.Channel},
+			ThreadTimestamp: evt.ThreadTimestamp,
+		})
+		return err
+	}
+
+	text := codeFence + "\n" + table + codeFence
+	if opts.Title != "" {
+		text = "*" + opts.Title + "*\n" + text
+	}
+	out := &OutgoingMessage{
+		Channel: evt.Channel,
+		Text:    text,
+		Params:  slack.PostMessageParameters{ThreadTimestamp: evt.ThreadTimestamp},
+	}
+	b.attachJoin(out, evt)
+	_, err := b.Send(out)
+	return err
+}
+
+// renderTable aligns the columns of the table, separating the headers from the rows.
+func renderTable(headers []string, rows [][]string) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if len(headers) > 0 {
+		w.Write([]byte(strings.Join(headers, "\t") + "\n"))
+		rule := make([]string, len(headers))
+		for i, h := range headers {
+			rule[i] = strings.Repeat("-", utf8.RuneCountInString(h))
+		}
+		w.Write([]byte(strings.Join(rule, "\t") + "\n"))
+	}
+	for _, row := range rows {
+		w.Write([]byte(strings.Join(row, "\t") + "\n"))
+	}
+	w.Flush()
+
+	// tabwriter pads the last column of rows shorter than the headers
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(strings.TrimSuffix(l, "\n"), " ")
+		if strings.HasSuffix(l, "\n") {
+			lines[i] += "\n"
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// renderCSV encodes the table as CSV.
+func renderCSV(headers []string, rows [][]string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if len(headers) > 0 {
+		if err := w.Write(headers); err != nil {
+			return "", err
+		}
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
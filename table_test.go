@@ -0,0 +1,27 @@
+package slackbot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTable(t *testing.T) {
+	assert := assert.New(t)
+
+	headers := []string{"Service", "Status", "Latency"}
+	rows := [][]string{
+		{"api", "up", "12ms"},
+		{"billing-worker", "degraded", "340ms"},
+	}
+	assert.Equal(
+		"Service         Status    Latency\n"+
+			"-------         ------    -------\n"+
+			"api             up        12ms\n"+
+			"billing-worker  degraded  340ms\n",
+		renderTable(headers, rows))
+
+	csv, err := renderCSV(headers, [][]string{{"api", "up, mostly", "12ms"}})
+	assert.NoError(err)
+	assert.Equal("Service,Status,Latency\napi,\"up, mostly\",12ms\n", csv)
+}
@@ -0,0 +1,120 @@
+package slackbot
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	TEAM_CONTEXT       = "__TEAM_CONTEXT__"
+	ENTERPRISE_CONTEXT = "__ENTERPRISE_CONTEXT__"
+)
+
+// TeamFromContext returns the ID of the workspace the event in context comes from. On
+// Enterprise Grid, a single bot may receive events of several workspaces of the organization.
+func TeamFromContext(ctx context.Context) string {
+	if result, ok := ctx.Value(TEAM_CONTEXT).(string); ok {
+		return result
+	}
+	if bot := BotFromContext(ctx); bot != nil {
+		return bot.botTeamID
+	}
+	return ""
+}
+
+// AddTeamToContext sets the workspace of the event in context and returns the newly derived context
+func AddTeamToContext(ctx context.Context, teamID string) context.Context {
+	return context.WithValue(ctx, TEAM_CONTEXT, teamID)
+}
+
+// EnterpriseFromContext returns the ID of the Enterprise Grid organization the event in
+// context comes from, empty outside of Enterprise Grid.
+func EnterpriseFromContext(ctx context.Context) string {
+	if result, ok := ctx.Value(ENTERPRISE_CONTEXT).(string); ok {
+		return result
+	}
+	if bot := BotFromContext(ctx); bot != nil {
+		return bot.botEnterpriseID
+	}
+	return ""
+}
+
+// AddEnterpriseToContext sets the organization of the event in context and returns the newly
+// derived context
+func AddEnterpriseToContext(ctx context.Context, enterpriseID string) context.Context {
+	return context.WithValue(ctx, ENTERPRISE_CONTEXT, enterpriseID)
+}
+
+// TeamStore returns the bot Store scoped to the workspace of the event in context, so that
+// workspaces of an organization do not share their values. Workspace IDs are unique across
+// organizations, values shared by the workspaces of an organization go to EnterpriseStore.
+func TeamStore(ctx context.Context) Store {
+	bot := BotFromContext(ctx)
+	return bot.StoreFor(TeamFromContext(ctx))
+}
+
+// StoreFor returns the bot Store scoped to a workspace.
+func (b *Bot) StoreFor(teamID string) Store {
+	return &prefixStore{store: b.Store(), prefix: "team:" + teamID + ":"}
+}
+
+// EnterpriseStore returns the bot Store scoped to the organization of the event in context,
+// shared by its workspaces. Outside of Enterprise Grid, it is scoped to the workspace.
+func EnterpriseStore(ctx context.Context) Store {
+	if enterprise := EnterpriseFromContext(ctx); enterprise != "" {
+		return BotFromContext(ctx).StoreForEnterprise(enterprise)
+	}
+	return TeamStore(ctx)
+}
+
+// StoreForEnterprise returns the bot Store scoped to an Enterprise Grid organization.
+func (b *Bot) StoreForEnterprise(enterpriseID string) Store {
+	return &prefixStore{store: b.Store(), prefix: "enterprise:" + enterpriseID + ":"}
+}
+
+// prefixStore namespaces the keys of a Store.
+type prefixStore struct {
+	store  Store
+	prefix string
+}
+
+func (s *prefixStore) Get(key string) ([]byte, bool, error) {
+	return s.store.Get(s.prefix + key)
+}
+
+func (s *prefixStore) Set(key string, value []byte, ttl time.Duration) error {
+	return s.store.Set(s.prefix+key, value, ttl)
+}
+
+func (s *prefixStore) Delete(key string) error {
+	return s.store.Delete(s.prefix + key)
+}
+
+// Teams restricts the route to events of the given workspaces, typically to configure routes
+// per workspace of an Enterprise Grid organization.
+func (r *Route) Teams(teamIDs ...string) *Route {
+	return r.AddMatcher(&TeamMatcher{teams: teamIDs})
+}
+
+// ============================================================================
+// Team Matcher
+// ============================================================================
+
+type TeamMatcher struct {
+	teams     []string
+	botUserID string
+}
+
+func (tm *TeamMatcher) Match(ctx context.Context) (bool, context.Context) {
+	team := TeamFromContext(ctx)
+	for _, t := range tm.teams {
+		if t == team {
+			return true, ctx
+		}
+	}
+	return false, ctx
+}
+
+func (tm *TeamMatcher) SetBotID(botID string) {
+	tm.botUserID = botID
+}
@@ -0,0 +1,71 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeams(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	bot.botTeamID = "T1"
+	ctx := AddBotToContext(context.Background(), bot)
+	var heard []string
+	bot.Hear("^deploy$").Teams("T2").Handler(func(ctx context.Context) {
+		heard = append(heard, "T2 "+TeamFromContext(ctx))
+	})
+	bot.Hear("^deploy$").Handler(func(ctx context.Context) {
+		heard = append(heard, "default "+TeamFromContext(ctx))
+	})
+
+	for _, team := range []string{"T2", "T3", ""} {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.User, evt.Text, evt.Team = "C1", "U1", "deploy", team
+		bot.handleMessage(ctx, evt)
+	}
+	// events without a team come from the workspace of the bot
+	assert.Equal([]string{"T2 T2", "default T3", "default T1"}, heard)
+}
+
+func TestTeamStore(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+
+	assert.NoError(TeamStore(AddTeamToContext(ctx, "T1")).Set("motd", []byte("hello"), 0))
+	_, found, err := TeamStore(AddTeamToContext(ctx, "T2")).Get("motd")
+	assert.NoError(err)
+	assert.False(found)
+	value, found, _ := bot.StoreFor("T1").Get("motd")
+	assert.True(found)
+	assert.Equal("hello", string(value))
+
+	assert.NoError(bot.StoreFor("T1").Delete("motd"))
+	_, found, _ = bot.Store().Get("team:T1:motd")
+	assert.False(found)
+}
+
+func TestEnterpriseStore(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+	grid := AddEnterpriseToContext(AddTeamToContext(ctx, "T1"), "E1")
+
+	// the workspaces of an organization share its store
+	assert.NoError(EnterpriseStore(grid).Set("motd", []byte("hello"), 0))
+	value, found, _ := EnterpriseStore(AddEnterpriseToContext(AddTeamToContext(ctx, "T2"), "E1")).Get("motd")
+	assert.True(found)
+	assert.Equal("hello", string(value))
+	_, found, _ = TeamStore(grid).Get("motd")
+	assert.False(found)
+	_, found, _ = bot.Store().Get("enterprise:E1:motd")
+	assert.True(found)
+
+	// outside of Enterprise Grid, it is the store of the workspace
+	assert.NoError(EnterpriseStore(AddTeamToContext(ctx, "T3")).Set("motd", []byte("hi"), 0))
+	value, _, _ = bot.StoreFor("T3").Get("motd")
+	assert.Equal("hi", string(value))
+}
@@ -0,0 +1,146 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultThreadIdle is how long a ThreadRouter lives without matching a message.
+const DefaultThreadIdle = 10 * time.Minute
+
+// ThreadRouter is a temporary router whose routes only match the messages of one thread.
+// Its routes are matched before those of the bot, without requiring the messages to address
+// the bot, and it is closed once no message matched for its idle duration. It is handy for
+// interactions spanning several messages in a thread:
+//
+//	thread := bot.ThreadRouter(evt.Timestamp)
+//	thread.Hear("(?i)^yes$").MessageHandler(ConfirmHandler)
+//	thread.Hear("(?i)^no$").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+//		slackbot.ThreadRouterFromContext(ctx).Close()
+//	})
+type ThreadRouter struct {
+	SimpleRouter
+	bot      *Bot
+	threadTS string
+
+	mu       sync.Mutex
+	idle     time.Duration
+	timer    *time.Timer
+	onExpire []func()
+	closed   bool
+}
+
+const THREAD_ROUTER_CONTEXT = "__THREAD_ROUTER_CONTEXT__"
+
+// ThreadRouterFromContext returns the ThreadRouter whose route matched the message.
+func ThreadRouterFromContext(ctx context.Context) *ThreadRouter {
+	if t, ok := ctx.Value(THREAD_ROUTER_CONTEXT).(*ThreadRouter); ok {
+		return t
+	}
+	return nil
+}
+
+// ThreadRouter returns the router of the thread, created if it is not open. The thread is
+// identified by the ts of its parent message.
+func (b *Bot) ThreadRouter(threadTS string) *ThreadRouter {
+	b.threadsMu.Lock()
+	defer b.threadsMu.Unlock()
+	if t, ok := b.threads[threadTS]; ok {
+		return t
+	}
+	if b.threads == nil {
+		b.threads = make(map[string]*ThreadRouter)
+	}
+	t := &ThreadRouter{bot: b, threadTS: threadTS, idle: DefaultThreadIdle}
+	t.botUserID = b.botUserID
+	t.timer = time.AfterFunc(t.idle, t.Close)
+	b.threads[threadTS] = t
+	return t
+}
+
+// ExpireAfter changes how long the router lives without matching a message.
+func (t *ThreadRouter) ExpireAfter(idle time.Duration) *ThreadRouter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.idle = idle
+	if !t.closed {
+		t.timer.Reset(idle)
+	}
+	return t
+}
+
+// OnExpire registers a function called when the router is closed, after being idle or by Close.
+func (t *ThreadRouter) OnExpire(fn func()) *ThreadRouter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onExpire = append(t.onExpire, fn)
+	return t
+}
+
+// Close removes the router, its routes no longer matching.
+func (t *ThreadRouter) Close() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.closed = true
+	t.timer.Stop()
+	callbacks := t.onExpire
+	t.mu.Unlock()
+
+	t.bot.threadsMu.Lock()
+	if t.bot.threads[t.threadTS] == t {
+		delete(t.bot.threads, t.threadTS)
+	}
+	t.bot.threadsMu.Unlock()
+	for _, fn := range callbacks {
+		fn()
+	}
+}
+
+// Closed returns true once the router expired or was closed.
+func (t *ThreadRouter) Closed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+// Match matches the routes of the thread, and keeps the router alive when one matches.
+func (t *ThreadRouter) Match(ctx context.Context, match *RouteMatch) (bool, context.Context) {
+	evt := MessageFromContext(ctx)
+	if evt == nil || evt.ThreadTimestamp != t.threadTS || t.Closed() {
+		return false, ctx
+	}
+	// the thread is a conversation with the bot, its messages need not mention it
+	matched, routed := t.SimpleRouter.Match(context.WithValue(ctx, ADDRESSED_CONTEXT, true), match)
+	if !matched {
+		return false, ctx
+	}
+	ctx = routed
+	t.mu.Lock()
+	if !t.closed {
+		t.timer.Reset(t.idle)
+	}
+	t.mu.Unlock()
+	return true, context.WithValue(ctx, THREAD_ROUTER_CONTEXT, t)
+}
+
+// matchRoutes matches the routes of the thread of the message, if it has a router, then
+// those of the bot.
+func (b *Bot) matchRoutes(ctx context.Context, evt *slack.MessageEvent, match *RouteMatch) (bool, context.Context) {
+	if evt.ThreadTimestamp != "" {
+		b.threadsMu.Lock()
+		t := b.threads[evt.ThreadTimestamp]
+		b.threadsMu.Unlock()
+		if t != nil {
+			if matched, ctx := t.Match(ctx, match); matched {
+				return true, ctx
+			}
+		}
+	}
+	return b.Match(ctx, match)
+}
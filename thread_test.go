@@ -0,0 +1,45 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreadRouter(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").CommandPrefix("!")
+	var handled []string
+	bot.Hear("^yes$").Handler(func(ctx context.Context) { handled = append(handled, "bot") })
+	thread := bot.ThreadRouter("1.0")
+	thread.Hear("^yes$").Handler(func(ctx context.Context) {
+		handled = append(handled, "thread")
+		assert.Equal(thread, ThreadRouterFromContext(ctx))
+	})
+	assert.Equal(thread, bot.ThreadRouter("1.0"))
+
+	run := func(threadTS, text string) bool {
+		evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", Text: text, ThreadTimestamp: threadTS}}
+		ctx := AddTextToContext(AddMessageToContext(AddBotToContext(context.Background(), bot), evt), text)
+		var match RouteMatch
+		matched, ctx := bot.matchRoutes(ctx, evt, &match)
+		if matched {
+			match.Handler(ctx)
+		}
+		return matched
+	}
+	assert.True(run("1.0", "yes"))
+	// outside the thread, the bot requires the prefix
+	assert.False(run("2.0", "yes"))
+	assert.Equal([]string{"thread"}, handled)
+
+	expired := make(chan struct{})
+	thread.OnExpire(func() { close(expired) }).ExpireAfter(10 * time.Millisecond)
+	<-expired
+	assert.True(thread.Closed())
+	assert.False(run("1.0", "yes"))
+	assert.NotEqual(thread, bot.ThreadRouter("1.0"))
+}
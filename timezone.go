@@ -0,0 +1,100 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// timezoneCacheTTL is how long user timezones are cached.
+const timezoneCacheTTL = time.Hour
+
+type cachedLocation struct {
+	loc     *time.Location
+	expires time.Time
+}
+
+// timezoneCache keeps the timezones of users, keyed by ID.
+type timezoneCache struct {
+	mu    sync.Mutex
+	users map[string]cachedLocation
+}
+
+// UserLocation returns the timezone set in the profile of a user, cached for an hour.
+func (b *Bot) UserLocation(userID string) (*time.Location, error) {
+	b.timezones.mu.Lock()
+	cached, ok := b.timezones.users[userID]
+	b.timezones.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.loc, nil
+	}
+
+	user, err := b.Client.GetUserInfo(userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(user.TZ)
+	if err != nil {
+		loc = time.FixedZone(user.TZLabel, user.TZOffset)
+	}
+	b.timezones.mu.Lock()
+	if b.timezones.users == nil {
+		b.timezones.users = make(map[string]cachedLocation)
+	}
+	b.timezones.users[userID] = cachedLocation{loc: loc, expires: time.Now().Add(timezoneCacheTTL)}
+	b.timezones.mu.Unlock()
+	return loc, nil
+}
+
+// TimeForUser returns t in the timezone of the author of the message in context, or
+// unchanged if it cannot be resolved.
+func TimeForUser(ctx context.Context, t time.Time) time.Time {
+	bot := BotFromContext(ctx)
+	msg := MessageFromContext(ctx)
+	if bot == nil || msg == nil {
+		return t
+	}
+	loc, err := bot.UserLocation(msg.User)
+	if err != nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// FormatTimeForUser formats t with the layout in the timezone of the author of the message
+// in context.
+func FormatTimeForUser(ctx context.Context, t time.Time, layout string) string {
+	return TimeForUser(ctx, t).Format(layout)
+}
+
+// UserScheduledFunc is a function run by the scheduler for a user.
+type UserScheduledFunc func(ctx context.Context, bot *Bot, userID string)
+
+// userDailyAt runs every day at the given time of the timezone of a user, resolved anew
+// for each run so profile changes are followed.
+type userDailyAt struct {
+	bot          *Bot
+	userID       string
+	hour, minute int
+}
+
+func (d userDailyAt) Next(t time.Time) time.Time {
+	loc, err := d.bot.UserLocation(d.userID)
+	if err != nil {
+		loc = time.UTC
+	}
+	return dailyAt{hour: d.hour, minute: d.minute, loc: loc}.Next(t)
+}
+
+// ScheduleForUsers registers a function run every day at the given time in the timezone of
+// each user, e.g. 9am wherever each recipient is.
+func (b *Bot) ScheduleForUsers(hour, minute int, userIDs []string, fn UserScheduledFunc) *Bot {
+	b.RequireScopes("users:read")
+	for _, userID := range userIDs {
+		userID := userID
+		b.Schedule(userDailyAt{bot: b, userID: userID, hour: hour, minute: minute}, func(ctx context.Context, bot *Bot) {
+			fn(ctx, bot, userID)
+		})
+	}
+	return b
+}
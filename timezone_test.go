@@ -0,0 +1,54 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserLocation(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U1", "tz": "Europe/Paris", "tz_label": "Central European Time", "tz_offset": 3600}}`)
+
+	now := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	msg := &slack.MessageEvent{}
+	msg.User = "U1"
+	ctx := AddMessageToContext(AddBotToContext(context.Background(), bot), msg)
+	assert.Equal("09:00", FormatTimeForUser(ctx, now, "15:04"))
+	loc, err := bot.UserLocation("U1")
+	assert.NoError(err)
+	assert.Equal("Europe/Paris", loc.String())
+	// timezones are cached
+	assert.Equal([]string{"U1"}, api.values("users.info", "user"))
+
+	// unknown timezones fall back to the offset
+	api.respond("users.info", `{"ok": true, "user": {"id": "U2", "tz": "Mars/Olympus", "tz_label": "Olympus Time", "tz_offset": -7200}}`)
+	msg.User = "U2"
+	assert.Equal("06:00", FormatTimeForUser(ctx, now, "15:04"))
+
+	// times are left unchanged when the user cannot be resolved
+	api.respond("users.info", `{"ok": false, "error": "user_not_found"}`)
+	msg.User = "U3"
+	assert.Equal(now, TimeForUser(ctx, now))
+	assert.Equal(now, TimeForUser(context.Background(), now))
+}
+
+func TestScheduleForUsers(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("users.info", `{"ok": true, "user": {"id": "U1", "tz": "America/New_York"}}`)
+	bot.ScheduleForUsers(9, 0, []string{"U1"}, func(ctx context.Context, bot *Bot, userID string) {})
+
+	if assert.Len(bot.scheduled, 1) {
+		next := bot.scheduled[0].schedule.Next(time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC))
+		// 9am in New York is 2pm UTC in winter
+		assert.Equal(time.Date(2026, 1, 15, 14, 0, 0, 0, time.UTC), next.UTC())
+	}
+	assert.True(bot.requiredScopes["users:read"])
+}
@@ -0,0 +1,78 @@
+package slackbot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// TopicFunc computes the topic of a channel at a given time.
+type TopicFunc func(now time.Time) (string, error)
+
+// Rotation cycles through members, e.g. an on-call schedule, one member per period.
+type Rotation struct {
+	Members []string
+	// Start of the period of the first member
+	Start  time.Time
+	Period time.Duration
+}
+
+// Current returns the member on duty at t.
+func (r Rotation) Current(t time.Time) string {
+	if len(r.Members) == 0 || r.Period <= 0 {
+		return ""
+	}
+	elapsed := t.Sub(r.Start)
+	n := int(elapsed / r.Period)
+	if elapsed < 0 && elapsed%r.Period != 0 {
+		n--
+	}
+	n %= len(r.Members)
+	if n < 0 {
+		n += len(r.Members)
+	}
+	return r.Members[n]
+}
+
+// RotationTopic renders the template with the current member of the rotation as .Member and
+// the time as .Now, e.g. "On call this week: <@{{.Member}}>".
+func RotationTopic(tmpl string, rotation Rotation) TopicFunc {
+	t := template.Must(template.New("topic").Parse(tmpl))
+	return func(now time.Time) (string, error) {
+		var buf bytes.Buffer
+		err := t.Execute(&buf, struct {
+			Member string
+			Now    time.Time
+		}{rotation.Current(now), now})
+		return buf.String(), err
+	}
+}
+
+// RotateTopic sets the topic of the channel on the schedule. The topic is only changed when
+// it differs from the current one, so Slack does not announce unchanged topics.
+func (b *Bot) RotateTopic(channel string, schedule Schedule, topic TopicFunc) *Bot {
+	b.RequireScopes("channels:read", "channels:manage")
+	return b.Schedule(schedule, func(ctx context.Context, bot *Bot) {
+		if err := bot.setTopic(channel, topic); err != nil {
+			fmt.Printf("Error rotating topic of %s: %s\n", channel, err)
+		}
+	})
+}
+
+func (b *Bot) setTopic(channel string, topic TopicFunc) error {
+	text, err := topic(time.Now())
+	if err != nil {
+		return err
+	}
+	current, err := b.Client.GetConversationInfo(channel, false)
+	if err != nil {
+		return err
+	}
+	if current.Topic.Value == text {
+		return nil
+	}
+	_, err = b.Client.SetTopicOfConversation(channel, text)
+	return err
+}
@@ -0,0 +1,23 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotation(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Date(2020, 7, 13, 0, 0, 0, 0, time.UTC)
+	r := Rotation{Members: []string{"U1", "U2", "U3"}, Start: start, Period: 7 * 24 * time.Hour}
+
+	assert.Equal("U1", r.Current(start))
+	assert.Equal("U2", r.Current(start.AddDate(0, 0, 8)))
+	assert.Equal("U1", r.Current(start.AddDate(0, 0, 21)))
+	assert.Equal("U3", r.Current(start.AddDate(0, 0, -1)))
+
+	topic, err := RotationTopic("On call: <@{{.Member}}>", r)(start)
+	assert.NoError(err)
+	assert.Equal("On call: <@U1>", topic)
+}
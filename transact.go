@@ -0,0 +1,56 @@
+package slackbot
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// TransactFailureText is the default reply sent when a transaction fails. It is formatted
+// with the error returned by the transaction.
+var TransactFailureText = ":x: Sorry, that didn't work: %s"
+
+// Tx queues replies that are only sent once the transaction succeeds.
+type Tx struct {
+	bot     *Bot
+	evt     *slack.MessageEvent
+	failure string
+	replies []func()
+}
+
+// Transact runs fn and sends the replies queued on the Tx only if it returns nil. When it
+// returns an error, the queued replies are dropped and the failure message is sent instead.
+func (b *Bot) Transact(evt *slack.MessageEvent, fn func(tx *Tx) error) error {
+	tx := &Tx{bot: b, evt: evt, failure: TransactFailureText}
+	if err := fn(tx); err != nil {
+		if tx.failure != "" {
+			b.Reply(evt, fmt.Sprintf(tx.failure, err))
+		}
+		return err
+	}
+	for _, reply := range tx.replies {
+		reply()
+	}
+	return nil
+}
+
+// OnFailure sets the failure message of the transaction, formatted with the error.
+// An empty message sends nothing on failure.
+func (tx *Tx) OnFailure(format string) {
+	tx.failure = format
+}
+
+// Reply queues a simple reply.
+func (tx *Tx) Reply(msg string, opts ...ReplyOption) {
+	tx.replies = append(tx.replies, func() { tx.bot.Reply(tx.evt, msg, opts...) })
+}
+
+// ReplyPost queues a simple reply sent using Slack API.
+func (tx *Tx) ReplyPost(msg string, opts ...ReplyOption) {
+	tx.replies = append(tx.replies, func() { tx.bot.ReplyPost(tx.evt, msg, opts...) })
+}
+
+// ReplyWithAttachments queues a Slack Attachments reply.
+func (tx *Tx) ReplyWithAttachments(attachments []slack.Attachment, opts ...ReplyOption) {
+	tx.replies = append(tx.replies, func() { tx.bot.ReplyWithAttachments(tx.evt, attachments, opts...) })
+}
@@ -0,0 +1,46 @@
+package slackbot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransact(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+
+	assert.NoError(bot.Transact(evt, func(tx *Tx) error {
+		tx.Reply("step 1 done")
+		tx.Reply("step 2 done")
+		assert.Empty(api.calls("text"))
+		return nil
+	}))
+	assert.Equal([]string{"step 1 done", "step 2 done"}, api.values("chat.postMessage", "text"))
+}
+
+func TestTransactFailure(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	evt := &slack.MessageEvent{}
+	evt.Channel = "C1"
+
+	err := bot.Transact(evt, func(tx *Tx) error {
+		tx.Reply("step 1 done")
+		return errors.New("disk full")
+	})
+	assert.EqualError(err, "disk full")
+	assert.Equal([]string{":x: Sorry, that didn't work: disk full"}, api.values("chat.postMessage", "text"))
+
+	bot.Transact(evt, func(tx *Tx) error {
+		tx.OnFailure("")
+		return errors.New("disk full")
+	})
+	assert.Len(api.calls("text"), 1)
+}
@@ -0,0 +1,121 @@
+package slackbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// TranscriptMessage is a message of a transcript.
+type TranscriptMessage struct {
+	User      string    `json:"user"`
+	UserName  string    `json:"user_name"`
+	Timestamp string    `json:"ts"`
+	Time      time.Time `json:"time"`
+	Text      string    `json:"text"`
+}
+
+// Transcript is the content of a thread, e.g. for incident postmortems or support handoffs.
+type Transcript struct {
+	Channel  string              `json:"channel"`
+	ThreadTS string              `json:"thread_ts"`
+	Messages []TranscriptMessage `json:"messages"`
+}
+
+// Transcript collects the messages of a thread, with the names of their authors.
+func (b *Bot) Transcript(channel, threadTS string) (*Transcript, error) {
+	b.RequireScopes("channels:history", "users:read")
+	t := &Transcript{Channel: channel, ThreadTS: threadTS}
+	names := make(map[string]string)
+	params := &slack.GetConversationRepliesParameters{ChannelID: channel, Timestamp: threadTS}
+	for {
+		msgs, hasMore, cursor, err := b.Client.GetConversationReplies(params)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		for _, m := range msgs {
+			t.Messages = append(t.Messages, TranscriptMessage{
+				User:      m.User,
+				UserName:  b.userName(m.User, m.Username, names),
+				Timestamp: m.Timestamp,
+				Time:      TimestampTime(m.Timestamp),
+				Text:      m.Text,
+			})
+		}
+		if !hasMore || cursor == "" {
+			return t, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// userName resolves the display name of a user, caching it in names. Bot messages may only
+// have a username.
+func (b *Bot) userName(userID, username string, names map[string]string) string {
+	if userID == "" {
+		return username
+	}
+	if name, ok := names[userID]; ok {
+		return name
+	}
+	name := userID
+	if user, err := b.Client.GetUserInfo(userID); err == nil {
+		name = user.Profile.DisplayName
+		if name == "" {
+			name = user.RealName
+		}
+		if name == "" {
+			name = user.Name
+		}
+	}
+	names[userID] = name
+	return name
+}
+
+// TimestampTime converts a Slack message ts to a time, or the zero time when
+// it is not one.
+func TimestampTime(ts string) time.Time {
+	secs, micros, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	usec, _ := strconv.ParseInt((micros + "000000")[:6], 10, 64)
+	return time.Unix(sec, usec*int64(time.Microsecond)).UTC()
+}
+
+const transcriptTimeLayout = "2006-01-02 15:04:05 MST"
+
+// Markdown renders the transcript as Markdown.
+func (t *Transcript) Markdown() string {
+	var buf strings.Builder
+	for _, m := range t.Messages {
+		fmt.Fprintf(&buf, "**%s** _%s_\n\n%s\n\n", m.UserName, m.Time.Format(transcriptTimeLayout), m.Text)
+	}
+	return buf.String()
+}
+
+// HTML renders the transcript as an HTML fragment.
+func (t *Transcript) HTML() string {
+	var buf strings.Builder
+	buf.WriteString("<div class=\"transcript\">\n")
+	for _, m := range t.Messages {
+		fmt.Fprintf(&buf, "<div class=\"message\"><strong>%s</strong> <time datetime=\"%s\">%s</time><p>%s</p></div>\n",
+			html.EscapeString(m.UserName),
+			m.Time.Format(time.RFC3339),
+			m.Time.Format(transcriptTimeLayout),
+			strings.ReplaceAll(html.EscapeString(m.Text), "\n", "<br>"))
+	}
+	buf.WriteString("</div>\n")
+	return buf.String()
+}
+
+// JSON renders the transcript as JSON.
+func (t *Transcript) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
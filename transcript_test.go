@@ -0,0 +1,26 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscript(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(time.Date(2014, time.February, 18, 14, 39, 42, 123000000, time.UTC), TimestampTime("1392734382.123000"))
+
+	transcript := &Transcript{Channel: "C1", ThreadTS: "1392734382.000100", Messages: []TranscriptMessage{
+		{User: "U1", UserName: "alice", Time: TimestampTime("1392734382.000100"), Text: "API is down"},
+		{User: "U2", UserName: "bob", Time: TimestampTime("1392734442.000100"), Text: "rolling back <deploy>\ndone"},
+	}}
+	assert.Equal("**alice** _2014-02-18 14:39:42 UTC_\n\nAPI is down\n\n"+
+		"**bob** _2014-02-18 14:40:42 UTC_\n\nrolling back <deploy>\ndone\n\n", transcript.Markdown())
+	assert.Contains(transcript.HTML(), "<strong>bob</strong> <time datetime=\"2014-02-18T14:40:42Z\">2014-02-18 14:40:42 UTC</time><p>rolling back &lt;deploy&gt;<br>done</p>")
+
+	data, err := transcript.JSON()
+	assert.NoError(err)
+	assert.Contains(string(data), `"user_name": "alice"`)
+}
@@ -0,0 +1,99 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TypedHandler handles a command whose arguments are parsed into a struct of type T.
+type TypedHandler[T any] func(ctx context.Context, bot *Bot, args T) error
+
+// Handle registers a command described by its usage, e.g. "deploy <env> [replicas]", whose
+// arguments are parsed and validated into the fields of T before fn runs. Fields are bound
+// to arguments by their `arg` tag, or by name, and may be strings, ints, bools or durations,
+// or types defined from them. Arguments in angle brackets are required, those in square
+// brackets optional. When T does not fit the usage, the route never matches and reports the
+// error with GetError.
+//
+//	type DeployArgs struct {
+//		Env      string `arg:"env"`
+//		Replicas int    `arg:"replicas"`
+//	}
+//	slackbot.Handle(bot, "deploy <env> [replicas]", func(ctx context.Context, bot *slackbot.Bot, args DeployArgs) error {...})
+func Handle[T any](router Router, usage string, fn TypedHandler[T]) *Route {
+	route := router.Hear(commandRegexp(strings.Join(usageCommand(usage), " "))).Usage(usage)
+
+	schema, fields, err := typedSchema(usage, reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		route.err = err
+		return route
+	}
+	return route.Args(schema).Handler(func(ctx context.Context) {
+		var args T
+		v := reflect.ValueOf(&args).Elem()
+		for name, value := range ArgsFromContext(ctx) {
+			field := v.FieldByIndex(fields[name])
+			field.Set(reflect.ValueOf(value).Convert(field.Type()))
+		}
+		if err := fn(ctx, BotFromContext(ctx), args); err != nil {
+			BotFromContext(ctx).HandleError(ctx, err)
+			BotFromContext(ctx).Reply(MessageFromContext(ctx), fmt.Sprintf(":x: %s", err))
+		}
+	})
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringType   = reflect.TypeOf("")
+	intType      = reflect.TypeOf(0)
+	boolType     = reflect.TypeOf(false)
+)
+
+// typedSchema derives the argument schema of a usage from the fields of the struct type t,
+// along with the field bound to each argument.
+func typedSchema(usage string, t reflect.Type) (Schema, map[string][]int, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("slackbot: arguments of %q must be a struct, got %s", usage, t)
+	}
+	var schema Schema
+	fields := map[string][]int{}
+	for _, w := range strings.Fields(usage)[len(usageCommand(usage)):] {
+		required := strings.HasPrefix(w, "<")
+		name := strings.Trim(w, "<>[]")
+		field, ok := argField(t, name)
+		if !ok {
+			return nil, nil, fmt.Errorf("slackbot: no field of %s for argument %q", t, name)
+		}
+		arg := Arg{Name: name, Required: required}
+		// type of the values parsed for the argument
+		var parsed reflect.Type
+		switch {
+		case field.Type == durationType:
+			arg.Type, parsed = DurationArg, durationType
+		case field.Type.Kind() == reflect.String:
+			arg.Type, parsed = StringArg, stringType
+		case field.Type.Kind() == reflect.Int:
+			arg.Type, parsed = IntArg, intType
+		case field.Type.Kind() == reflect.Bool:
+			arg.Type, parsed = BoolArg, boolType
+		}
+		if parsed == nil || !parsed.ConvertibleTo(field.Type) {
+			return nil, nil, fmt.Errorf("slackbot: unsupported type %s of argument %q", field.Type, name)
+		}
+		schema = append(schema, arg)
+		fields[name] = field.Index
+	}
+	return schema, fields, nil
+}
+
+func argField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Tag.Get("arg") == name {
+			return f, true
+		}
+	}
+	return t.FieldByNameFunc(func(field string) bool { return strings.EqualFold(field, name) })
+}
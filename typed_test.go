@@ -0,0 +1,76 @@
+package slackbot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	type deployArgs struct {
+		Env      string `arg:"env"`
+		Replicas int
+		Force    bool `arg:"force"`
+	}
+
+	schema, fields, err := typedSchema("deploy <env> [replicas] [force]", reflect.TypeOf(deployArgs{}))
+	assert.NoError(err)
+	assert.Equal(Schema{
+		{Name: "env", Type: StringArg, Required: true},
+		{Name: "replicas", Type: IntArg},
+		{Name: "force", Type: BoolArg},
+	}, schema)
+	assert.Equal([]int{1}, fields["replicas"])
+
+	_, _, err = typedSchema("deploy <region>", reflect.TypeOf(deployArgs{}))
+	assert.Error(err)
+}
+
+type environment string
+
+func TestHandleNamedTypes(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+
+	type deployArgs struct {
+		Env     environment `arg:"env"`
+		Timeout time.Duration
+	}
+	var got deployArgs
+	route := Handle(bot, "deploy <env> [timeout]", func(ctx context.Context, bot *Bot, args deployArgs) error {
+		got = args
+		return nil
+	})
+	assert.NoError(route.GetError())
+
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", "deploy staging 5m"
+	bot.handleMessage(ctx, evt)
+	assert.Equal(deployArgs{Env: "staging", Timeout: 5 * time.Minute}, got)
+}
+
+func TestHandleInvalidArgs(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	ctx := AddBotToContext(context.Background(), bot)
+
+	type deployArgs struct {
+		Env []string
+	}
+	route := Handle(bot, "deploy <env>", func(ctx context.Context, bot *Bot, args deployArgs) error {
+		return nil
+	})
+	assert.Error(route.GetError())
+
+	// the route does not match, rather than running without a handler
+	evt := &slack.MessageEvent{}
+	evt.Channel, evt.User, evt.Text = "C1", "U1", "deploy staging"
+	assert.NotPanics(func() { bot.handleMessage(ctx, evt) })
+}
@@ -11,6 +11,7 @@ type MessageType string
 const (
 	DirectMessage MessageType = "direct_message"
 	DirectMention MessageType = "direct_mention"
+	GroupMessage  MessageType = "group_message"
 	Mention       MessageType = "mention"
 	Ambient       MessageType = "ambient"
 )
@@ -19,6 +20,12 @@ type Handler func(context.Context)
 type MessageHandler func(ctx context.Context, bot *Bot, msg *slack.MessageEvent)
 type Preprocessor func(context.Context) context.Context
 
+// Middleware wraps a route handler, typically to decide whether and how it runs
+type Middleware func(Handler) Handler
+
+// AckHandler does the work of an acknowledged command and returns the text of the final reply
+type AckHandler func(ctx context.Context, bot *Bot, msg *slack.MessageEvent) (string, error)
+
 // Matcher type for matching message routes
 type Matcher interface {
 	Match(context.Context) (bool, context.Context)
@@ -0,0 +1,82 @@
+package slackbot
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// TypingOptions configures the typing simulation preceding replies.
+type TypingOptions struct {
+	// Typing speed, in words of 5 characters per minute
+	WPM int
+	// The delay varies randomly by up to Jitter, in both directions
+	Jitter time.Duration
+	// Upper bound of the delay, whatever the length of the reply
+	MaxDelay time.Duration
+}
+
+// DefaultTyping is the typing simulation used unless configured otherwise.
+var DefaultTyping = TypingOptions{WPM: 600, MaxDelay: maxTypingSleepMs}
+
+// delay returns how long typing a reply of n characters takes.
+func (o TypingOptions) delay(n int) time.Duration {
+	var d time.Duration
+	if o.WPM > 0 {
+		d = time.Minute * time.Duration(n) / time.Duration(o.WPM*5)
+	}
+	if o.Jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(2*o.Jitter))) - o.Jitter
+	}
+	if d < 0 {
+		d = 0
+	}
+	if o.MaxDelay > 0 && d > o.MaxDelay {
+		d = o.MaxDelay
+	}
+	return d
+}
+
+// SetTyping sets the typing simulation of the bot, DefaultTyping otherwise.
+func (b *Bot) SetTyping(opts TypingOptions) *Bot {
+	b.typing = &opts
+	return b
+}
+
+// Typing sets the typing simulation of the replies sent by the handler of the route.
+func (r *Route) Typing(opts TypingOptions) *Route {
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot, evt := BotFromContext(ctx), MessageFromContext(ctx)
+			if bot == nil || evt == nil {
+				next(ctx)
+				return
+			}
+			bot.routeTyping.Store(evt, opts)
+			defer bot.routeTyping.Delete(evt)
+			next(ctx)
+		}
+	})
+}
+
+// typingFor returns the typing simulation of replies to the message event.
+func (b *Bot) typingFor(evt *slack.MessageEvent) TypingOptions {
+	if opts, ok := b.routeTyping.Load(evt); ok {
+		return opts.(TypingOptions)
+	}
+	if b.typing != nil {
+		return *b.typing
+	}
+	return DefaultTyping
+}
+
+// TypeWith sends a typing message and waits as long as typing msg takes with the options.
+func (b *Bot) TypeWith(evt *slack.MessageEvent, msg interface{}, opts TypingOptions) {
+	// typing indicators are only available through RTM
+	if b.RTM != nil {
+		b.RTM.SendMessage(b.RTM.NewTypingMessage(evt.Channel))
+	}
+	time.Sleep(opts.delay(msgLen(msg)))
+}
@@ -0,0 +1,24 @@
+package slackbot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypingDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(200*time.Millisecond, DefaultTyping.delay(10))
+	assert.Equal(2*time.Second, DefaultTyping.delay(1000))
+
+	slow := TypingOptions{WPM: 60}
+	assert.Equal(10*time.Second, slow.delay(50))
+
+	jittery := TypingOptions{WPM: 60, Jitter: time.Second, MaxDelay: 3 * time.Second}
+	for i := 0; i < 20; i++ {
+		d := jittery.delay(10)
+		assert.True(d >= time.Second && d < 3*time.Second, d)
+	}
+}
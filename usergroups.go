@@ -0,0 +1,96 @@
+package slackbot
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// userGroupCacheTTL is how long the members of user groups are cached.
+const userGroupCacheTTL = 5 * time.Minute
+
+type cachedUserGroup struct {
+	members map[string]bool
+	expires time.Time
+}
+
+// userGroupCache keeps the members of user groups, keyed by ID.
+type userGroupCache struct {
+	mu     sync.Mutex
+	groups map[string]cachedUserGroup
+}
+
+// UserGroupMembers returns the IDs of the members of a user group, cached for a few minutes.
+func (b *Bot) UserGroupMembers(groupID string) (map[string]bool, error) {
+	b.userGroups.mu.Lock()
+	cached, ok := b.userGroups.groups[groupID]
+	b.userGroups.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.members, nil
+	}
+
+	ids, err := b.Client.GetUserGroupMembers(groupID)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	members := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		members[id] = true
+	}
+	b.userGroups.mu.Lock()
+	if b.userGroups.groups == nil {
+		b.userGroups.groups = make(map[string]cachedUserGroup)
+	}
+	b.userGroups.groups[groupID] = cachedUserGroup{members: members, expires: time.Now().Add(userGroupCacheTTL)}
+	b.userGroups.mu.Unlock()
+	return members, nil
+}
+
+// IsInUserGroup returns true if the user is a member of one of the user groups.
+func (b *Bot) IsInUserGroup(userID string, groupIDs ...string) bool {
+	for _, group := range groupIDs {
+		if members, err := b.UserGroupMembers(group); err == nil && members[userID] {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowlist lists the users allowed to do something, by ID or as members of user groups. The
+// empty Allowlist allows nobody.
+type Allowlist struct {
+	Users  []string
+	Groups []string
+}
+
+// Allows returns true if the user is in the allowlist or a member of one of its user groups.
+func (b *Bot) Allows(list Allowlist, userID string) bool {
+	for _, id := range list.Users {
+		if id == userID {
+			return true
+		}
+	}
+	return b.IsInUserGroup(userID, list.Groups...)
+}
+
+// NotAllowedText is the reply sent when a user outside the allowlist of a route invokes it.
+var NotAllowedText = "Sorry, you are not allowed to do that."
+
+// Allow restricts the route to the users of the allowlist. Other users receive the text, or
+// NotAllowedText when empty.
+func (r *Route) Allow(list Allowlist, text string) *Route {
+	if text == "" {
+		text = NotAllowedText
+	}
+	return r.Use(func(next Handler) Handler {
+		return func(ctx context.Context) {
+			bot := BotFromContext(ctx)
+			msg := MessageFromContext(ctx)
+			if !bot.Allows(list, msg.User) {
+				bot.Reply(msg, text)
+				return
+			}
+			next(ctx)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	api := newSlackAPI(t, bot)
+	api.respond("usergroups.users.list", `{"ok": true, "users": ["U2"]}`)
+
+	var ran []string
+	bot.Hear("deploy").Allow(Allowlist{Users: []string{"U1"}, Groups: []string{"S1"}}, "").Handler(func(ctx context.Context) {
+		ran = append(ran, MessageFromContext(ctx).User+" deploy")
+	})
+	bot.Hear("purge").Allow(Allowlist{}, "Nobody purges.").Handler(func(ctx context.Context) {
+		ran = append(ran, MessageFromContext(ctx).User+" purge")
+	})
+	for _, text := range []string{"deploy", "purge"} {
+		for _, user := range []string{"U1", "U2", "U3"} {
+			evt := &slack.MessageEvent{}
+			evt.Channel, evt.User, evt.Text = "C1", user, text
+			bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+		}
+	}
+	assert.Equal([]string{"U1 deploy", "U2 deploy"}, ran)
+	assert.Equal([]string{NotAllowedText, "Nobody purges.", "Nobody purges.", "Nobody purges."}, api.values("chat.postMessage", "text"))
+	// members are cached
+	assert.Equal([]string{"S1"}, api.values("usergroups.users.list", "usergroup"))
+}
@@ -48,3 +48,8 @@ func WhoMentioned(evt *slack.MessageEvent) []string {
 	}
 	return matches
 }
+
+// submatches returns the submatches of the regular expression in text, nil if it does not match
+func submatches(regex, text string) []string {
+	return regexp.MustCompile(regex).FindStringSubmatch(text)
+}
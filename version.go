@@ -0,0 +1,124 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// VersionInfo describes the build of the bot.
+type VersionInfo struct {
+	Version string
+	// VCS revision, and whether the working tree had local changes
+	Commit   string
+	Modified bool
+	// Time of the commit, or of the build
+	BuildTime string
+	GoVersion string
+}
+
+// BuildVersion returns the version information embedded in the binary by the Go toolchain.
+func BuildVersion() VersionInfo {
+	info := VersionInfo{GoVersion: runtime.Version()}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Version = bi.Main.Version
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Commit = s.Value
+		case "vcs.time":
+			info.BuildTime = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+}
+
+// EnableVersionCommand adds a `version` command reporting the version of the bot, its
+// uptime and since when it is connected. Fields of info left empty, or info itself when
+// nil, are taken from BuildVersion.
+func (b *Bot) EnableVersionCommand(info *VersionInfo) *Bot {
+	build := BuildVersion()
+	if info == nil {
+		info = &build
+	}
+	v := *info
+	if v.Version == "" {
+		v.Version = build.Version
+	}
+	if v.Commit == "" {
+		v.Commit, v.Modified = build.Commit, build.Modified
+	}
+	if v.BuildTime == "" {
+		v.BuildTime = build.BuildTime
+	}
+	if v.GoVersion == "" {
+		v.GoVersion = build.GoVersion
+	}
+	b.Hear(`(?i)^version$`).MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		bot.Reply(evt, bot.versionText(v, time.Now()))
+	})
+	return b
+}
+
+func (b *Bot) versionText(v VersionInfo, now time.Time) string {
+	commit := orDefault(v.Commit, "unknown")
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	if v.Modified {
+		commit += " (modified)"
+	}
+	lines := []string{
+		fmt.Sprintf("*Version:* %s", orDefault(v.Version, "unknown")),
+		fmt.Sprintf("*Commit:* %s", commit),
+	}
+	if v.BuildTime != "" {
+		lines = append(lines, fmt.Sprintf("*Built:* %s", v.BuildTime))
+	}
+	lines = append(lines, fmt.Sprintf("*Go:* %s", v.GoVersion))
+
+	started, connected := b.StartedAt(), b.ConnectedAt()
+	if !started.IsZero() {
+		lines = append(lines, fmt.Sprintf("*Uptime:* %s", now.Sub(started).Round(time.Second)))
+	}
+	if !connected.IsZero() {
+		lines = append(lines, fmt.Sprintf("*Connected since:* %s", FormatDate(connected, DateShortPretty+" at "+DateTime)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StartedAt returns when Serve started, zero before.
+func (b *Bot) StartedAt() time.Time {
+	b.uptimeMu.Lock()
+	defer b.uptimeMu.Unlock()
+	return b.startedAt
+}
+
+// ConnectedAt returns when the bot last connected to Slack, zero before.
+func (b *Bot) ConnectedAt() time.Time {
+	b.uptimeMu.Lock()
+	defer b.uptimeMu.Unlock()
+	return b.connectedAt
+}
+
+func (b *Bot) setStarted(t time.Time) {
+	b.uptimeMu.Lock()
+	b.startedAt = t
+	b.uptimeMu.Unlock()
+}
+
+func (b *Bot) setConnected(t time.Time) {
+	b.uptimeMu.Lock()
+	b.connectedAt = t
+	b.uptimeMu.Unlock()
+}
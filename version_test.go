@@ -0,0 +1,22 @@
+package slackbot
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionText(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(runtime.Version(), BuildVersion().GoVersion)
+
+	bot := New("")
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	bot.setStarted(now.Add(-90 * time.Minute))
+	bot.setConnected(now.Add(-time.Hour))
+	text := bot.versionText(VersionInfo{Version: "v1.2.3", Commit: "0123456789abcdef", Modified: true, GoVersion: "go1.18"}, now)
+	assert.Equal("*Version:* v1.2.3\n*Commit:* 0123456789ab (modified)\n*Go:* go1.18\n*Uptime:* 1h30m0s\n"+
+		"*Connected since:* <!date^1614596400^{date_short_pretty} at {time}|Mon Mar 1 2021 11:00 UTC>", text)
+}
@@ -0,0 +1,55 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/slack-go/slack"
+)
+
+// Watch makes the bot join the channels, given by ID, when it starts so it receives
+// their messages without being invited.
+func (b *Bot) Watch(channels ...string) *Bot {
+	b.RequireScopes("channels:join")
+	b.watchMu.Lock()
+	defer b.watchMu.Unlock()
+	b.watched = append(b.watched, channels...)
+	return b
+}
+
+// WatchMatching makes the bot join every new public channel whose name matches the
+// pattern, e.g. "^incident-".
+func (b *Bot) WatchMatching(pattern string) *Bot {
+	re := regexp.MustCompile(pattern)
+	b.RequireScopes("channels:join", "channels:read")
+	return b.OnEvent("channel_created", func(ctx context.Context, bot *Bot, evt interface{}) {
+		e, ok := evt.(*slack.ChannelCreatedEvent)
+		if !ok || !re.MatchString(e.Channel.Name) {
+			return
+		}
+		if err := bot.join(e.Channel.ID); err != nil {
+			fmt.Printf("Error joining %s: %s\n", e.Channel.Name, err)
+		}
+	})
+}
+
+// joinWatched joins the watched channels.
+func (b *Bot) joinWatched() {
+	b.watchMu.Lock()
+	channels := append([]string(nil), b.watched...)
+	b.watchMu.Unlock()
+	for _, channel := range channels {
+		if err := b.join(channel); err != nil {
+			fmt.Printf("Error joining %s: %s\n", channel, err)
+		}
+	}
+}
+
+// join makes the bot a member of the channel. Joining a channel twice is harmless.
+func (b *Bot) join(channel string) error {
+	return withRetry(func() error {
+		_, _, _, err := b.Client.JoinConversation(channel)
+		return err
+	})
+}
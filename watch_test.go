@@ -0,0 +1,36 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").Watch("C1", "C2")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.join", `{"ok": true, "channel": {"id": "C1"}}`)
+
+	bot.joinWatched()
+	assert.Equal([]string{"C1", "C2"}, api.values("conversations.join", "channel"))
+	assert.True(bot.requiredScopes["channels:join"])
+}
+
+func TestWatchMatching(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").WatchMatching("^incident-")
+	api := newSlackAPI(t, bot)
+	api.respond("conversations.join", `{"ok": true, "channel": {"id": "C1"}}`)
+	ctx := AddBotToContext(context.Background(), bot)
+
+	for _, name := range []string{"incident-42", "random", "old-incident-1"} {
+		evt := &slack.ChannelCreatedEvent{Type: "channel_created"}
+		evt.Channel.ID, evt.Channel.Name = "C-"+name, name
+		bot.handleEvent(ctx, "channel_created", evt)
+	}
+	assert.Equal([]string{"C-incident-42"}, api.values("conversations.join", "channel"))
+	assert.True(bot.requiredScopes["channels:read"])
+}
@@ -0,0 +1,256 @@
+package slackbot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// EndFlow, returned by Step.Next or used as a Goto target, completes the flow.
+const EndFlow = "__END_FLOW__"
+
+// FlowExpiredText is replied when a flow is abandoned for longer than its timeout.
+var FlowExpiredText = "This conversation expired, start over when you are ready."
+
+// Step is a question of a Flow.
+type Step struct {
+	// Name of the answer in the FormAnswers of the flow, and of the step for branching
+	Name   string
+	Prompt string
+	// Accepted answers, case-insensitively, any answer when empty
+	Choices []string
+	// Validate checks the answer, its error is replied and the question stays open
+	Validate func(answer string) error
+	// Goto maps answers to the name of the next step. Answers not in the map go on with
+	// the next declared step.
+	Goto map[string]string
+	// Next, when set, overrides Goto and picks the name of the next step from the answers
+	// so far. Returning an empty name goes on with the next declared step.
+	Next func(answers FormAnswers) string
+}
+
+// Flow is a sequence of questions asked in a thread, one after the other, to the user who
+// started it. Steps run in order, unless a step branches to another with Goto or Next, and
+// the flow completes after its last step or when a step goes to EndFlow. The user may reply
+// "cancel" to abandon it.
+//
+//	intake := &slackbot.Flow{
+//		Steps: []slackbot.Step{
+//			{Name: "kind", Prompt: "Bug or feature?", Choices: []string{"bug", "feature"},
+//				Goto: map[string]string{"feature": "pitch"}},
+//			{Name: "version", Prompt: "Which version are you running?",
+//				Goto: map[string]string{"latest": "summary"}},
+//			{Name: "steps", Prompt: "How can we reproduce it?",
+//				Next: func(slackbot.FormAnswers) string { return "summary" }},
+//			{Name: "pitch", Prompt: "What problem would it solve?"},
+//			{Name: "summary", Prompt: "Describe it in one sentence."},
+//		},
+//		Done: FileTicket,
+//	}
+//	bot.Hear("(?i)^new ticket$").MessageHandler(func(ctx context.Context, bot *slackbot.Bot, evt *slack.MessageEvent) {
+//		bot.StartFlow(evt, intake)
+//	})
+type Flow struct {
+	Steps []Step
+	// Done receives the answers once the flow completes. The message event is the last answer.
+	Done func(ctx context.Context, bot *Bot, evt *slack.MessageEvent, answers FormAnswers)
+	// How long the flow waits for an answer before it expires, DefaultThreadIdle when zero
+	Timeout time.Duration
+}
+
+// Validate checks that the steps are named uniquely and that branches lead to known steps.
+func (f *Flow) Validate() error {
+	if len(f.Steps) == 0 {
+		return fmt.Errorf("flow has no steps")
+	}
+	names := make(map[string]bool)
+	for _, s := range f.Steps {
+		if s.Name == "" || names[s.Name] {
+			return fmt.Errorf("flow step name %q is empty or duplicated", s.Name)
+		}
+		names[s.Name] = true
+	}
+	for _, s := range f.Steps {
+		for answer, target := range s.Goto {
+			if target != EndFlow && !names[target] {
+				return fmt.Errorf("flow step %q goes to unknown step %q on %q", s.Name, target, answer)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Flow) index(name string) int {
+	for i, s := range f.Steps {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// flowRun is a flow being answered by a user.
+type flowRun struct {
+	flow    *Flow
+	thread  *ThreadRouter
+	channel string
+	ts      string
+
+	mu       sync.Mutex
+	step     int
+	answers  FormAnswers
+	finished bool
+}
+
+// StartFlow asks the first question of the flow in the thread of the message, replies of its
+// sender answering it. The flow is compiled onto the ThreadRouter of the thread.
+func (b *Bot) StartFlow(evt *slack.MessageEvent, flow *Flow) error {
+	if err := flow.Validate(); err != nil {
+		return err
+	}
+	ts := evt.ThreadTimestamp
+	if ts == "" {
+		ts = evt.Timestamp
+	}
+	run := &flowRun{flow: flow, thread: b.ThreadRouter(ts), channel: evt.Channel, ts: ts, answers: FormAnswers{}}
+	if flow.Timeout > 0 {
+		run.thread.ExpireAfter(flow.Timeout)
+	}
+	run.thread.OnExpire(func() {
+		run.mu.Lock()
+		expired := !run.finished
+		run.finished = true
+		run.mu.Unlock()
+		if expired {
+			run.say(b, FlowExpiredText)
+		}
+	})
+	run.thread.NewRoute().FromUsers(evt.User).MessageHandler(run.answer)
+	run.ask(b)
+	return nil
+}
+
+func (r *flowRun) say(b *Bot, text string) {
+	_, _ = b.Send(&OutgoingMessage{
+		Channel: r.channel,
+		Text:    text,
+		Params:  slack.PostMessageParameters{AsUser: true, ThreadTimestamp: r.ts},
+	})
+}
+
+func (r *flowRun) ask(b *Bot) {
+	r.mu.Lock()
+	step := r.flow.Steps[r.step]
+	r.mu.Unlock()
+	text := step.Prompt
+	if len(step.Choices) > 0 {
+		text += fmt.Sprintf(" (%s)", strings.Join(step.Choices, ", "))
+	}
+	r.say(b, text)
+}
+
+func (r *flowRun) answer(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	text := strings.TrimSpace(TextFromContext(ctx))
+	if strings.EqualFold(text, "cancel") {
+		r.mu.Lock()
+		r.finished = true
+		r.mu.Unlock()
+		r.thread.Close()
+		r.say(bot, CancelledText)
+		return
+	}
+
+	r.mu.Lock()
+	if r.finished {
+		r.mu.Unlock()
+		return
+	}
+	step := r.flow.Steps[r.step]
+	r.mu.Unlock()
+
+	if len(step.Choices) > 0 {
+		choice := ""
+		for _, c := range step.Choices {
+			if strings.EqualFold(c, text) {
+				choice = c
+			}
+		}
+		if choice == "" {
+			r.say(bot, fmt.Sprintf("Please answer one of: %s.", strings.Join(step.Choices, ", ")))
+			return
+		}
+		text = choice
+	}
+	if step.Validate != nil {
+		if err := step.Validate(text); err != nil {
+			r.say(bot, err.Error())
+			return
+		}
+	}
+
+	r.mu.Lock()
+	r.answers[step.Name] = text
+	next := ""
+	if step.Next != nil {
+		next = step.Next(r.answers)
+	} else {
+		next = step.Goto[text]
+	}
+	switch {
+	case next == EndFlow:
+		r.step = len(r.flow.Steps)
+	case next != "" && r.flow.index(next) >= 0:
+		r.step = r.flow.index(next)
+	default:
+		r.step++
+	}
+	done := r.step >= len(r.flow.Steps)
+	r.finished = done
+	answers := r.answers
+	r.mu.Unlock()
+
+	if !done {
+		r.ask(bot)
+		return
+	}
+	r.thread.Close()
+	if r.flow.Done != nil {
+		r.flow.Done(ctx, bot, evt, answers)
+	}
+}
+
+// ============================================================================
+// User Matcher
+// ============================================================================
+
+// UserMatcher matches the messages sent by some users.
+type UserMatcher struct {
+	users     []string
+	botUserID string
+}
+
+func (um *UserMatcher) Match(ctx context.Context) (bool, context.Context) {
+	evt := MessageFromContext(ctx)
+	if evt == nil {
+		return false, ctx
+	}
+	for _, u := range um.users {
+		if u == evt.User {
+			return true, ctx
+		}
+	}
+	return false, ctx
+}
+
+func (um *UserMatcher) SetBotID(botID string) {
+	um.botUserID = botID
+}
+
+// FromUsers makes the route only match the messages sent by the users.
+func (r *Route) FromUsers(userIDs ...string) *Route {
+	return r.AddMatcher(&UserMatcher{users: userIDs})
+}
@@ -0,0 +1,74 @@
+package slackbot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlow(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	var sent []string
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		assert.Equal("1.0", msg.Params.ThreadTimestamp)
+		sent = append(sent, msg.Text)
+		return false
+	})
+	var result FormAnswers
+	flow := &Flow{
+		Steps: []Step{
+			{Name: "kind", Prompt: "Bug or feature?", Choices: []string{"bug", "feature"}, Goto: map[string]string{"feature": "pitch"}},
+			{Name: "version", Prompt: "Version?", Validate: func(answer string) error {
+				if !strings.HasPrefix(answer, "v") {
+					return errors.New("Versions start with v.")
+				}
+				return nil
+			}, Next: func(FormAnswers) string { return EndFlow }},
+			{Name: "pitch", Prompt: "Why?"},
+		},
+		Done: func(ctx context.Context, bot *Bot, evt *slack.MessageEvent, answers FormAnswers) {
+			result = answers
+		},
+	}
+	assert.NoError(bot.StartFlow(&slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0"}}, flow))
+
+	say := func(user, text string) {
+		evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: user, Text: text, ThreadTimestamp: "1.0"}}
+		bot.handleMessage(AddBotToContext(context.Background(), bot), evt)
+	}
+	say("U1", "question")
+	say("U2", "bug")
+	say("U1", "BUG")
+	say("U1", "1.2")
+	say("U1", "v1.2")
+	assert.Equal([]string{"Bug or feature? (bug, feature)", "Please answer one of: bug, feature.", "Version?", "Versions start with v."}, sent)
+	assert.Equal(FormAnswers{"kind": "bug", "version": "v1.2"}, result)
+}
+
+func TestFlowCancelAndExpire(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("")
+	sent := make(chan string, 10)
+	bot.BeforeSend(func(msg *OutgoingMessage) bool {
+		sent <- msg.Text
+		return false
+	})
+	flow := &Flow{Steps: []Step{{Name: "name", Prompt: "Name?"}}, Timeout: 10 * time.Millisecond}
+	assert.NoError(bot.StartFlow(&slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "1.0"}}, flow))
+	assert.Equal("Name?", <-sent)
+	assert.Equal(FlowExpiredText, <-sent)
+
+	flow.Timeout = 0
+	assert.NoError(bot.StartFlow(&slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Timestamp: "2.0"}}, flow))
+	assert.Equal("Name?", <-sent)
+	bot.handleMessage(AddBotToContext(context.Background(), bot), &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1", Text: "cancel", ThreadTimestamp: "2.0"}})
+	assert.Equal(CancelledText, <-sent)
+
+	assert.Error((&Flow{Steps: []Step{{Name: "a", Goto: map[string]string{"x": "b"}}}}).Validate())
+}
@@ -0,0 +1,192 @@
+package slackbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const workspaceKey = "workspace"
+
+// Workspace holds the settings of a workspace, editable by its admins with the workspace
+// commands.
+type Workspace struct {
+	// Plugins explicitly enabled or disabled, others are enabled
+	Plugins map[string]bool `json:"plugins,omitempty"`
+	// Users administering the bot in the workspace, besides the bot admins
+	Admins []string `json:"admins,omitempty"`
+	// Default locale of the workspace, e.g. "en-US"
+	Locale string `json:"locale,omitempty"`
+	// Channel receiving the alerts of the bot
+	AlertChannel string `json:"alert_channel,omitempty"`
+}
+
+// PluginEnabled returns true unless the plugin is disabled in the workspace.
+func (w *Workspace) PluginEnabled(name string) bool {
+	enabled, ok := w.Plugins[name]
+	return enabled || !ok
+}
+
+// IsAdmin returns true if the user administers the bot in the workspace.
+func (w *Workspace) IsAdmin(userID string) bool {
+	for _, id := range w.Admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultWorkspace sets the settings of workspaces whose admins have not changed them.
+func (b *Bot) DefaultWorkspace(w Workspace) *Bot {
+	b.defaultWorkspace = w
+	return b
+}
+
+// WorkspaceConfig returns the settings of the workspace in context.
+func WorkspaceConfig(ctx context.Context) *Workspace {
+	bot := BotFromContext(ctx)
+	w := bot.defaultWorkspace
+	data, found, err := TeamStore(ctx).Get(workspaceKey)
+	if err != nil {
+		fmt.Printf("Error loading workspace config: %s\n", err)
+	} else if found {
+		w = Workspace{}
+		if err := json.Unmarshal(data, &w); err != nil {
+			fmt.Printf("Error decoding workspace config: %s\n", err)
+			w = bot.defaultWorkspace
+		}
+	}
+	// the defaults are shared, copy before they may be modified
+	w.Plugins = copyPlugins(w.Plugins)
+	w.Admins = append([]string(nil), w.Admins...)
+	return &w
+}
+
+func copyPlugins(plugins map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(plugins))
+	for k, v := range plugins {
+		c[k] = v
+	}
+	return c
+}
+
+// SetWorkspaceConfig saves the settings of the workspace in context.
+func SetWorkspaceConfig(ctx context.Context, w *Workspace) error {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	return TeamStore(ctx).Set(workspaceKey, data, 0)
+}
+
+// Plugin makes the route part of a plugin, so it only matches in workspaces where the plugin
+// is enabled.
+func (r *Route) Plugin(name string) *Route {
+	return r.AddMatcher(&PluginMatcher{plugin: name})
+}
+
+// EnableWorkspaceCommands adds the commands with which admins edit the workspace settings:
+//
+//	workspace
+//	workspace locale <locale>
+//	workspace alerts <#channel>
+//	workspace enable|disable <plugin>
+//	workspace admin add|remove <@user>
+func (b *Bot) EnableWorkspaceCommands() *Bot {
+	b.Hear(`(?i)^workspace$`).WorkspaceAdminOnly().MessageHandler(workspaceShowHandler)
+	b.Hear(workspaceLocaleRegexp).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		locale := submatches(workspaceLocaleRegexp, TextFromContext(ctx))[1]
+		updateWorkspace(ctx, evt, func(w *Workspace) { w.Locale = locale })
+	})
+	b.Hear(workspaceAlertsRegexp).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		channel := submatches(workspaceAlertsRegexp, TextFromContext(ctx))[1]
+		updateWorkspace(ctx, evt, func(w *Workspace) { w.AlertChannel = channel })
+	})
+	b.Hear(workspacePluginRegexp).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		args := submatches(workspacePluginRegexp, TextFromContext(ctx))
+		updateWorkspace(ctx, evt, func(w *Workspace) { w.Plugins[args[2]] = strings.EqualFold(args[1], "enable") })
+	})
+	b.Hear(workspaceAdminRegexp).WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		args := submatches(workspaceAdminRegexp, TextFromContext(ctx))
+		updateWorkspace(ctx, evt, func(w *Workspace) {
+			admins := w.Admins[:0]
+			for _, id := range w.Admins {
+				if id != args[2] {
+					admins = append(admins, id)
+				}
+			}
+			if strings.EqualFold(args[1], "add") {
+				admins = append(admins, args[2])
+			}
+			w.Admins = admins
+		})
+	})
+	return b
+}
+
+const (
+	workspaceLocaleRegexp = `(?i)^workspace locale ([\w-]+)$`
+	workspaceAlertsRegexp = `(?i)^workspace alerts <#(\w+)(?:\|[^>]*)?>$`
+	workspacePluginRegexp = `(?i)^workspace (enable|disable) (\S+)$`
+	workspaceAdminRegexp  = `(?i)^workspace admin (add|remove) <@(\w+)(?:\|[^>]*)?>$`
+)
+
+func updateWorkspace(ctx context.Context, evt *slack.MessageEvent, update func(*Workspace)) {
+	bot := BotFromContext(ctx)
+	w := WorkspaceConfig(ctx)
+	update(w)
+	if err := SetWorkspaceConfig(ctx, w); err != nil {
+		bot.Reply(evt, fmt.Sprintf("Could not save the workspace settings: %s", err))
+		return
+	}
+	workspaceShowHandler(ctx, bot, evt)
+}
+
+func workspaceShowHandler(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+	w := WorkspaceConfig(ctx)
+	lines := []string{"*Workspace settings*"}
+	lines = append(lines, fmt.Sprintf("Locale: %s", orDefault(w.Locale, "_default_")))
+	if w.AlertChannel != "" {
+		lines = append(lines, fmt.Sprintf("Alert channel: <#%s>", w.AlertChannel))
+	} else {
+		lines = append(lines, "Alert channel: _none_")
+	}
+	admins := make([]string, len(w.Admins))
+	for i, id := range w.Admins {
+		admins[i] = "<@" + id + ">"
+	}
+	lines = append(lines, fmt.Sprintf("Admins: %s", orDefault(strings.Join(admins, ", "), "_bot admins only_")))
+	plugins := make([]string, 0, len(w.Plugins))
+	for name, enabled := range w.Plugins {
+		state := "enabled"
+		if !enabled {
+			state = "disabled"
+		}
+		plugins = append(plugins, fmt.Sprintf("`%s` %s", name, state))
+	}
+	sort.Strings(plugins)
+	lines = append(lines, fmt.Sprintf("Plugins: %s", orDefault(strings.Join(plugins, ", "), "_all enabled_")))
+	bot.Reply(evt, strings.Join(lines, "\n"))
+}
+
+// ============================================================================
+// Plugin Matcher
+// ============================================================================
+
+type PluginMatcher struct {
+	plugin    string
+	botUserID string
+}
+
+func (pm *PluginMatcher) Match(ctx context.Context) (bool, context.Context) {
+	return WorkspaceConfig(ctx).PluginEnabled(pm.plugin), ctx
+}
+
+func (pm *PluginMatcher) SetBotID(botID string) {
+	pm.botUserID = botID
+}
@@ -0,0 +1,67 @@
+package slackbot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceConfig(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").DefaultWorkspace(Workspace{Locale: "en-US", Plugins: map[string]bool{"deploy": false}})
+	ctx := AddTeamToContext(AddBotToContext(context.Background(), bot), "T1")
+	evt := &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", User: "U1"}}
+	ctx = AddMessageToContext(ctx, evt)
+
+	w := WorkspaceConfig(ctx)
+	assert.Equal("en-US", w.Locale)
+	assert.False(w.PluginEnabled("deploy"))
+	assert.True(w.PluginEnabled("jira"))
+	matched, _ := (&PluginMatcher{plugin: "deploy"}).Match(ctx)
+	assert.False(matched)
+
+	w.Plugins["deploy"] = true
+	w.Admins = []string{"U1"}
+	assert.NoError(SetWorkspaceConfig(ctx, w))
+	matched, _ = (&PluginMatcher{plugin: "deploy"}).Match(ctx)
+	assert.True(matched)
+	assert.True(WorkspaceConfig(ctx).IsAdmin("U1"))
+	assert.False(bot.defaultWorkspace.Plugins["deploy"])
+
+	// other workspaces keep the defaults
+	other := AddTeamToContext(ctx, "T2")
+	assert.False(WorkspaceConfig(other).IsAdmin("U1"))
+	assert.Equal([]string{"C9"}, submatches(workspaceAlertsRegexp, "workspace alerts <#C9|ops>")[1:])
+}
+
+func TestWorkspaceAdminOnly(t *testing.T) {
+	assert := assert.New(t)
+	bot := New("").SetAdmins("UBOT")
+	api := newSlackAPI(t, bot)
+	bot.EnableWorkspaceCommands()
+	var ran []string
+	bot.Hear("^maintenance$").AdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		ran = append(ran, evt.User+" "+evt.Text)
+	})
+	bot.Hear("^settings$").WorkspaceAdminOnly().MessageHandler(func(ctx context.Context, bot *Bot, evt *slack.MessageEvent) {
+		ran = append(ran, evt.User+" "+evt.Text)
+	})
+	ctx := AddBotToContext(context.Background(), bot)
+	handle := func(team, user, text string) {
+		evt := &slack.MessageEvent{}
+		evt.Channel, evt.Team, evt.User, evt.Text = "C1", team, user, text
+		bot.handleMessage(ctx, evt)
+	}
+
+	handle("T1", "UBOT", "workspace admin add <@U1>")
+	handle("T1", "U1", "settings")
+	handle("T1", "U1", "maintenance")
+	handle("T2", "U1", "settings")
+	handle("T1", "UBOT", "maintenance")
+	// workspace admins are not bot admins
+	assert.Equal([]string{"U1 settings", "UBOT maintenance"}, ran)
+	texts := api.values("chat.postMessage", "text")
+	assert.Equal([]string{AdminOnlyText, WorkspaceAdminOnlyText}, texts[1:])
+}